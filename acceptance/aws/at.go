@@ -123,7 +123,7 @@ func (b *ATBuilder) Run(t *testing.T, l ...*logger.Logger) {
 		}
 	}
 	if b.expectRevert != "" {
-		revert, err := ran.Revert()
+		revert, err := ran.Revert(cenv.LookupCommandFunc())
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -82,15 +82,22 @@ func TestContainerTask(t *testing.T) {
 	t.Run("update", func(t *testing.T) {
 		Template("update containertask name=my-service cluster=my-cluster-name deployment-name=prod desired-count=5").
 			Mock(&ecsMock{
+				DescribeServicesFunc: func(param0 *ecs.DescribeServicesInput) (*ecs.DescribeServicesOutput, error) {
+					return &ecs.DescribeServicesOutput{Services: []*ecs.Service{{ServiceName: String("prod"), TaskDefinition: String("my-old-service"), DesiredCount: Int64(2)}}}, nil
+				},
 				UpdateServiceFunc: func(param0 *ecs.UpdateServiceInput) (*ecs.UpdateServiceOutput, error) {
 					return nil, nil
 				},
-			}).ExpectInput("UpdateService", &ecs.UpdateServiceInput{
+			}).ExpectInput("DescribeServices", &ecs.DescribeServicesInput{
+			Cluster:  String("my-cluster-name"),
+			Services: []*string{String("prod")},
+		}).ExpectInput("UpdateService", &ecs.UpdateServiceInput{
 			TaskDefinition: String("my-service"),
 			Cluster:        String("my-cluster-name"),
 			Service:        String("prod"),
 			DesiredCount:   Int64(5),
-		}).ExpectCalls("UpdateService").Run(t)
+		}).ExpectCalls("DescribeServices", "UpdateService").
+			ExpectRevert("update containertask cluster=my-cluster-name deployment-name=prod desired-count=2 name=my-old-service").Run(t)
 	})
 
 	t.Run("attach", func(t *testing.T) {
@@ -84,6 +84,20 @@ func TestInstance(t *testing.T) {
 
 	t.Run("update", func(t *testing.T) {
 		Template("update instance id=id-1234 type=t2.micro lock=true").Mock(&ec2Mock{
+			DescribeInstanceAttributeFunc: func(param0 *ec2.DescribeInstanceAttributeInput) (*ec2.DescribeInstanceAttributeOutput, error) {
+				if got, want := StringValue(param0.InstanceId), "id-1234"; got != want {
+					t.Fatalf("got %s, want %s", got, want)
+				}
+				switch StringValue(param0.Attribute) {
+				case "instanceType":
+					return &ec2.DescribeInstanceAttributeOutput{InstanceType: &ec2.AttributeValue{Value: String("t2.nano")}}, nil
+				case "disableApiTermination":
+					return &ec2.DescribeInstanceAttributeOutput{DisableApiTermination: &ec2.AttributeBooleanValue{Value: Bool(false)}}, nil
+				default:
+					t.Fatalf("unexpected attribute %s", StringValue(param0.Attribute))
+				}
+				return nil, nil
+			},
 			ModifyInstanceAttributeFunc: func(param0 *ec2.ModifyInstanceAttributeInput) (*ec2.ModifyInstanceAttributeOutput, error) {
 				return nil, nil
 			},
@@ -91,8 +105,9 @@ func TestInstance(t *testing.T) {
 			InstanceId:            String("id-1234"),
 			InstanceType:          &ec2.AttributeValue{Value: String("t2.micro")},
 			DisableApiTermination: &ec2.AttributeBooleanValue{Value: Bool(true)},
-		}).
-			ExpectCalls("ModifyInstanceAttribute").Run(t)
+		}).IgnoreInput("DescribeInstanceAttribute").
+			ExpectCalls("DescribeInstanceAttribute", "DescribeInstanceAttribute", "ModifyInstanceAttribute").
+			ExpectRevert("update instance id=id-1234 lock=false type=t2.nano").Run(t)
 	})
 
 	t.Run("delete", func(t *testing.T) {
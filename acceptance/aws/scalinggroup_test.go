@@ -29,9 +29,26 @@ func TestScalingGroup(t *testing.T) {
 
 	t.Run("update", func(t *testing.T) {
 		Template("update scalinggroup name=new-autoscaling launchconfiguration=config max-size=12 min-size=10 subnets=sub_1,sub_2 cooldown=3 desired-capacity=12 healthcheck-grace-period=4 healthcheck-type=healthy new-instances-protected=true").Mock(&autoscalingMock{
+			DescribeAutoScalingGroupsFunc: func(input *autoscaling.DescribeAutoScalingGroupsInput) (*autoscaling.DescribeAutoScalingGroupsOutput, error) {
+				return &autoscaling.DescribeAutoScalingGroupsOutput{AutoScalingGroups: []*autoscaling.Group{{
+					AutoScalingGroupName:             String("new-autoscaling"),
+					LaunchConfigurationName:          String("old-config"),
+					MaxSize:                          Int64(2),
+					MinSize:                          Int64(1),
+					DefaultCooldown:                  Int64(300),
+					DesiredCapacity:                  Int64(1),
+					HealthCheckGracePeriod:           Int64(0),
+					HealthCheckType:                  String("EC2"),
+					NewInstancesProtectedFromScaleIn: Bool(false),
+					VPCZoneIdentifier:                String("sub_3,sub_4"),
+				}}}, nil
+			},
 			UpdateAutoScalingGroupFunc: func(input *autoscaling.UpdateAutoScalingGroupInput) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
 				return nil, nil
 			}}).
+			ExpectInput("DescribeAutoScalingGroups", &autoscaling.DescribeAutoScalingGroupsInput{
+				AutoScalingGroupNames: []*string{String("new-autoscaling")},
+			}).
 			ExpectInput("UpdateAutoScalingGroup", &autoscaling.UpdateAutoScalingGroupInput{
 				AutoScalingGroupName:             String("new-autoscaling"),
 				LaunchConfigurationName:          String("config"),
@@ -43,7 +60,8 @@ func TestScalingGroup(t *testing.T) {
 				HealthCheckType:                  String("healthy"),
 				NewInstancesProtectedFromScaleIn: Bool(true),
 				VPCZoneIdentifier:                String("sub_1,sub_2"),
-			}).ExpectCalls("UpdateAutoScalingGroup").Run(t)
+			}).ExpectCalls("DescribeAutoScalingGroups", "UpdateAutoScalingGroup").
+			ExpectRevert("update scalinggroup cooldown=300 desired-capacity=1 healthcheck-grace-period=0 healthcheck-type=EC2 launchconfiguration=old-config max-size=2 min-size=1 name=new-autoscaling new-instances-protected=false subnets=[sub_3,sub_4]").Run(t)
 	})
 
 	t.Run("delete", func(t *testing.T) {
@@ -60,13 +60,20 @@ func TestSubnet(t *testing.T) {
 
 	t.Run("update", func(t *testing.T) {
 		Template("update subnet id=any-subnet-id public=true").Mock(&ec2Mock{
+			DescribeSubnetsFunc: func(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+				return &ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{{SubnetId: String("any-subnet-id"), MapPublicIpOnLaunch: Bool(false)}}}, nil
+			},
 			ModifySubnetAttributeFunc: func(input *ec2.ModifySubnetAttributeInput) (*ec2.ModifySubnetAttributeOutput, error) {
 				return nil, nil
 			}}).
+			ExpectInput("DescribeSubnets", &ec2.DescribeSubnetsInput{
+				SubnetIds: []*string{String("any-subnet-id")},
+			}).
 			ExpectInput("ModifySubnetAttribute", &ec2.ModifySubnetAttributeInput{
 				MapPublicIpOnLaunch: &ec2.AttributeBooleanValue{Value: Bool(true)},
 				SubnetId:            String("any-subnet-id"),
-			}).ExpectCalls("ModifySubnetAttribute").Run(t)
+			}).ExpectCalls("DescribeSubnets", "ModifySubnetAttribute").
+			ExpectRevert("update subnet id=any-subnet-id public=false").Run(t)
 	})
 
 	t.Run("delete", func(t *testing.T) {
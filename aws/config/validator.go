@@ -134,6 +134,16 @@ func allRegions() []string {
 	return regions
 }
 
+// StandardRegions returns the regions of the standard AWS partition (i.e. China and GovCloud excluded)
+func StandardRegions() []string {
+	var regions sort.StringSlice
+	for id := range endpoints.AwsPartition().Regions() {
+		regions = append(regions, id)
+	}
+	sort.Sort(regions)
+	return regions
+}
+
 func IsValidProfile(given string) bool {
 	return stringInSlice(given, AllProfiles())
 }
@@ -17,6 +17,7 @@ limitations under the License.
 
 package awsconv
 
+import "sort"
 import "github.com/wallix/awless/cloud"
 import "github.com/wallix/awless/cloud/properties"
 
@@ -516,3 +517,21 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 	//Queue
 	cloud.Queue: {}, //Manually set
 }
+
+// ResourceTypes returns the resource types converted from AWS, in alphabetical order
+func ResourceTypes() (types []string) {
+	for typ := range awsResourcesDef {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return
+}
+
+// ResourceProperties returns the properties converted from AWS for a resource type, in alphabetical order
+func ResourceProperties(resourceType string) (props []string) {
+	for prop := range awsResourcesDef[resourceType] {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+	return
+}
@@ -1,15 +1,33 @@
 package awsdoc
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 func TemplateParamsDoc(action, entity, param string) (string, bool) {
 	if doc, ok := manualParamsDoc[action+"."+entity][param]; ok {
 		return doc, ok
 	}
 	doc, ok := generatedParamsDoc[action+"."+entity][param]
+	if !ok && action == "check" {
+		return genericCheckParamDoc(entity, param), true
+	}
 	return doc, ok
 }
 
+// genericCheckParamDoc documents the params of the generic `check <entity>`, checking the synced properties of the entity
+func genericCheckParamDoc(entity, param string) string {
+	switch param {
+	case "id":
+		return fmt.Sprintf("The ID of the %s to check", entity)
+	case "timeout":
+		return fmt.Sprintf("The time in seconds to wait for the %s to have the expected value", entity)
+	default:
+		return fmt.Sprintf("The expected value of the '%s' property of the %s (or 'not-found')", param, entity)
+	}
+}
+
 func TemplateParamsDocWithEnums(action, entity, param string) (string, bool) {
 	var suffix string
 	if enum, ok := EnumDoc[action+"."+entity+"."+param]; ok && len(enum) > 0 && strings.TrimSpace(enum[0]) != "" {
@@ -53,7 +53,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.instance.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[instance]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Ec2.DescribeInstancesPages(&ec2.DescribeInstancesInput{},
@@ -86,7 +86,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.subnet.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[subnet]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Ec2.DescribeSubnets(&ec2.DescribeSubnetsInput{})
@@ -112,7 +112,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.vpc.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[vpc]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Ec2.DescribeVpcs(&ec2.DescribeVpcsInput{})
@@ -138,7 +138,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.keypair.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[keypair]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Ec2.DescribeKeyPairs(&ec2.DescribeKeyPairsInput{})
@@ -164,7 +164,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.securitygroup.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[securitygroup]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Ec2.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{})
@@ -190,7 +190,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.volume.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[volume]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Ec2.DescribeVolumesPages(&ec2.DescribeVolumesInput{},
@@ -221,7 +221,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.internetgateway.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[internetgateway]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Ec2.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{})
@@ -247,7 +247,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.natgateway.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[natgateway]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Ec2.DescribeNatGateways(&ec2.DescribeNatGatewaysInput{})
@@ -273,7 +273,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.routetable.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[routetable]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Ec2.DescribeRouteTables(&ec2.DescribeRouteTablesInput{})
@@ -299,7 +299,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.availabilityzone.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[availabilityzone]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Ec2.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{})
@@ -325,7 +325,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.image.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[image]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Ec2.DescribeImages(&ec2.DescribeImagesInput{Owners: []*string{awssdk.String("self")}})
@@ -351,7 +351,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.importimagetask.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[importimagetask]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Ec2.DescribeImportImageTasks(&ec2.DescribeImportImageTasksInput{})
@@ -377,7 +377,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.elasticip.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[elasticip]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Ec2.DescribeAddresses(&ec2.DescribeAddressesInput{})
@@ -403,7 +403,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.snapshot.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[snapshot]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Ec2.DescribeSnapshotsPages(&ec2.DescribeSnapshotsInput{OwnerIds: []*string{awssdk.String("self")}},
@@ -434,7 +434,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.networkinterface.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[networkinterface]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Ec2.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{})
@@ -460,7 +460,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.loadbalancer.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[loadbalancer]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Elbv2.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{},
@@ -491,7 +491,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.targetgroup.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[targetgroup]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Elbv2.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{})
@@ -517,7 +517,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.database.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[database]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Rds.DescribeDBInstancesPages(&rds.DescribeDBInstancesInput{},
@@ -548,7 +548,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.dbsubnetgroup.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[dbsubnetgroup]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Rds.DescribeDBSubnetGroupsPages(&rds.DescribeDBSubnetGroupsInput{},
@@ -579,7 +579,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.launchconfiguration.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[launchconfiguration]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Autoscaling.DescribeLaunchConfigurationsPages(&autoscaling.DescribeLaunchConfigurationsInput{},
@@ -610,7 +610,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.scalinggroup.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[scalinggroup]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Autoscaling.DescribeAutoScalingGroupsPages(&autoscaling.DescribeAutoScalingGroupsInput{},
@@ -641,7 +641,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.scalingpolicy.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[scalingpolicy]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Autoscaling.DescribePoliciesPages(&autoscaling.DescribePoliciesInput{},
@@ -672,7 +672,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.repository.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[repository]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Ecr.DescribeRepositoriesPages(&ecr.DescribeRepositoriesInput{},
@@ -703,7 +703,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.infra.certificate.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[certificate]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Acm.ListCertificatesPages(&acm.ListCertificatesInput{},
@@ -740,7 +740,7 @@ func BuildAccessFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.access.group.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource access[group]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Iam.GetAccountAuthorizationDetailsPages(&iam.GetAccountAuthorizationDetailsInput{Filter: []*string{awssdk.String(iam.EntityTypeGroup)}},
@@ -771,7 +771,7 @@ func BuildAccessFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.access.role.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource access[role]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Iam.GetAccountAuthorizationDetailsPages(&iam.GetAccountAuthorizationDetailsInput{Filter: []*string{awssdk.String(iam.EntityTypeRole)}},
@@ -802,7 +802,7 @@ func BuildAccessFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.access.instanceprofile.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource access[instanceprofile]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Iam.ListInstanceProfilesPages(&iam.ListInstanceProfilesInput{},
@@ -833,7 +833,7 @@ func BuildAccessFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.access.mfadevice.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource access[mfadevice]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Iam.ListVirtualMFADevicesPages(&iam.ListVirtualMFADevicesInput{},
@@ -876,7 +876,7 @@ func BuildMessagingFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.messaging.subscription.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource messaging[subscription]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Sns.ListSubscriptionsPages(&sns.ListSubscriptionsInput{},
@@ -907,7 +907,7 @@ func BuildMessagingFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.messaging.topic.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource messaging[topic]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Sns.ListTopicsPages(&sns.ListTopicsInput{},
@@ -944,7 +944,7 @@ func BuildDnsFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.dns.zone.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource dns[zone]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Route53.ListHostedZonesPages(&route53.ListHostedZonesInput{},
@@ -981,7 +981,7 @@ func BuildLambdaFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.lambda.function.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource lambda[function]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Lambda.ListFunctionsPages(&lambda.ListFunctionsInput{},
@@ -1018,7 +1018,7 @@ func BuildMonitoringFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.monitoring.metric.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource monitoring[metric]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Cloudwatch.ListMetricsPages(&cloudwatch.ListMetricsInput{},
@@ -1049,7 +1049,7 @@ func BuildMonitoringFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.monitoring.alarm.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource monitoring[alarm]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Cloudwatch.DescribeAlarmsPages(&cloudwatch.DescribeAlarmsInput{},
@@ -1086,7 +1086,7 @@ func BuildCdnFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.cdn.distribution.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource cdn[distribution]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Cloudfront.ListDistributionsPages(&cloudfront.ListDistributionsInput{},
@@ -1123,7 +1123,7 @@ func BuildCloudformationFetchFuncs(conf *Config) fetch.Funcs {
 
 		if !conf.getBoolDefaultTrue("aws.cloudformation.stack.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource cloudformation[stack]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 		var badResErr error
 		err := conf.APIs.Cloudformation.DescribeStacksPages(&cloudformation.DescribeStacksInput{},
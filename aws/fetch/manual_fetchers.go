@@ -31,7 +31,7 @@ func addManualInfraFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 
 		if !conf.getBoolDefaultTrue("aws.infra.containerinstance.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[containerinstance]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		var clusterArns []*string
@@ -85,7 +85,7 @@ func addManualInfraFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 
 		if !conf.getBoolDefaultTrue("aws.infra.container.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[container]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		var tasks []*ecs.Task
@@ -144,7 +144,7 @@ func addManualInfraFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 
 		if !conf.getBoolDefaultTrue("aws.infra.containertask.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[containertask]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		type resStruct struct {
@@ -275,7 +275,7 @@ func addManualInfraFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 
 		if !conf.getBoolDefaultTrue("aws.infra.containercluster.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[containercluster]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		var clusterNames []*string
@@ -312,7 +312,7 @@ func addManualInfraFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 
 		if !conf.getBoolDefaultTrue("aws.infra.listener.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource infra[listener]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		errc := make(chan error)
@@ -376,7 +376,7 @@ func addManualAccessFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 
 		if !conf.getBoolDefaultTrue("aws.access.user.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource access[user]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		var wg sync.WaitGroup
@@ -465,7 +465,7 @@ func addManualAccessFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 
 		if !conf.getBoolDefaultTrue("aws.access.policy.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource access[policy]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		errC := make(chan error)
@@ -532,7 +532,7 @@ func addManualAccessFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 
 		if !conf.getBoolDefaultTrue("aws.access.accesskey.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource access[accesskey]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		var wg sync.WaitGroup
@@ -617,7 +617,7 @@ func addManualStorageFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 
 		if !conf.getBoolDefaultTrue("aws.storage.bucket.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource storage[bucket]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		bucketM := &sync.Mutex{}
@@ -651,7 +651,7 @@ func addManualStorageFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 
 		if !conf.getBoolDefaultTrue("aws.storage.s3object.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource storage[s3object]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		var wg sync.WaitGroup
@@ -681,7 +681,7 @@ func addManualMessagingFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 
 		if !conf.getBoolDefaultTrue("aws.messaging.queue.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource messaging[queue]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		out, err := conf.APIs.Sqs.ListQueues(&sqs.ListQueuesInput{})
@@ -784,7 +784,7 @@ func addManualDnsFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 
 		if !conf.getBoolDefaultTrue("aws.dns.record.sync") && !getBoolFromContext(ctx, "force") {
 			conf.Log.Verbose("sync: *disabled* for resource dns[record]")
-			return resources, objects, nil
+			return resources, objects, fetch.ErrDisabled
 		}
 
 		filters := getFiltersFromContext(ctx)
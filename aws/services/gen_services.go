@@ -387,6 +387,13 @@ func (s *Infra) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			allErrors.Add(ee)
 		}
 	}
+	for _, status := range gph.FetchStatuses() {
+		for _, e := range *fetch.WrapError(status.Err) {
+			if ee, ok := e.(awserr.RequestFailure); ok && ee.Message() == accessDenied {
+				status.Err = cloud.ErrFetchAccessDenied
+			}
+		}
+	}
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -1055,7 +1062,15 @@ func (s *Infra) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 
 func (s *Infra) FetchByType(ctx context.Context, t string) (cloud.GraphAPI, error) {
 	defer s.fetcher.Reset()
-	return s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	gph, err := s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	if err != nil {
+		return gph, err
+	}
+	objects, err := s.fetcher.Get(t + "_objects")
+	if err != nil {
+		return gph, err
+	}
+	return gph, addParentsOfType(gph, objects, s.region, t)
 }
 
 func (s *Infra) IsSyncDisabled() bool {
@@ -1143,6 +1158,13 @@ func (s *Access) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			allErrors.Add(ee)
 		}
 	}
+	for _, status := range gph.FetchStatuses() {
+		for _, e := range *fetch.WrapError(status.Err) {
+			if ee, ok := e.(awserr.RequestFailure); ok && ee.Message() == accessDenied {
+				status.Err = cloud.ErrFetchAccessDenied
+			}
+		}
+	}
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -1327,7 +1349,15 @@ func (s *Access) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 
 func (s *Access) FetchByType(ctx context.Context, t string) (cloud.GraphAPI, error) {
 	defer s.fetcher.Reset()
-	return s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	gph, err := s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	if err != nil {
+		return gph, err
+	}
+	objects, err := s.fetcher.Get(t + "_objects")
+	if err != nil {
+		return gph, err
+	}
+	return gph, addParentsOfType(gph, objects, s.region, t)
 }
 
 func (s *Access) IsSyncDisabled() bool {
@@ -1406,6 +1436,13 @@ func (s *Storage) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			allErrors.Add(ee)
 		}
 	}
+	for _, status := range gph.FetchStatuses() {
+		for _, e := range *fetch.WrapError(status.Err) {
+			if ee, ok := e.(awserr.RequestFailure); ok && ee.Message() == accessDenied {
+				status.Err = cloud.ErrFetchAccessDenied
+			}
+		}
+	}
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -1480,7 +1517,15 @@ func (s *Storage) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 
 func (s *Storage) FetchByType(ctx context.Context, t string) (cloud.GraphAPI, error) {
 	defer s.fetcher.Reset()
-	return s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	gph, err := s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	if err != nil {
+		return gph, err
+	}
+	objects, err := s.fetcher.Get(t + "_objects")
+	if err != nil {
+		return gph, err
+	}
+	return gph, addParentsOfType(gph, objects, s.region, t)
 }
 
 func (s *Storage) IsSyncDisabled() bool {
@@ -1564,6 +1609,13 @@ func (s *Messaging) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			allErrors.Add(ee)
 		}
 	}
+	for _, status := range gph.FetchStatuses() {
+		for _, e := range *fetch.WrapError(status.Err) {
+			if ee, ok := e.(awserr.RequestFailure); ok && ee.Message() == accessDenied {
+				status.Err = cloud.ErrFetchAccessDenied
+			}
+		}
+	}
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -1660,7 +1712,15 @@ func (s *Messaging) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 
 func (s *Messaging) FetchByType(ctx context.Context, t string) (cloud.GraphAPI, error) {
 	defer s.fetcher.Reset()
-	return s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	gph, err := s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	if err != nil {
+		return gph, err
+	}
+	objects, err := s.fetcher.Get(t + "_objects")
+	if err != nil {
+		return gph, err
+	}
+	return gph, addParentsOfType(gph, objects, s.region, t)
 }
 
 func (s *Messaging) IsSyncDisabled() bool {
@@ -1739,6 +1799,13 @@ func (s *Dns) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			allErrors.Add(ee)
 		}
 	}
+	for _, status := range gph.FetchStatuses() {
+		for _, e := range *fetch.WrapError(status.Err) {
+			if ee, ok := e.(awserr.RequestFailure); ok && ee.Message() == accessDenied {
+				status.Err = cloud.ErrFetchAccessDenied
+			}
+		}
+	}
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -1813,7 +1880,15 @@ func (s *Dns) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 
 func (s *Dns) FetchByType(ctx context.Context, t string) (cloud.GraphAPI, error) {
 	defer s.fetcher.Reset()
-	return s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	gph, err := s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	if err != nil {
+		return gph, err
+	}
+	objects, err := s.fetcher.Get(t + "_objects")
+	if err != nil {
+		return gph, err
+	}
+	return gph, addParentsOfType(gph, objects, s.region, t)
 }
 
 func (s *Dns) IsSyncDisabled() bool {
@@ -1891,6 +1966,13 @@ func (s *Lambda) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			allErrors.Add(ee)
 		}
 	}
+	for _, status := range gph.FetchStatuses() {
+		for _, e := range *fetch.WrapError(status.Err) {
+			if ee, ok := e.(awserr.RequestFailure); ok && ee.Message() == accessDenied {
+				status.Err = cloud.ErrFetchAccessDenied
+			}
+		}
+	}
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -1943,7 +2025,15 @@ func (s *Lambda) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 
 func (s *Lambda) FetchByType(ctx context.Context, t string) (cloud.GraphAPI, error) {
 	defer s.fetcher.Reset()
-	return s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	gph, err := s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	if err != nil {
+		return gph, err
+	}
+	objects, err := s.fetcher.Get(t + "_objects")
+	if err != nil {
+		return gph, err
+	}
+	return gph, addParentsOfType(gph, objects, s.region, t)
 }
 
 func (s *Lambda) IsSyncDisabled() bool {
@@ -2022,6 +2112,13 @@ func (s *Monitoring) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			allErrors.Add(ee)
 		}
 	}
+	for _, status := range gph.FetchStatuses() {
+		for _, e := range *fetch.WrapError(status.Err) {
+			if ee, ok := e.(awserr.RequestFailure); ok && ee.Message() == accessDenied {
+				status.Err = cloud.ErrFetchAccessDenied
+			}
+		}
+	}
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -2096,7 +2193,15 @@ func (s *Monitoring) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 
 func (s *Monitoring) FetchByType(ctx context.Context, t string) (cloud.GraphAPI, error) {
 	defer s.fetcher.Reset()
-	return s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	gph, err := s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	if err != nil {
+		return gph, err
+	}
+	objects, err := s.fetcher.Get(t + "_objects")
+	if err != nil {
+		return gph, err
+	}
+	return gph, addParentsOfType(gph, objects, s.region, t)
 }
 
 func (s *Monitoring) IsSyncDisabled() bool {
@@ -2174,6 +2279,13 @@ func (s *Cdn) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			allErrors.Add(ee)
 		}
 	}
+	for _, status := range gph.FetchStatuses() {
+		for _, e := range *fetch.WrapError(status.Err) {
+			if ee, ok := e.(awserr.RequestFailure); ok && ee.Message() == accessDenied {
+				status.Err = cloud.ErrFetchAccessDenied
+			}
+		}
+	}
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -2226,7 +2338,15 @@ func (s *Cdn) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 
 func (s *Cdn) FetchByType(ctx context.Context, t string) (cloud.GraphAPI, error) {
 	defer s.fetcher.Reset()
-	return s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	gph, err := s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	if err != nil {
+		return gph, err
+	}
+	objects, err := s.fetcher.Get(t + "_objects")
+	if err != nil {
+		return gph, err
+	}
+	return gph, addParentsOfType(gph, objects, s.region, t)
 }
 
 func (s *Cdn) IsSyncDisabled() bool {
@@ -2304,6 +2424,13 @@ func (s *Cloudformation) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			allErrors.Add(ee)
 		}
 	}
+	for _, status := range gph.FetchStatuses() {
+		for _, e := range *fetch.WrapError(status.Err) {
+			if ee, ok := e.(awserr.RequestFailure); ok && ee.Message() == accessDenied {
+				status.Err = cloud.ErrFetchAccessDenied
+			}
+		}
+	}
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -2356,7 +2483,15 @@ func (s *Cloudformation) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 
 func (s *Cloudformation) FetchByType(ctx context.Context, t string) (cloud.GraphAPI, error) {
 	defer s.fetcher.Reset()
-	return s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	gph, err := s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	if err != nil {
+		return gph, err
+	}
+	objects, err := s.fetcher.Get(t + "_objects")
+	if err != nil {
+		return gph, err
+	}
+	return gph, addParentsOfType(gph, objects, s.region, t)
 }
 
 func (s *Cloudformation) IsSyncDisabled() bool {
@@ -18,7 +18,13 @@ package awsservices
 
 import (
 	"errors"
+	"fmt"
+	gosync "sync"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/wallix/awless/aws/spec"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/graph"
@@ -26,6 +32,16 @@ import (
 	"github.com/wallix/awless/sync"
 )
 
+// servicesFactory keeps the init configuration to build the services of other regions and profiles
+var servicesFactory struct {
+	mu                   gosync.Mutex
+	profile              string
+	extraConf            map[string]interface{}
+	log                  *logger.Logger
+	enableNetworkMonitor bool
+	sessions             map[string]*session.Session
+}
+
 var (
 	AccessService, InfraService, StorageService, MessagingService, DnsService, LambdaService, MonitoringService, CdnService, CloudformationService cloud.Service
 )
@@ -63,6 +79,12 @@ func Init(profile, region string, extraConf map[string]interface{}, log *logger.
 	cloud.ServiceRegistry[CdnService.Name()] = CdnService
 	cloud.ServiceRegistry[CloudformationService.Name()] = CloudformationService
 
+	servicesFactory.mu.Lock()
+	servicesFactory.profile, servicesFactory.extraConf, servicesFactory.log = profile, extraConf, log
+	servicesFactory.enableNetworkMonitor = enableNetworkMonitor
+	servicesFactory.sessions = map[string]*session.Session{profile: sess}
+	servicesFactory.mu.Unlock()
+
 	awsspec.CommandFactory = &awsspec.AWSFactory{
 		Log:  log,
 		Sess: sess,
@@ -78,6 +100,71 @@ func Init(profile, region string, extraConf map[string]interface{}, log *logger.
 	return nil
 }
 
+// ServicesFor returns all the services of the given profile bound to the given region,
+// sharing the session resolved once per profile (i.e. MFA and assumed role credentials)
+func ServicesFor(profile, region string) ([]cloud.Service, error) {
+	f := &servicesFactory
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.sessions == nil {
+		return nil, errors.New("cloud services not initialized")
+	}
+	sess, ok := f.sessions[profile]
+	if !ok {
+		var err error
+		sb := newSessionResolver().withRegion(region).withProfile(profile).withNetworkMonitor(f.enableNetworkMonitor).withLogger(f.log)
+		if sess, err = sb.resolve(); err != nil {
+			return nil, fmt.Errorf("profile %s: %s", profile, err)
+		}
+		if _, err = sess.Config.Credentials.Get(); err != nil {
+			return nil, fmt.Errorf("profile %s: %s", profile, err)
+		}
+		f.sessions[profile] = sess
+	}
+	sess = sess.Copy(&awssdk.Config{Region: awssdk.String(region)})
+	return []cloud.Service{
+		NewAccess(sess, profile, f.extraConf, f.log),
+		NewInfra(sess, profile, f.extraConf, f.log),
+		NewStorage(sess, profile, f.extraConf, f.log),
+		NewMessaging(sess, profile, f.extraConf, f.log),
+		NewDns(sess, profile, f.extraConf, f.log),
+		NewLambda(sess, profile, f.extraConf, f.log),
+		NewMonitoring(sess, profile, f.extraConf, f.log),
+		NewCdn(sess, profile, f.extraConf, f.log),
+		NewCloudformation(sess, profile, f.extraConf, f.log),
+	}, nil
+}
+
+// AccountOf returns the account ID of the credentials used by the given services (i.e. from ServicesFor)
+func AccountOf(services []cloud.Service) (string, error) {
+	for _, srv := range services {
+		if access, ok := srv.(*Access); ok {
+			identity, err := access.GetIdentity()
+			if err != nil {
+				return "", err
+			}
+			return identity.Account, nil
+		}
+	}
+	return "", errors.New("no access service to get the account from")
+}
+
+// S3APIFor returns a S3 client with the session of the current profile. With an endpoint (i.e. a MinIO server),
+// the client targets this S3 compatible store, with path style addressing of buckets
+func S3APIFor(endpoint string) (s3iface.S3API, error) {
+	f := &servicesFactory
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sess, ok := f.sessions[f.profile]
+	if !ok {
+		return nil, errors.New("cloud services not initialized")
+	}
+	if endpoint != "" {
+		sess = sess.Copy(&awssdk.Config{Endpoint: awssdk.String(endpoint), S3ForcePathStyle: awssdk.Bool(true)})
+	}
+	return s3.New(sess), nil
+}
+
 func getBool(m map[string]interface{}, key string, def bool) bool {
 	if b, ok := m[key].(bool); ok {
 		return b
@@ -17,11 +17,13 @@ limitations under the License.
 package awsservices
 
 import (
+	"encoding/base64"
 	"regexp"
 	"strings"
 	"sync"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/wallix/awless/cloud"
 )
@@ -212,3 +214,17 @@ func (s *Access) GetUserPolicies(username string) (*UserPolicies, error) {
 
 	return all, nil
 }
+
+func (s *Infra) InstanceConsoleOutput(id string) (string, error) {
+	out, err := s.GetConsoleOutput(&ec2.GetConsoleOutputInput{
+		InstanceId: awssdk.String(id),
+	})
+	if err != nil {
+		return "", err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(awssdk.StringValue(out.Output))
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
@@ -279,6 +279,27 @@ func (fb funcBuilder) addRelationListWithField() addParentFn {
 	}
 }
 
+// addParentsOfType adds the relations of the resources of a type fetched alone, from their fetched objects
+func addParentsOfType(gph *graph.Graph, objects interface{}, region, resourceType string) error {
+	fns, ok := addParentsFns[resourceType]
+	if !ok || objects == nil {
+		return nil
+	}
+	list := reflect.ValueOf(objects)
+	if list.Kind() != reflect.Slice {
+		return fmt.Errorf("add parents of %s: not a list of objects: %T", resourceType, objects)
+	}
+	snap := gph.AsRDFGraphSnaphot()
+	for i := 0; i < list.Len(); i++ {
+		for _, fn := range fns {
+			if err := fn(gph, snap, region, list.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func verifyValidStructField(i interface{}, name string) (reflect.Value, error) {
 	value := reflect.ValueOf(i)
 	if value.Kind() != reflect.Ptr {
@@ -48,6 +48,7 @@ import (
 	"github.com/wallix/awless/fetch"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/graph/resourcetest"
+	tstore "github.com/wallix/triplestore"
 )
 
 func TestBuildAccessRdfGraph(t *testing.T) {
@@ -732,6 +733,20 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 	}
 
 	compareResources(t, g, resources, expected, expectedChildren, expectedAppliedOn)
+
+	t.Run("fetch by type with relations", func(t *testing.T) {
+		g, err := InfraService.FetchByType(context.Background(), "instance")
+		if err != nil {
+			t.Fatal(err)
+		}
+		snap := g.(*graph.Graph).AsRDFGraphSnaphot()
+		if got := snap.WithPredObj(rdf.ParentOf, tstore.Resource("inst_4")); len(got) != 1 || got[0].Subject() != "sub_3" {
+			t.Fatalf("got %v, want sub_3 parent", got)
+		}
+		if got := snap.WithPredObj(rdf.ApplyOn, tstore.Resource("inst_4")); len(got) != 3 {
+			t.Fatalf("got %v, want 2 security groups and keypair", got)
+		}
+	})
 }
 
 func TestBuildStorageRdfGraph(t *testing.T) {
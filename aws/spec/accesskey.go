@@ -66,6 +66,10 @@ func (cmd *CreateAccesskey) ParamsSpec() params.Spec {
 	return builder.Done()
 }
 
+func (cmd *CreateAccesskey) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "accesskey", "id", result, revertParam("user", params["user"])), nil
+}
+
 func (cmd *CreateAccesskey) AfterRun(renv env.Running, output interface{}) error {
 	accessKey := output.(*iam.CreateAccessKeyOutput).AccessKey
 	if !BoolValue(cmd.Save) {
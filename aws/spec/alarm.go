@@ -61,6 +61,10 @@ func (cmd *CreateAlarm) ParamsSpec() params.Spec {
 		})
 }
 
+func (cmd *CreateAlarm) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "alarm", "name", result), nil
+}
+
 func (cmd *CreateAlarm) ExtractResult(i interface{}) string {
 	return StringValue(cmd.Name)
 }
@@ -89,6 +93,10 @@ func (cmd *StartAlarm) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("names")))
 }
 
+func (cmd *StartAlarm) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("stop", "alarm", revertParamsExcept(params)...)}, nil
+}
+
 type StopAlarm struct {
 	_      string `action:"stop" entity:"alarm" awsAPI:"cloudwatch" awsCall:"DisableAlarmActions" awsInput:"cloudwatch.DisableAlarmActionsInput" awsOutput:"cloudwatch.DisableAlarmActionsOutput"`
 	logger *logger.Logger
@@ -101,6 +109,10 @@ func (cmd *StopAlarm) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("names")))
 }
 
+func (cmd *StopAlarm) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("start", "alarm", revertParamsExcept(params)...)}, nil
+}
+
 type AttachAlarm struct {
 	_         string `action:"attach" entity:"alarm" awsAPI:"cloudwatch"`
 	logger    *logger.Logger
@@ -114,6 +126,10 @@ func (cmd *AttachAlarm) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("action-arn"), params.Key("name")))
 }
 
+func (cmd *AttachAlarm) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "alarm", revertParamsExcept(params)...)}, nil
+}
+
 func (cmd *AttachAlarm) ManualRun(renv env.Running) (interface{}, error) {
 	alarm, err := getAlarm(cmd.api, cmd.Name)
 	if err != nil {
@@ -156,6 +172,10 @@ func (cmd *DetachAlarm) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("action-arn"), params.Key("name")))
 }
 
+func (cmd *DetachAlarm) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("attach", "alarm", revertParamsExcept(params)...)}, nil
+}
+
 func (cmd *DetachAlarm) ManualRun(renv env.Running) (interface{}, error) {
 	alarm, err := getAlarm(cmd.api, cmd.Name)
 	if err != nil {
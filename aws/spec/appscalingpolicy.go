@@ -47,6 +47,15 @@ func (cmd *CreateAppscalingpolicy) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateAppscalingpolicy) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("delete", "appscalingpolicy",
+		revertParam("dimension", params["dimension"]),
+		revertParam("name", params["name"]),
+		revertParam("resource", params["resource"]),
+		revertParam("service-namespace", params["service-namespace"]),
+	)}, nil
+}
+
 func (cmd *CreateAppscalingpolicy) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*applicationautoscaling.PutScalingPolicyOutput).PolicyARN)
 }
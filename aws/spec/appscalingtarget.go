@@ -39,6 +39,14 @@ func (cmd *CreateAppscalingtarget) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("dimension"), params.Key("max-capacity"), params.Key("min-capacity"), params.Key("resource"), params.Key("role"), params.Key("service-namespace")))
 }
 
+func (cmd *CreateAppscalingtarget) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("delete", "appscalingtarget",
+		revertParam("dimension", params["dimension"]),
+		revertParam("resource", params["resource"]),
+		revertParam("service-namespace", params["service-namespace"]),
+	)}, nil
+}
+
 type DeleteAppscalingtarget struct {
 	_                string `action:"delete" entity:"appscalingtarget" awsAPI:"applicationautoscaling" awsCall:"DeregisterScalableTarget" awsInput:"applicationautoscaling.DeregisterScalableTargetInput" awsOutput:"applicationautoscaling.DeregisterScalableTargetOutput"`
 	logger           *logger.Logger
@@ -43,6 +43,10 @@ func (cmd *CreateBucket) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateBucket) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "bucket", "name", result), nil
+}
+
 func (cmd *CreateBucket) ExtractResult(i interface{}) string {
 	return StringValue(cmd.Name)
 }
@@ -46,6 +46,10 @@ func (cmd *CreateCertificate) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateCertificate) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "certificate", "arn", result), nil
+}
+
 func (cmd *CreateCertificate) ManualRun(renv env.Running) (interface{}, error) {
 	input := &acm.RequestCertificateInput{}
 	domains := awssdk.StringValueSlice(cmd.Domains)
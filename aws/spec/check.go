@@ -0,0 +1,240 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wallix/awless/aws/conv"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/params"
+)
+
+const checkAction = "check"
+
+// genericCheckProperties are the params of the generic `check <entity>` command per entity,
+// i.e. the synced properties of the entity, lowercased, mapped to their property name
+var genericCheckProperties = make(map[string]map[string]string)
+
+func init() {
+	for _, entity := range awsconv.ResourceTypes() {
+		props := make(map[string]string)
+		for _, prop := range awsconv.ResourceProperties(entity) {
+			if prop != properties.ID {
+				props[strings.ToLower(prop)] = prop
+			}
+		}
+		if len(props) == 0 {
+			continue
+		}
+		genericCheckProperties[entity] = props
+		genericRule := NewCheckResource(entity, nil).ParamsSpec().Rule()
+
+		key := checkAction + entity
+		if def, dedicated := AWSTemplatesDefinitions[key]; dedicated {
+			def.Params = params.AtLeastOneOf(def.Params, genericRule)
+			AWSTemplatesDefinitions[key] = def
+			continue
+		}
+		AWSTemplatesDefinitions[key] = Definition{
+			Action: checkAction,
+			Entity: entity,
+			Params: genericRule,
+		}
+		DriverSupportedActions[checkAction] = append(DriverSupportedActions[checkAction], entity)
+	}
+	sort.Strings(DriverSupportedActions[checkAction])
+}
+
+// buildGeneric builds the generic check commands of the entities with no dedicated check command,
+// and makes the dedicated ones fall back to the generic check when given its params
+func (f *AWSFactory) buildGeneric(key string, build func() interface{}) func() interface{} {
+	entity := strings.TrimPrefix(key, checkAction)
+	if _, ok := genericCheckProperties[entity]; !ok || !strings.HasPrefix(key, checkAction) {
+		return build
+	}
+	if build == nil {
+		return func() interface{} { return NewCheckResource(entity, f.Graph, f.Log) }
+	}
+	return func() interface{} {
+		return &checkWithFallback{command: build().(command), generic: NewCheckResource(entity, f.Graph, f.Log)}
+	}
+}
+
+// checkWithFallback runs a dedicated check command, or the generic check of the synced properties
+// of its entity when the params do not match the dedicated command (i.e. `check scalinggroup id=... status=...`)
+type checkWithFallback struct {
+	command
+	generic *CheckResource
+}
+
+func (cmd *checkWithFallback) ParamsSpec() params.Spec {
+	dedicated := cmd.command.ParamsSpec()
+	builder := params.SpecBuilder(params.AtLeastOneOf(dedicated.Rule(), cmd.generic.ParamsSpec().Rule()), dedicated.Validators())
+	for _, reducer := range dedicated.Reducers() {
+		builder.AddReducer(reducer.Reduce, reducer.Keys()...)
+	}
+	return builder.Done()
+}
+
+func (cmd *checkWithFallback) ParamType(key string) (string, bool) {
+	if typer, ok := cmd.command.(interface {
+		ParamType(string) (string, bool)
+	}); ok {
+		if t, ok := typer.ParamType(key); ok {
+			return t, ok
+		}
+	}
+	return cmd.generic.ParamType(key)
+}
+
+func (cmd *checkWithFallback) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if cmd.isGeneric(params) {
+		return cmd.generic.Run(renv, params)
+	}
+	return cmd.command.Run(renv, params)
+}
+
+func (cmd *checkWithFallback) isGeneric(values map[string]interface{}) bool {
+	var keys []string
+	for k := range values {
+		keys = append(keys, k)
+	}
+	return params.Run(cmd.command.ParamsSpec().Rule(), keys) != nil
+}
+
+// CheckResource waits for any synced property of a resource to have the expected value,
+// polling the fetch function of its type: `check <entity> id=... <property>=<value> timeout=...`.
+// The id param also matches the name of the resource when no resource has this id
+type CheckResource struct {
+	entity      string
+	logger      *logger.Logger
+	graph       cloud.GraphAPI
+	fetchByType func(ctx context.Context, resourceType string) (cloud.GraphAPI, error)
+	frequency   time.Duration
+}
+
+func NewCheckResource(entity string, g cloud.GraphAPI, l ...*logger.Logger) *CheckResource {
+	cmd := &CheckResource{entity: entity, graph: g, frequency: 5 * time.Second}
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	cmd.fetchByType = func(ctx context.Context, resourceType string) (cloud.GraphAPI, error) {
+		srv, err := cloud.GetServiceForType(resourceType)
+		if err != nil {
+			return nil, err
+		}
+		return srv.FetchByType(ctx, resourceType)
+	}
+	return cmd
+}
+
+func (cmd *CheckResource) ParamsSpec() params.Spec {
+	var props []params.Rule
+	for _, param := range cmd.propertyParams() {
+		props = append(props, params.Key(param))
+	}
+	return params.NewSpec(params.AllOf(params.Key("id"), params.OnlyOneOf(props...), params.Key("timeout")))
+}
+
+func (cmd *CheckResource) ParamType(key string) (string, bool) {
+	switch key {
+	case "id":
+		return "string", true
+	case "timeout":
+		return "int64", true
+	}
+	return "", false
+}
+
+func (cmd *CheckResource) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	id := fmt.Sprint(params["id"])
+	timeout, err := castInt64(params["timeout"])
+	if err != nil {
+		return nil, fmt.Errorf("timeout: %s", err)
+	}
+	var param string
+	for _, p := range cmd.propertyParams() {
+		if _, ok := params[p]; ok {
+			param = p
+		}
+	}
+	if param == "" {
+		return nil, fmt.Errorf("check %s: missing property to check, expecting one of %s", cmd.entity, strings.Join(cmd.propertyParams(), ", "))
+	}
+	if renv.IsDryRun() {
+		return nil, nil
+	}
+	prop := genericCheckProperties[cmd.entity][param]
+
+	c := &checker{
+		description: fmt.Sprintf("%s %s", cmd.entity, id),
+		timeout:     time.Duration(timeout) * time.Second,
+		frequency:   cmd.frequency,
+		fetchFunc: func() (string, error) {
+			g, err := cmd.fetchByType(context.Background(), cmd.entity)
+			if err != nil {
+				return "", err
+			}
+			resources, err := g.FindWithProperties(map[string]interface{}{properties.ID: id})
+			if err != nil {
+				return "", err
+			}
+			// ids of some entities are not known beforehand (i.e. stack ids are ARNs): resolve by name as a fallback
+			if len(resources) == 0 {
+				if resources, err = g.FindWithProperties(map[string]interface{}{properties.Name: id}); err != nil {
+					return "", err
+				}
+			}
+			if len(resources) == 0 {
+				return notFoundState, nil
+			}
+			if len(resources) > 1 {
+				return "", fmt.Errorf("%d %s named '%s', use the id instead", len(resources), cloud.PluralizeResource(cmd.entity), id)
+			}
+			val, ok := resources[0].Property(prop)
+			if !ok {
+				return "", nil
+			}
+			return fmt.Sprint(val), nil
+		},
+		expect:    fmt.Sprint(params[param]),
+		logger:    cmd.logger,
+		checkName: param,
+	}
+	return nil, c.check()
+}
+
+func (cmd *CheckResource) inject(params map[string]interface{}) error {
+	return nil
+}
+
+func (cmd *CheckResource) propertyParams() (names []string) {
+	for param := range genericCheckProperties[cmd.entity] {
+		names = append(names, param)
+	}
+	sort.Strings(names)
+	return
+}
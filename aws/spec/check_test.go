@@ -0,0 +1,156 @@
+package awsspec
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/params"
+)
+
+func TestGenericCheckDefinitions(t *testing.T) {
+	def, ok := AWSLookupDefinitions("checkstack")
+	if !ok {
+		t.Fatal("expected generic check definition for stack")
+	}
+	required, _, _ := params.List(def.Params)
+	for _, p := range []string{"id", "state", "timeout"} {
+		if !contains(required, p) {
+			t.Fatalf("expected param %s in %v", p, required)
+		}
+	}
+	if err := params.Run(def.Params, []string{"id", "state", "timeout"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := params.Run(def.Params, []string{"id", "state", "name", "timeout"}); err == nil {
+		t.Fatal("expected error when checking several properties")
+	}
+	if err := params.Run(def.Params, []string{"id", "unknown", "timeout"}); err == nil {
+		t.Fatal("expected error for unknown property")
+	}
+
+	if cmd, ok := MockAWSSessionFactory.Build("checkinstance")().(*checkWithFallback); !ok {
+		t.Fatal("expected dedicated check command for instance")
+	} else if _, ok = cmd.command.(*CheckInstance); !ok {
+		t.Fatalf("got %T, want dedicated check command for instance", cmd.command)
+	}
+	if _, ok := MockAWSSessionFactory.Build("checkfunction")().(*CheckResource); !ok {
+		t.Fatal("expected generic check command for function")
+	}
+	if MockAWSSessionFactory.Build("checkunknown") != nil {
+		t.Fatal("expected no command for unknown entity")
+	}
+	if !contains(DriverSupportedActions["check"], "distribution") || !contains(DriverSupportedActions["check"], "stack") {
+		t.Fatalf("expected check one-liners, got %v", DriverSupportedActions["check"])
+	}
+}
+
+func TestRunGenericCheck(t *testing.T) {
+	statuses := []string{"CREATE_IN_PROGRESS", "CREATE_IN_PROGRESS", "CREATE_COMPLETE"}
+	var fetchCount int
+	cmd := NewCheckResource("stack", nil)
+	cmd.frequency = 0
+	cmd.fetchByType = func(ctx context.Context, resourceType string) (cloud.GraphAPI, error) {
+		if resourceType != "stack" {
+			t.Fatalf("unexpected fetch of %s", resourceType)
+		}
+		g := graph.NewGraph()
+		g.AddResource(
+			resourcetest.Stack("other-stack").Prop(properties.State, "ROLLBACK_COMPLETE").Build(),
+			resourcetest.Stack("my-stack").Prop(properties.State, statuses[fetchCount]).Build(),
+		)
+		fetchCount++
+		return g, nil
+	}
+	renv := template.NewRunEnv(template.NewEnv().Build())
+
+	if _, err := cmd.Run(renv, map[string]interface{}{"id": "my-stack", "state": "create_complete", "timeout": 10}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fetchCount, 3; got != want {
+		t.Fatalf("got %d fetches, want %d", got, want)
+	}
+
+	fetchCount = 0
+	if _, err := cmd.Run(renv, map[string]interface{}{"id": "deleted-stack", "state": "not-found", "timeout": "10"}); err != nil {
+		t.Fatal(err)
+	}
+
+	stackArn := "arn:aws:cloudformation:eu-west-1:123456789012:stack/my-stack/4e1a2b30-7c8f-11e7-a2c1-50a686be73ba"
+	cmd.fetchByType = func(ctx context.Context, resourceType string) (cloud.GraphAPI, error) {
+		g := graph.NewGraph()
+		g.AddResource(
+			resourcetest.Stack(stackArn).Prop(properties.Name, "my-stack").Prop(properties.State, "CREATE_COMPLETE").Build(),
+			resourcetest.Stack("arn:aws:cloudformation:eu-west-1:123456789012:stack/twin/1").Prop(properties.Name, "twin").Build(),
+			resourcetest.Stack("arn:aws:cloudformation:eu-west-1:123456789012:stack/twin/2").Prop(properties.Name, "twin").Build(),
+		)
+		return g, nil
+	}
+	for _, id := range []string{stackArn, "my-stack"} {
+		if _, err := cmd.Run(renv, map[string]interface{}{"id": id, "state": "CREATE_COMPLETE", "timeout": 10}); err != nil {
+			t.Fatalf("%s: %s", id, err)
+		}
+	}
+	if _, err := cmd.Run(renv, map[string]interface{}{"id": "twin", "state": "CREATE_COMPLETE", "timeout": 10}); err == nil || !strings.Contains(err.Error(), "2 stacks named 'twin'") {
+		t.Fatalf("got %v, want ambiguous name error", err)
+	}
+
+	renv.SetDryRun(true)
+	fetchCount = 0
+	if _, err := cmd.Run(renv, map[string]interface{}{"id": "my-stack", "state": "CREATE_COMPLETE", "timeout": 10}); err != nil {
+		t.Fatal(err)
+	}
+	if fetchCount != 0 {
+		t.Fatal("expected no fetch on dry run")
+	}
+	if _, err := cmd.Run(renv, map[string]interface{}{"id": "my-stack", "timeout": 10}); err == nil || !strings.Contains(err.Error(), "missing property") {
+		t.Fatalf("got %v, want missing property error", err)
+	}
+}
+
+func TestDedicatedCheckFallback(t *testing.T) {
+	def, ok := AWSLookupDefinitions("checkscalinggroup")
+	if !ok {
+		t.Fatal("expected check definition for scalinggroup")
+	}
+	for _, keys := range [][]string{{"name", "count", "timeout"}, {"id", "desiredcapacity", "timeout"}, {"id", "state", "timeout"}} {
+		if err := params.Run(def.Params, keys); err != nil {
+			t.Fatalf("%v: %s", keys, err)
+		}
+	}
+	if err := params.Run(def.Params, []string{"id", "count", "timeout"}); err == nil {
+		t.Fatal("expected error when mixing dedicated and generic params")
+	}
+
+	cmd := MockAWSSessionFactory.Build("checkscalinggroup")().(*checkWithFallback)
+	if err := params.Run(cmd.ParamsSpec().Rule(), []string{"id", "desiredcapacity", "timeout"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cmd.isGeneric(map[string]interface{}{"name": "my-group", "count": 3, "timeout": 10}), false; got != want {
+		t.Fatalf("got %t, want %t", got, want)
+	}
+	if typ, _ := cmd.ParamType("count"); typ != "awsint64" {
+		t.Fatalf("got %s, want awsint64", typ)
+	}
+
+	var fetched bool
+	cmd.generic.frequency = 0
+	cmd.generic.fetchByType = func(ctx context.Context, resourceType string) (cloud.GraphAPI, error) {
+		fetched = true
+		g := graph.NewGraph()
+		g.AddResource(resourcetest.ScalingGroup("my-group").Prop(properties.DesiredCapacity, 3).Build())
+		return g, nil
+	}
+	renv := template.NewRunEnv(template.NewEnv().Build())
+	if _, err := cmd.Run(renv, map[string]interface{}{"id": "my-group", "desiredcapacity": 3, "timeout": 10}); err != nil {
+		t.Fatal(err)
+	}
+	if !fetched {
+		t.Fatal("expected generic check of the synced properties")
+	}
+}
@@ -36,6 +36,10 @@ func (cmd *CreateContainercluster) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("name")))
 }
 
+func (cmd *CreateContainercluster) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "containercluster", "id", result), nil
+}
+
 func (cmd *CreateContainercluster) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ecs.CreateClusterOutput).Cluster.ClusterArn)
 }
@@ -66,6 +66,20 @@ func (cmd *StartContainertask) ParamsSpec() params.Spec {
 		})
 }
 
+func (cmd *StartContainertask) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	switch params["type"] {
+	case "service":
+		return []string{
+			revertLine("update", "containertask", revertParam("cluster", params["cluster"]), revertParam("deployment-name", params["deployment-name"]), "desired-count=0"),
+			revertLine("stop", "containertask", revertParam("cluster", params["cluster"]), "type=service", revertParam("deployment-name", params["deployment-name"])),
+		}, nil
+	case "task":
+		return []string{revertLine("stop", "containertask", revertParam("cluster", params["cluster"]), "type=task", revertResultParam("run-arn", result))}, nil
+	default:
+		return nil, nil
+	}
+}
+
 func (cmd *StartContainertask) ManualRun(renv env.Running) (interface{}, error) {
 	switch StringValue(cmd.Type) {
 	case "service":
@@ -213,6 +227,32 @@ func (cmd *UpdateContainertask) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *UpdateContainertask) SnapshotState(renv env.Running, params map[string]interface{}) (map[string]interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, err
+	}
+	out, err := cmd.api.DescribeServices(&ecs.DescribeServicesInput{Cluster: cmd.Cluster, Services: []*string{cmd.DeploymentName}})
+	if err != nil {
+		return nil, err
+	}
+	if len(out.Services) != 1 {
+		return nil, fmt.Errorf("containertask deployment %s not found", StringValue(cmd.DeploymentName))
+	}
+	service := out.Services[0]
+	snapshot := make(map[string]interface{})
+	if cmd.DesiredCount != nil {
+		snapshot["desired-count"] = Int64AsIntValue(service.DesiredCount)
+	}
+	if cmd.Name != nil {
+		snapshot["name"] = StringValue(service.TaskDefinition)
+	}
+	return snapshot, nil
+}
+
+func (cmd *UpdateContainertask) RevertSnapshot(params, snapshot map[string]interface{}) ([]string, error) {
+	return revertSnapshot("containertask", params, snapshot, "cluster", "deployment-name"), nil
+}
+
 type AttachContainertask struct {
 	_               string `action:"attach" entity:"containertask" awsAPI:"ecs"`
 	logger          *logger.Logger
@@ -235,6 +275,10 @@ func (cmd *AttachContainertask) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *AttachContainertask) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "containertask", revertParam("name", params["name"]), revertParam("container-name", params["container-name"]))}, nil
+}
+
 func (cmd *AttachContainertask) ManualRun(renv env.Running) (interface{}, error) {
 	var taskDefinitionInput *ecs.RegisterTaskDefinitionInput
 	taskDefinitionName := StringValue(cmd.Name)
@@ -141,6 +141,16 @@ func (cmd *CreateDatabase) ParamsSpec() params.Spec {
 	)
 }
 
+func (cmd *CreateDatabase) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	return []string{
+		revertLine("delete", "database", revertResultParam("id", result), "skip-snapshot=true"),
+		fmt.Sprintf("check database %s state=not-found timeout=900", revertResultParam("id", result)),
+	}, nil
+}
+
 func (cmd *CreateDatabase) ManualRun(renv env.Running) (output interface{}, err error) {
 	if replica := cmd.ReadReplicaIdentifier; replica != nil {
 		input := &rds.CreateDBInstanceReadReplicaInput{}
@@ -263,6 +273,10 @@ func (cmd *StartDatabase) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id")))
 }
 
+func (cmd *StartDatabase) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("stop", "database", revertParamsExcept(params)...)}, nil
+}
+
 type StopDatabase struct {
 	_      string `action:"stop" entity:"database" awsAPI:"rds" awsCall:"StopDBInstance" awsInput:"rds.StopDBInstanceInput" awsOutput:"rds.StopDBInstanceOutput"`
 	logger *logger.Logger
@@ -275,6 +289,10 @@ func (cmd *StopDatabase) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id")))
 }
 
+func (cmd *StopDatabase) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("start", "database", revertParamsExcept(params)...)}, nil
+}
+
 type RestartDatabase struct {
 	_            string `action:"restart" entity:"database" awsAPI:"rds" awsCall:"RebootDBInstance" awsInput:"rds.RebootDBInstanceInput" awsOutput:"rds.RebootDBInstanceOutput"`
 	logger       *logger.Logger
@@ -38,6 +38,10 @@ func (cmd *CreateDbsubnetgroup) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("description"), params.Key("name"), params.Key("subnets")))
 }
 
+func (cmd *CreateDbsubnetgroup) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "dbsubnetgroup", "name", result), nil
+}
+
 func (cmd *CreateDbsubnetgroup) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*rds.CreateDBSubnetGroupOutput).DBSubnetGroup.DBSubnetGroupName)
 }
@@ -60,6 +60,10 @@ func (cmd *CreateDistribution) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateDistribution) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "distribution", "id", result), nil
+}
+
 func (cmd *CreateDistribution) ManualRun(renv env.Running) (interface{}, error) {
 	originId := "orig_1"
 	input := &cloudfront.CreateDistributionInput{
@@ -382,7 +386,7 @@ func (cmd *DeleteDistribution) ManualRun(renv env.Running) (interface{}, error)
 	}
 
 	cmd.logger.Info("check distribution disabling has been propagated")
-	checkDistribution := CommandFactory.Build("checkdistribution")().(*CheckDistribution)
+	checkDistribution := CommandFactory.Build("checkdistribution")().(command)
 	entries = map[string]interface{}{
 		"id":      cmd.Id,
 		"state":   "Deployed",
@@ -36,6 +36,10 @@ func (cmd *CreateElasticip) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("domain")))
 }
 
+func (cmd *CreateElasticip) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "elasticip", "id", result), nil
+}
+
 func (cmd *CreateElasticip) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.AllocateAddressOutput).AllocationId)
 }
@@ -75,6 +79,10 @@ func (cmd *AttachElasticip) ParamsSpec() params.Spec {
 	)
 }
 
+func (cmd *AttachElasticip) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "elasticip", revertResultParam("association", result))}, nil
+}
+
 func (cmd *AttachElasticip) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.AssociateAddressOutput).AssociationId)
 }
@@ -90,3 +98,7 @@ type DetachElasticip struct {
 func (cmd *DetachElasticip) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("association")))
 }
+
+func (cmd *DetachElasticip) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("attach", "elasticip", revertParamsExcept(params)...)}, nil
+}
@@ -49,6 +49,10 @@ func (cmd *CreateFunction) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateFunction) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "function", "id", result), nil
+}
+
 func (cmd *CreateFunction) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*lambda.FunctionConfiguration).FunctionArn)
 }
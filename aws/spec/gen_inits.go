@@ -42,6 +42,10 @@ type AWSFactory struct {
 }
 
 func (f *AWSFactory) Build(key string) func() interface{} {
+	return f.buildGeneric(key, f.build(key))
+}
+
+func (f *AWSFactory) build(key string) func() interface{} {
 	switch key {
 	case "attachalarm":
 		return func() interface{} { return NewAttachAlarm(f.Sess, f.Graph, f.Log) }
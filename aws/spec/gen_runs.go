@@ -129,13 +129,18 @@ func (cmd *AttachAlarm) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *AttachAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("alarm"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "alarm"), nil
 }
 
 func (cmd *AttachAlarm) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachAlarm) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachContainertask {
 	cmd := new(AttachContainertask)
 	if len(l) > 0 {
@@ -202,13 +207,18 @@ func (cmd *AttachContainertask) run(renv env.Running, params map[string]interfac
 }
 
 func (cmd *AttachContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("containertask"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "containertask"), nil
 }
 
 func (cmd *AttachContainertask) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachContainertask) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachElasticip(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachElasticip {
 	cmd := new(AttachElasticip)
 	if len(l) > 0 {
@@ -298,7 +308,7 @@ func (cmd *AttachElasticip) dryRun(renv env.Running, params map[string]interface
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.AssociateAddress call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: attach elasticip ok")
-			return fakeDryRunId("elasticip"), nil
+			return fakeDryRunId(renv, "elasticip"), nil
 		}
 	}
 
@@ -309,6 +319,10 @@ func (cmd *AttachElasticip) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachElasticip) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachInstance {
 	cmd := new(AttachInstance)
 	if len(l) > 0 {
@@ -381,13 +395,18 @@ func (cmd *AttachInstance) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *AttachInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("instance"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "instance"), nil
 }
 
 func (cmd *AttachInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachInstance) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachInstanceprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachInstanceprofile {
 	cmd := new(AttachInstanceprofile)
 	if len(l) > 0 {
@@ -457,6 +476,10 @@ func (cmd *AttachInstanceprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachInstanceprofile) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachInternetgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachInternetgateway {
 	cmd := new(AttachInternetgateway)
 	if len(l) > 0 {
@@ -546,7 +569,7 @@ func (cmd *AttachInternetgateway) dryRun(renv env.Running, params map[string]int
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.AttachInternetGateway call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: attach internetgateway ok")
-			return fakeDryRunId("internetgateway"), nil
+			return fakeDryRunId(renv, "internetgateway"), nil
 		}
 	}
 
@@ -557,6 +580,10 @@ func (cmd *AttachInternetgateway) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachInternetgateway) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachMfadevice(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachMfadevice {
 	cmd := new(AttachMfadevice)
 	if len(l) > 0 {
@@ -629,13 +656,18 @@ func (cmd *AttachMfadevice) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *AttachMfadevice) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("mfadevice"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "mfadevice"), nil
 }
 
 func (cmd *AttachMfadevice) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachMfadevice) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachNetworkinterface {
 	cmd := new(AttachNetworkinterface)
 	if len(l) > 0 {
@@ -725,7 +757,7 @@ func (cmd *AttachNetworkinterface) dryRun(renv env.Running, params map[string]in
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.AttachNetworkInterface call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: attach networkinterface ok")
-			return fakeDryRunId("networkinterface"), nil
+			return fakeDryRunId(renv, "networkinterface"), nil
 		}
 	}
 
@@ -736,6 +768,10 @@ func (cmd *AttachNetworkinterface) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachNetworkinterface) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachPolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachPolicy {
 	cmd := new(AttachPolicy)
 	if len(l) > 0 {
@@ -802,13 +838,18 @@ func (cmd *AttachPolicy) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *AttachPolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("policy"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "policy"), nil
 }
 
 func (cmd *AttachPolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachPolicy) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachRole(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachRole {
 	cmd := new(AttachRole)
 	if len(l) > 0 {
@@ -881,13 +922,18 @@ func (cmd *AttachRole) run(renv env.Running, params map[string]interface{}) (int
 }
 
 func (cmd *AttachRole) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("role"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "role"), nil
 }
 
 func (cmd *AttachRole) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachRole) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachRoutetable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachRoutetable {
 	cmd := new(AttachRoutetable)
 	if len(l) > 0 {
@@ -977,7 +1023,7 @@ func (cmd *AttachRoutetable) dryRun(renv env.Running, params map[string]interfac
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.AssociateRouteTable call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: attach routetable ok")
-			return fakeDryRunId("routetable"), nil
+			return fakeDryRunId(renv, "routetable"), nil
 		}
 	}
 
@@ -988,6 +1034,10 @@ func (cmd *AttachRoutetable) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachRoutetable) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachSecuritygroup {
 	cmd := new(AttachSecuritygroup)
 	if len(l) > 0 {
@@ -1054,13 +1104,18 @@ func (cmd *AttachSecuritygroup) run(renv env.Running, params map[string]interfac
 }
 
 func (cmd *AttachSecuritygroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("securitygroup"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "securitygroup"), nil
 }
 
 func (cmd *AttachSecuritygroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachSecuritygroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachUser(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachUser {
 	cmd := new(AttachUser)
 	if len(l) > 0 {
@@ -1133,13 +1188,18 @@ func (cmd *AttachUser) run(renv env.Running, params map[string]interface{}) (int
 }
 
 func (cmd *AttachUser) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("user"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "user"), nil
 }
 
 func (cmd *AttachUser) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachUser) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAttachVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachVolume {
 	cmd := new(AttachVolume)
 	if len(l) > 0 {
@@ -1229,7 +1289,7 @@ func (cmd *AttachVolume) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.AttachVolume call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: attach volume ok")
-			return fakeDryRunId("volume"), nil
+			return fakeDryRunId(renv, "volume"), nil
 		}
 	}
 
@@ -1240,6 +1300,10 @@ func (cmd *AttachVolume) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AttachVolume) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewAuthenticateRegistry(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AuthenticateRegistry {
 	cmd := new(AuthenticateRegistry)
 	if len(l) > 0 {
@@ -1306,13 +1370,18 @@ func (cmd *AuthenticateRegistry) run(renv env.Running, params map[string]interfa
 }
 
 func (cmd *AuthenticateRegistry) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("registry"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "registry"), nil
 }
 
 func (cmd *AuthenticateRegistry) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *AuthenticateRegistry) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCheckCertificate(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CheckCertificate {
 	cmd := new(CheckCertificate)
 	if len(l) > 0 {
@@ -1379,13 +1448,18 @@ func (cmd *CheckCertificate) run(renv env.Running, params map[string]interface{}
 }
 
 func (cmd *CheckCertificate) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("certificate"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "certificate"), nil
 }
 
 func (cmd *CheckCertificate) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CheckCertificate) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCheckDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CheckDatabase {
 	cmd := new(CheckDatabase)
 	if len(l) > 0 {
@@ -1452,13 +1526,18 @@ func (cmd *CheckDatabase) run(renv env.Running, params map[string]interface{}) (
 }
 
 func (cmd *CheckDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("database"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "database"), nil
 }
 
 func (cmd *CheckDatabase) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CheckDatabase) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCheckDistribution(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CheckDistribution {
 	cmd := new(CheckDistribution)
 	if len(l) > 0 {
@@ -1525,13 +1604,18 @@ func (cmd *CheckDistribution) run(renv env.Running, params map[string]interface{
 }
 
 func (cmd *CheckDistribution) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("distribution"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "distribution"), nil
 }
 
 func (cmd *CheckDistribution) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CheckDistribution) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCheckInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CheckInstance {
 	cmd := new(CheckInstance)
 	if len(l) > 0 {
@@ -1598,13 +1682,18 @@ func (cmd *CheckInstance) run(renv env.Running, params map[string]interface{}) (
 }
 
 func (cmd *CheckInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("instance"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "instance"), nil
 }
 
 func (cmd *CheckInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CheckInstance) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCheckLoadbalancer(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CheckLoadbalancer {
 	cmd := new(CheckLoadbalancer)
 	if len(l) > 0 {
@@ -1671,13 +1760,18 @@ func (cmd *CheckLoadbalancer) run(renv env.Running, params map[string]interface{
 }
 
 func (cmd *CheckLoadbalancer) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("loadbalancer"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "loadbalancer"), nil
 }
 
 func (cmd *CheckLoadbalancer) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CheckLoadbalancer) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCheckNatgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CheckNatgateway {
 	cmd := new(CheckNatgateway)
 	if len(l) > 0 {
@@ -1744,13 +1838,18 @@ func (cmd *CheckNatgateway) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *CheckNatgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("natgateway"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "natgateway"), nil
 }
 
 func (cmd *CheckNatgateway) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CheckNatgateway) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCheckNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CheckNetworkinterface {
 	cmd := new(CheckNetworkinterface)
 	if len(l) > 0 {
@@ -1817,13 +1916,18 @@ func (cmd *CheckNetworkinterface) run(renv env.Running, params map[string]interf
 }
 
 func (cmd *CheckNetworkinterface) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("networkinterface"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "networkinterface"), nil
 }
 
 func (cmd *CheckNetworkinterface) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CheckNetworkinterface) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCheckScalinggroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CheckScalinggroup {
 	cmd := new(CheckScalinggroup)
 	if len(l) > 0 {
@@ -1890,13 +1994,18 @@ func (cmd *CheckScalinggroup) run(renv env.Running, params map[string]interface{
 }
 
 func (cmd *CheckScalinggroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("scalinggroup"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "scalinggroup"), nil
 }
 
 func (cmd *CheckScalinggroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CheckScalinggroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCheckSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CheckSecuritygroup {
 	cmd := new(CheckSecuritygroup)
 	if len(l) > 0 {
@@ -1963,13 +2072,18 @@ func (cmd *CheckSecuritygroup) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *CheckSecuritygroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("securitygroup"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "securitygroup"), nil
 }
 
 func (cmd *CheckSecuritygroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CheckSecuritygroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCheckVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CheckVolume {
 	cmd := new(CheckVolume)
 	if len(l) > 0 {
@@ -2036,13 +2150,18 @@ func (cmd *CheckVolume) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *CheckVolume) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("volume"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "volume"), nil
 }
 
 func (cmd *CheckVolume) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CheckVolume) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCopyImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CopyImage {
 	cmd := new(CopyImage)
 	if len(l) > 0 {
@@ -2132,7 +2251,7 @@ func (cmd *CopyImage) dryRun(renv env.Running, params map[string]interface{}) (i
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.CopyImage call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: copy image ok")
-			return fakeDryRunId("image"), nil
+			return fakeDryRunId(renv, "image"), nil
 		}
 	}
 
@@ -2143,6 +2262,10 @@ func (cmd *CopyImage) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CopyImage) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCopySnapshot(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CopySnapshot {
 	cmd := new(CopySnapshot)
 	if len(l) > 0 {
@@ -2232,7 +2355,7 @@ func (cmd *CopySnapshot) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.CopySnapshot call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: copy snapshot ok")
-			return fakeDryRunId("snapshot"), nil
+			return fakeDryRunId(renv, "snapshot"), nil
 		}
 	}
 
@@ -2243,6 +2366,10 @@ func (cmd *CopySnapshot) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CopySnapshot) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateAccesskey(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateAccesskey {
 	cmd := new(CreateAccesskey)
 	if len(l) > 0 {
@@ -2315,13 +2442,18 @@ func (cmd *CreateAccesskey) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *CreateAccesskey) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("accesskey"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "accesskey"), nil
 }
 
 func (cmd *CreateAccesskey) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateAccesskey) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateAlarm {
 	cmd := new(CreateAlarm)
 	if len(l) > 0 {
@@ -2394,13 +2526,18 @@ func (cmd *CreateAlarm) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *CreateAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("alarm"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "alarm"), nil
 }
 
 func (cmd *CreateAlarm) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateAlarm) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateAppscalingpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateAppscalingpolicy {
 	cmd := new(CreateAppscalingpolicy)
 	if len(l) > 0 {
@@ -2473,13 +2610,18 @@ func (cmd *CreateAppscalingpolicy) run(renv env.Running, params map[string]inter
 }
 
 func (cmd *CreateAppscalingpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("appscalingpolicy"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "appscalingpolicy"), nil
 }
 
 func (cmd *CreateAppscalingpolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateAppscalingpolicy) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateAppscalingtarget(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateAppscalingtarget {
 	cmd := new(CreateAppscalingtarget)
 	if len(l) > 0 {
@@ -2552,13 +2694,18 @@ func (cmd *CreateAppscalingtarget) run(renv env.Running, params map[string]inter
 }
 
 func (cmd *CreateAppscalingtarget) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("appscalingtarget"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "appscalingtarget"), nil
 }
 
 func (cmd *CreateAppscalingtarget) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateAppscalingtarget) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateBucket(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateBucket {
 	cmd := new(CreateBucket)
 	if len(l) > 0 {
@@ -2631,13 +2778,18 @@ func (cmd *CreateBucket) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *CreateBucket) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("bucket"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "bucket"), nil
 }
 
 func (cmd *CreateBucket) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateBucket) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateCertificate(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateCertificate {
 	cmd := new(CreateCertificate)
 	if len(l) > 0 {
@@ -2704,13 +2856,18 @@ func (cmd *CreateCertificate) run(renv env.Running, params map[string]interface{
 }
 
 func (cmd *CreateCertificate) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("certificate"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "certificate"), nil
 }
 
 func (cmd *CreateCertificate) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateCertificate) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateContainercluster(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateContainercluster {
 	cmd := new(CreateContainercluster)
 	if len(l) > 0 {
@@ -2783,13 +2940,18 @@ func (cmd *CreateContainercluster) run(renv env.Running, params map[string]inter
 }
 
 func (cmd *CreateContainercluster) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("containercluster"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "containercluster"), nil
 }
 
 func (cmd *CreateContainercluster) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateContainercluster) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateDatabase {
 	cmd := new(CreateDatabase)
 	if len(l) > 0 {
@@ -2856,13 +3018,18 @@ func (cmd *CreateDatabase) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *CreateDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("database"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "database"), nil
 }
 
 func (cmd *CreateDatabase) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateDatabase) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateDbsubnetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateDbsubnetgroup {
 	cmd := new(CreateDbsubnetgroup)
 	if len(l) > 0 {
@@ -2935,13 +3102,18 @@ func (cmd *CreateDbsubnetgroup) run(renv env.Running, params map[string]interfac
 }
 
 func (cmd *CreateDbsubnetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("dbsubnetgroup"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "dbsubnetgroup"), nil
 }
 
 func (cmd *CreateDbsubnetgroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateDbsubnetgroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateDistribution(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateDistribution {
 	cmd := new(CreateDistribution)
 	if len(l) > 0 {
@@ -3008,13 +3180,18 @@ func (cmd *CreateDistribution) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *CreateDistribution) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("distribution"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "distribution"), nil
 }
 
 func (cmd *CreateDistribution) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateDistribution) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateElasticip(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateElasticip {
 	cmd := new(CreateElasticip)
 	if len(l) > 0 {
@@ -3104,7 +3281,7 @@ func (cmd *CreateElasticip) dryRun(renv env.Running, params map[string]interface
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.AllocateAddress call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: create elasticip ok")
-			return fakeDryRunId("elasticip"), nil
+			return fakeDryRunId(renv, "elasticip"), nil
 		}
 	}
 
@@ -3115,6 +3292,10 @@ func (cmd *CreateElasticip) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateElasticip) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateFunction(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateFunction {
 	cmd := new(CreateFunction)
 	if len(l) > 0 {
@@ -3187,13 +3368,18 @@ func (cmd *CreateFunction) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *CreateFunction) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("function"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "function"), nil
 }
 
 func (cmd *CreateFunction) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateFunction) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateGroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateGroup {
 	cmd := new(CreateGroup)
 	if len(l) > 0 {
@@ -3266,13 +3452,18 @@ func (cmd *CreateGroup) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *CreateGroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("group"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "group"), nil
 }
 
 func (cmd *CreateGroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateGroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateImage {
 	cmd := new(CreateImage)
 	if len(l) > 0 {
@@ -3362,7 +3553,7 @@ func (cmd *CreateImage) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.CreateImage call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: create image ok")
-			return fakeDryRunId("image"), nil
+			return fakeDryRunId(renv, "image"), nil
 		}
 	}
 
@@ -3373,6 +3564,10 @@ func (cmd *CreateImage) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateImage) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateInstance {
 	cmd := new(CreateInstance)
 	if len(l) > 0 {
@@ -3462,7 +3657,7 @@ func (cmd *CreateInstance) dryRun(renv env.Running, params map[string]interface{
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.RunInstances call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: create instance ok")
-			return fakeDryRunId("instance"), nil
+			return fakeDryRunId(renv, "instance"), nil
 		}
 	}
 
@@ -3473,6 +3668,10 @@ func (cmd *CreateInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateInstance) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateInstanceprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateInstanceprofile {
 	cmd := new(CreateInstanceprofile)
 	if len(l) > 0 {
@@ -3545,13 +3744,18 @@ func (cmd *CreateInstanceprofile) run(renv env.Running, params map[string]interf
 }
 
 func (cmd *CreateInstanceprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("instanceprofile"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "instanceprofile"), nil
 }
 
 func (cmd *CreateInstanceprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateInstanceprofile) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateInternetgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateInternetgateway {
 	cmd := new(CreateInternetgateway)
 	if len(l) > 0 {
@@ -3641,7 +3845,7 @@ func (cmd *CreateInternetgateway) dryRun(renv env.Running, params map[string]int
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.CreateInternetGateway call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: create internetgateway ok")
-			return fakeDryRunId("internetgateway"), nil
+			return fakeDryRunId(renv, "internetgateway"), nil
 		}
 	}
 
@@ -3652,6 +3856,10 @@ func (cmd *CreateInternetgateway) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateInternetgateway) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateKeypair(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateKeypair {
 	cmd := new(CreateKeypair)
 	if len(l) > 0 {
@@ -3724,13 +3932,18 @@ func (cmd *CreateKeypair) run(renv env.Running, params map[string]interface{}) (
 }
 
 func (cmd *CreateKeypair) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("keypair"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "keypair"), nil
 }
 
 func (cmd *CreateKeypair) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateKeypair) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateLaunchconfiguration(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateLaunchconfiguration {
 	cmd := new(CreateLaunchconfiguration)
 	if len(l) > 0 {
@@ -3803,13 +4016,18 @@ func (cmd *CreateLaunchconfiguration) run(renv env.Running, params map[string]in
 }
 
 func (cmd *CreateLaunchconfiguration) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("launchconfiguration"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "launchconfiguration"), nil
 }
 
 func (cmd *CreateLaunchconfiguration) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateLaunchconfiguration) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateListener(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateListener {
 	cmd := new(CreateListener)
 	if len(l) > 0 {
@@ -3882,13 +4100,18 @@ func (cmd *CreateListener) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *CreateListener) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("listener"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "listener"), nil
 }
 
 func (cmd *CreateListener) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateListener) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateLoadbalancer(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateLoadbalancer {
 	cmd := new(CreateLoadbalancer)
 	if len(l) > 0 {
@@ -3961,13 +4184,18 @@ func (cmd *CreateLoadbalancer) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *CreateLoadbalancer) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("loadbalancer"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "loadbalancer"), nil
 }
 
 func (cmd *CreateLoadbalancer) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateLoadbalancer) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateLoginprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateLoginprofile {
 	cmd := new(CreateLoginprofile)
 	if len(l) > 0 {
@@ -4040,13 +4268,18 @@ func (cmd *CreateLoginprofile) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *CreateLoginprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("loginprofile"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "loginprofile"), nil
 }
 
 func (cmd *CreateLoginprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateLoginprofile) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateMfadevice(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateMfadevice {
 	cmd := new(CreateMfadevice)
 	if len(l) > 0 {
@@ -4113,13 +4346,18 @@ func (cmd *CreateMfadevice) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *CreateMfadevice) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("mfadevice"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "mfadevice"), nil
 }
 
 func (cmd *CreateMfadevice) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateMfadevice) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateNatgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateNatgateway {
 	cmd := new(CreateNatgateway)
 	if len(l) > 0 {
@@ -4192,13 +4430,18 @@ func (cmd *CreateNatgateway) run(renv env.Running, params map[string]interface{}
 }
 
 func (cmd *CreateNatgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("natgateway"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "natgateway"), nil
 }
 
 func (cmd *CreateNatgateway) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateNatgateway) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateNetworkinterface {
 	cmd := new(CreateNetworkinterface)
 	if len(l) > 0 {
@@ -4288,7 +4531,7 @@ func (cmd *CreateNetworkinterface) dryRun(renv env.Running, params map[string]in
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.CreateNetworkInterface call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: create networkinterface ok")
-			return fakeDryRunId("networkinterface"), nil
+			return fakeDryRunId(renv, "networkinterface"), nil
 		}
 	}
 
@@ -4299,6 +4542,10 @@ func (cmd *CreateNetworkinterface) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateNetworkinterface) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreatePolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreatePolicy {
 	cmd := new(CreatePolicy)
 	if len(l) > 0 {
@@ -4371,13 +4618,18 @@ func (cmd *CreatePolicy) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *CreatePolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("policy"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "policy"), nil
 }
 
 func (cmd *CreatePolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreatePolicy) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateQueue(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateQueue {
 	cmd := new(CreateQueue)
 	if len(l) > 0 {
@@ -4450,13 +4702,18 @@ func (cmd *CreateQueue) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *CreateQueue) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("queue"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "queue"), nil
 }
 
 func (cmd *CreateQueue) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateQueue) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateRecord(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRecord {
 	cmd := new(CreateRecord)
 	if len(l) > 0 {
@@ -4523,13 +4780,18 @@ func (cmd *CreateRecord) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *CreateRecord) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("record"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "record"), nil
 }
 
 func (cmd *CreateRecord) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateRecord) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateRepository(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRepository {
 	cmd := new(CreateRepository)
 	if len(l) > 0 {
@@ -4602,13 +4864,18 @@ func (cmd *CreateRepository) run(renv env.Running, params map[string]interface{}
 }
 
 func (cmd *CreateRepository) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("repository"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "repository"), nil
 }
 
 func (cmd *CreateRepository) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateRepository) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateRole(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRole {
 	cmd := new(CreateRole)
 	if len(l) > 0 {
@@ -4675,13 +4942,18 @@ func (cmd *CreateRole) run(renv env.Running, params map[string]interface{}) (int
 }
 
 func (cmd *CreateRole) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("role"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "role"), nil
 }
 
 func (cmd *CreateRole) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateRole) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateRoute(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRoute {
 	cmd := new(CreateRoute)
 	if len(l) > 0 {
@@ -4771,7 +5043,7 @@ func (cmd *CreateRoute) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.CreateRoute call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: create route ok")
-			return fakeDryRunId("route"), nil
+			return fakeDryRunId(renv, "route"), nil
 		}
 	}
 
@@ -4782,6 +5054,10 @@ func (cmd *CreateRoute) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateRoute) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateRoutetable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRoutetable {
 	cmd := new(CreateRoutetable)
 	if len(l) > 0 {
@@ -4871,7 +5147,7 @@ func (cmd *CreateRoutetable) dryRun(renv env.Running, params map[string]interfac
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.CreateRouteTable call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: create routetable ok")
-			return fakeDryRunId("routetable"), nil
+			return fakeDryRunId(renv, "routetable"), nil
 		}
 	}
 
@@ -4882,6 +5158,10 @@ func (cmd *CreateRoutetable) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateRoutetable) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateS3object(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateS3object {
 	cmd := new(CreateS3object)
 	if len(l) > 0 {
@@ -4948,13 +5228,18 @@ func (cmd *CreateS3object) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *CreateS3object) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("s3object"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "s3object"), nil
 }
 
 func (cmd *CreateS3object) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateS3object) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateScalinggroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateScalinggroup {
 	cmd := new(CreateScalinggroup)
 	if len(l) > 0 {
@@ -5027,13 +5312,18 @@ func (cmd *CreateScalinggroup) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *CreateScalinggroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("scalinggroup"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "scalinggroup"), nil
 }
 
 func (cmd *CreateScalinggroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateScalinggroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateScalingpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateScalingpolicy {
 	cmd := new(CreateScalingpolicy)
 	if len(l) > 0 {
@@ -5106,13 +5396,18 @@ func (cmd *CreateScalingpolicy) run(renv env.Running, params map[string]interfac
 }
 
 func (cmd *CreateScalingpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("scalingpolicy"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "scalingpolicy"), nil
 }
 
 func (cmd *CreateScalingpolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateScalingpolicy) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSecuritygroup {
 	cmd := new(CreateSecuritygroup)
 	if len(l) > 0 {
@@ -5202,7 +5497,7 @@ func (cmd *CreateSecuritygroup) dryRun(renv env.Running, params map[string]inter
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.CreateSecurityGroup call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: create securitygroup ok")
-			return fakeDryRunId("securitygroup"), nil
+			return fakeDryRunId(renv, "securitygroup"), nil
 		}
 	}
 
@@ -5213,6 +5508,10 @@ func (cmd *CreateSecuritygroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateSecuritygroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateSnapshot(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSnapshot {
 	cmd := new(CreateSnapshot)
 	if len(l) > 0 {
@@ -5302,7 +5601,7 @@ func (cmd *CreateSnapshot) dryRun(renv env.Running, params map[string]interface{
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.CreateSnapshot call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: create snapshot ok")
-			return fakeDryRunId("snapshot"), nil
+			return fakeDryRunId(renv, "snapshot"), nil
 		}
 	}
 
@@ -5313,6 +5612,10 @@ func (cmd *CreateSnapshot) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateSnapshot) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateStack(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateStack {
 	cmd := new(CreateStack)
 	if len(l) > 0 {
@@ -5385,13 +5688,18 @@ func (cmd *CreateStack) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *CreateStack) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("stack"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "stack"), nil
 }
 
 func (cmd *CreateStack) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateStack) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateSubnet(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSubnet {
 	cmd := new(CreateSubnet)
 	if len(l) > 0 {
@@ -5481,7 +5789,7 @@ func (cmd *CreateSubnet) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.CreateSubnet call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: create subnet ok")
-			return fakeDryRunId("subnet"), nil
+			return fakeDryRunId(renv, "subnet"), nil
 		}
 	}
 
@@ -5492,6 +5800,10 @@ func (cmd *CreateSubnet) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateSubnet) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateSubscription(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSubscription {
 	cmd := new(CreateSubscription)
 	if len(l) > 0 {
@@ -5564,13 +5876,18 @@ func (cmd *CreateSubscription) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *CreateSubscription) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("subscription"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "subscription"), nil
 }
 
 func (cmd *CreateSubscription) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateSubscription) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateTag(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateTag {
 	cmd := new(CreateTag)
 	if len(l) > 0 {
@@ -5640,6 +5957,10 @@ func (cmd *CreateTag) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateTag) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateTargetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateTargetgroup {
 	cmd := new(CreateTargetgroup)
 	if len(l) > 0 {
@@ -5712,13 +6033,18 @@ func (cmd *CreateTargetgroup) run(renv env.Running, params map[string]interface{
 }
 
 func (cmd *CreateTargetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("targetgroup"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "targetgroup"), nil
 }
 
 func (cmd *CreateTargetgroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateTargetgroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateTopic(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateTopic {
 	cmd := new(CreateTopic)
 	if len(l) > 0 {
@@ -5791,13 +6117,18 @@ func (cmd *CreateTopic) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *CreateTopic) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("topic"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "topic"), nil
 }
 
 func (cmd *CreateTopic) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateTopic) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateUser(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateUser {
 	cmd := new(CreateUser)
 	if len(l) > 0 {
@@ -5870,13 +6201,18 @@ func (cmd *CreateUser) run(renv env.Running, params map[string]interface{}) (int
 }
 
 func (cmd *CreateUser) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("user"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "user"), nil
 }
 
 func (cmd *CreateUser) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateUser) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateVolume {
 	cmd := new(CreateVolume)
 	if len(l) > 0 {
@@ -5966,7 +6302,7 @@ func (cmd *CreateVolume) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.CreateVolume call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: create volume ok")
-			return fakeDryRunId("volume"), nil
+			return fakeDryRunId(renv, "volume"), nil
 		}
 	}
 
@@ -5977,6 +6313,10 @@ func (cmd *CreateVolume) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateVolume) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateVpc(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateVpc {
 	cmd := new(CreateVpc)
 	if len(l) > 0 {
@@ -6066,7 +6406,7 @@ func (cmd *CreateVpc) dryRun(renv env.Running, params map[string]interface{}) (i
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.CreateVpc call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: create vpc ok")
-			return fakeDryRunId("vpc"), nil
+			return fakeDryRunId(renv, "vpc"), nil
 		}
 	}
 
@@ -6077,6 +6417,10 @@ func (cmd *CreateVpc) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateVpc) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewCreateZone(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateZone {
 	cmd := new(CreateZone)
 	if len(l) > 0 {
@@ -6149,13 +6493,18 @@ func (cmd *CreateZone) run(renv env.Running, params map[string]interface{}) (int
 }
 
 func (cmd *CreateZone) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("zone"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "zone"), nil
 }
 
 func (cmd *CreateZone) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *CreateZone) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteAccesskey(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAccesskey {
 	cmd := new(DeleteAccesskey)
 	if len(l) > 0 {
@@ -6228,13 +6577,18 @@ func (cmd *DeleteAccesskey) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *DeleteAccesskey) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("accesskey"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "accesskey"), nil
 }
 
 func (cmd *DeleteAccesskey) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteAccesskey) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAlarm {
 	cmd := new(DeleteAlarm)
 	if len(l) > 0 {
@@ -6307,13 +6661,18 @@ func (cmd *DeleteAlarm) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *DeleteAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("alarm"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "alarm"), nil
 }
 
 func (cmd *DeleteAlarm) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteAlarm) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteAppscalingpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAppscalingpolicy {
 	cmd := new(DeleteAppscalingpolicy)
 	if len(l) > 0 {
@@ -6386,13 +6745,18 @@ func (cmd *DeleteAppscalingpolicy) run(renv env.Running, params map[string]inter
 }
 
 func (cmd *DeleteAppscalingpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("appscalingpolicy"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "appscalingpolicy"), nil
 }
 
 func (cmd *DeleteAppscalingpolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteAppscalingpolicy) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteAppscalingtarget(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAppscalingtarget {
 	cmd := new(DeleteAppscalingtarget)
 	if len(l) > 0 {
@@ -6465,13 +6829,18 @@ func (cmd *DeleteAppscalingtarget) run(renv env.Running, params map[string]inter
 }
 
 func (cmd *DeleteAppscalingtarget) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("appscalingtarget"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "appscalingtarget"), nil
 }
 
 func (cmd *DeleteAppscalingtarget) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteAppscalingtarget) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteBucket(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteBucket {
 	cmd := new(DeleteBucket)
 	if len(l) > 0 {
@@ -6544,13 +6913,18 @@ func (cmd *DeleteBucket) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *DeleteBucket) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("bucket"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "bucket"), nil
 }
 
 func (cmd *DeleteBucket) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteBucket) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteCertificate(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteCertificate {
 	cmd := new(DeleteCertificate)
 	if len(l) > 0 {
@@ -6623,13 +6997,18 @@ func (cmd *DeleteCertificate) run(renv env.Running, params map[string]interface{
 }
 
 func (cmd *DeleteCertificate) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("certificate"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "certificate"), nil
 }
 
 func (cmd *DeleteCertificate) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteCertificate) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteContainercluster(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteContainercluster {
 	cmd := new(DeleteContainercluster)
 	if len(l) > 0 {
@@ -6702,13 +7081,18 @@ func (cmd *DeleteContainercluster) run(renv env.Running, params map[string]inter
 }
 
 func (cmd *DeleteContainercluster) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("containercluster"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "containercluster"), nil
 }
 
 func (cmd *DeleteContainercluster) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteContainercluster) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteContainertask {
 	cmd := new(DeleteContainertask)
 	if len(l) > 0 {
@@ -6778,6 +7162,10 @@ func (cmd *DeleteContainertask) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteContainertask) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteDatabase {
 	cmd := new(DeleteDatabase)
 	if len(l) > 0 {
@@ -6850,13 +7238,18 @@ func (cmd *DeleteDatabase) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *DeleteDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("database"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "database"), nil
 }
 
 func (cmd *DeleteDatabase) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteDatabase) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteDbsubnetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteDbsubnetgroup {
 	cmd := new(DeleteDbsubnetgroup)
 	if len(l) > 0 {
@@ -6929,13 +7322,18 @@ func (cmd *DeleteDbsubnetgroup) run(renv env.Running, params map[string]interfac
 }
 
 func (cmd *DeleteDbsubnetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("dbsubnetgroup"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "dbsubnetgroup"), nil
 }
 
 func (cmd *DeleteDbsubnetgroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteDbsubnetgroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteDistribution(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteDistribution {
 	cmd := new(DeleteDistribution)
 	if len(l) > 0 {
@@ -7002,13 +7400,18 @@ func (cmd *DeleteDistribution) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *DeleteDistribution) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("distribution"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "distribution"), nil
 }
 
 func (cmd *DeleteDistribution) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteDistribution) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteElasticip(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteElasticip {
 	cmd := new(DeleteElasticip)
 	if len(l) > 0 {
@@ -7098,7 +7501,7 @@ func (cmd *DeleteElasticip) dryRun(renv env.Running, params map[string]interface
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.ReleaseAddress call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: delete elasticip ok")
-			return fakeDryRunId("elasticip"), nil
+			return fakeDryRunId(renv, "elasticip"), nil
 		}
 	}
 
@@ -7109,6 +7512,10 @@ func (cmd *DeleteElasticip) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteElasticip) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteFunction(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteFunction {
 	cmd := new(DeleteFunction)
 	if len(l) > 0 {
@@ -7181,13 +7588,18 @@ func (cmd *DeleteFunction) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *DeleteFunction) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("function"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "function"), nil
 }
 
 func (cmd *DeleteFunction) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteFunction) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteGroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteGroup {
 	cmd := new(DeleteGroup)
 	if len(l) > 0 {
@@ -7260,13 +7672,18 @@ func (cmd *DeleteGroup) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *DeleteGroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("group"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "group"), nil
 }
 
 func (cmd *DeleteGroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteGroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteImage {
 	cmd := new(DeleteImage)
 	if len(l) > 0 {
@@ -7336,6 +7753,10 @@ func (cmd *DeleteImage) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteImage) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteInstance {
 	cmd := new(DeleteInstance)
 	if len(l) > 0 {
@@ -7425,7 +7846,7 @@ func (cmd *DeleteInstance) dryRun(renv env.Running, params map[string]interface{
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.TerminateInstances call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: delete instance ok")
-			return fakeDryRunId("instance"), nil
+			return fakeDryRunId(renv, "instance"), nil
 		}
 	}
 
@@ -7436,6 +7857,10 @@ func (cmd *DeleteInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteInstance) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteInstanceprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteInstanceprofile {
 	cmd := new(DeleteInstanceprofile)
 	if len(l) > 0 {
@@ -7508,13 +7933,18 @@ func (cmd *DeleteInstanceprofile) run(renv env.Running, params map[string]interf
 }
 
 func (cmd *DeleteInstanceprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("instanceprofile"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "instanceprofile"), nil
 }
 
 func (cmd *DeleteInstanceprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteInstanceprofile) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteInternetgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteInternetgateway {
 	cmd := new(DeleteInternetgateway)
 	if len(l) > 0 {
@@ -7604,7 +8034,7 @@ func (cmd *DeleteInternetgateway) dryRun(renv env.Running, params map[string]int
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DeleteInternetGateway call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: delete internetgateway ok")
-			return fakeDryRunId("internetgateway"), nil
+			return fakeDryRunId(renv, "internetgateway"), nil
 		}
 	}
 
@@ -7615,6 +8045,10 @@ func (cmd *DeleteInternetgateway) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteInternetgateway) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteKeypair(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteKeypair {
 	cmd := new(DeleteKeypair)
 	if len(l) > 0 {
@@ -7704,7 +8138,7 @@ func (cmd *DeleteKeypair) dryRun(renv env.Running, params map[string]interface{}
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DeleteKeyPair call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: delete keypair ok")
-			return fakeDryRunId("keypair"), nil
+			return fakeDryRunId(renv, "keypair"), nil
 		}
 	}
 
@@ -7715,6 +8149,10 @@ func (cmd *DeleteKeypair) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteKeypair) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteLaunchconfiguration(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteLaunchconfiguration {
 	cmd := new(DeleteLaunchconfiguration)
 	if len(l) > 0 {
@@ -7787,13 +8225,18 @@ func (cmd *DeleteLaunchconfiguration) run(renv env.Running, params map[string]in
 }
 
 func (cmd *DeleteLaunchconfiguration) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("launchconfiguration"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "launchconfiguration"), nil
 }
 
 func (cmd *DeleteLaunchconfiguration) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteLaunchconfiguration) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteListener(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteListener {
 	cmd := new(DeleteListener)
 	if len(l) > 0 {
@@ -7866,13 +8309,18 @@ func (cmd *DeleteListener) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *DeleteListener) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("listener"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "listener"), nil
 }
 
 func (cmd *DeleteListener) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteListener) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteLoadbalancer(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteLoadbalancer {
 	cmd := new(DeleteLoadbalancer)
 	if len(l) > 0 {
@@ -7945,13 +8393,18 @@ func (cmd *DeleteLoadbalancer) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *DeleteLoadbalancer) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("loadbalancer"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "loadbalancer"), nil
 }
 
 func (cmd *DeleteLoadbalancer) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteLoadbalancer) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteLoginprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteLoginprofile {
 	cmd := new(DeleteLoginprofile)
 	if len(l) > 0 {
@@ -8024,13 +8477,18 @@ func (cmd *DeleteLoginprofile) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *DeleteLoginprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("loginprofile"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "loginprofile"), nil
 }
 
 func (cmd *DeleteLoginprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteLoginprofile) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteMfadevice(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteMfadevice {
 	cmd := new(DeleteMfadevice)
 	if len(l) > 0 {
@@ -8103,13 +8561,18 @@ func (cmd *DeleteMfadevice) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *DeleteMfadevice) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("mfadevice"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "mfadevice"), nil
 }
 
 func (cmd *DeleteMfadevice) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteMfadevice) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteNatgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteNatgateway {
 	cmd := new(DeleteNatgateway)
 	if len(l) > 0 {
@@ -8182,13 +8645,18 @@ func (cmd *DeleteNatgateway) run(renv env.Running, params map[string]interface{}
 }
 
 func (cmd *DeleteNatgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("natgateway"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "natgateway"), nil
 }
 
 func (cmd *DeleteNatgateway) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteNatgateway) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteNetworkinterface {
 	cmd := new(DeleteNetworkinterface)
 	if len(l) > 0 {
@@ -8278,7 +8746,7 @@ func (cmd *DeleteNetworkinterface) dryRun(renv env.Running, params map[string]in
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DeleteNetworkInterface call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: delete networkinterface ok")
-			return fakeDryRunId("networkinterface"), nil
+			return fakeDryRunId(renv, "networkinterface"), nil
 		}
 	}
 
@@ -8289,6 +8757,10 @@ func (cmd *DeleteNetworkinterface) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteNetworkinterface) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeletePolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeletePolicy {
 	cmd := new(DeletePolicy)
 	if len(l) > 0 {
@@ -8361,13 +8833,18 @@ func (cmd *DeletePolicy) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *DeletePolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("policy"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "policy"), nil
 }
 
 func (cmd *DeletePolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeletePolicy) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteQueue(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteQueue {
 	cmd := new(DeleteQueue)
 	if len(l) > 0 {
@@ -8440,13 +8917,18 @@ func (cmd *DeleteQueue) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *DeleteQueue) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("queue"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "queue"), nil
 }
 
 func (cmd *DeleteQueue) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteQueue) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteRecord(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRecord {
 	cmd := new(DeleteRecord)
 	if len(l) > 0 {
@@ -8513,13 +8995,18 @@ func (cmd *DeleteRecord) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *DeleteRecord) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("record"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "record"), nil
 }
 
 func (cmd *DeleteRecord) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteRecord) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteRepository(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRepository {
 	cmd := new(DeleteRepository)
 	if len(l) > 0 {
@@ -8592,13 +9079,18 @@ func (cmd *DeleteRepository) run(renv env.Running, params map[string]interface{}
 }
 
 func (cmd *DeleteRepository) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("repository"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "repository"), nil
 }
 
 func (cmd *DeleteRepository) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteRepository) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteRole(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRole {
 	cmd := new(DeleteRole)
 	if len(l) > 0 {
@@ -8665,13 +9157,18 @@ func (cmd *DeleteRole) run(renv env.Running, params map[string]interface{}) (int
 }
 
 func (cmd *DeleteRole) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("role"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "role"), nil
 }
 
 func (cmd *DeleteRole) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteRole) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteRoute(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRoute {
 	cmd := new(DeleteRoute)
 	if len(l) > 0 {
@@ -8761,7 +9258,7 @@ func (cmd *DeleteRoute) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DeleteRoute call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: delete route ok")
-			return fakeDryRunId("route"), nil
+			return fakeDryRunId(renv, "route"), nil
 		}
 	}
 
@@ -8772,6 +9269,10 @@ func (cmd *DeleteRoute) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteRoute) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteRoutetable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRoutetable {
 	cmd := new(DeleteRoutetable)
 	if len(l) > 0 {
@@ -8861,7 +9362,7 @@ func (cmd *DeleteRoutetable) dryRun(renv env.Running, params map[string]interfac
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DeleteRouteTable call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: delete routetable ok")
-			return fakeDryRunId("routetable"), nil
+			return fakeDryRunId(renv, "routetable"), nil
 		}
 	}
 
@@ -8872,6 +9373,10 @@ func (cmd *DeleteRoutetable) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteRoutetable) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteS3object(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteS3object {
 	cmd := new(DeleteS3object)
 	if len(l) > 0 {
@@ -8944,13 +9449,18 @@ func (cmd *DeleteS3object) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *DeleteS3object) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("s3object"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "s3object"), nil
 }
 
 func (cmd *DeleteS3object) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteS3object) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteScalinggroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteScalinggroup {
 	cmd := new(DeleteScalinggroup)
 	if len(l) > 0 {
@@ -9023,13 +9533,18 @@ func (cmd *DeleteScalinggroup) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *DeleteScalinggroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("scalinggroup"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "scalinggroup"), nil
 }
 
 func (cmd *DeleteScalinggroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteScalinggroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteScalingpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteScalingpolicy {
 	cmd := new(DeleteScalingpolicy)
 	if len(l) > 0 {
@@ -9102,13 +9617,18 @@ func (cmd *DeleteScalingpolicy) run(renv env.Running, params map[string]interfac
 }
 
 func (cmd *DeleteScalingpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("scalingpolicy"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "scalingpolicy"), nil
 }
 
 func (cmd *DeleteScalingpolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteScalingpolicy) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSecuritygroup {
 	cmd := new(DeleteSecuritygroup)
 	if len(l) > 0 {
@@ -9198,7 +9718,7 @@ func (cmd *DeleteSecuritygroup) dryRun(renv env.Running, params map[string]inter
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DeleteSecurityGroup call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: delete securitygroup ok")
-			return fakeDryRunId("securitygroup"), nil
+			return fakeDryRunId(renv, "securitygroup"), nil
 		}
 	}
 
@@ -9209,6 +9729,10 @@ func (cmd *DeleteSecuritygroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteSecuritygroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteSnapshot(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSnapshot {
 	cmd := new(DeleteSnapshot)
 	if len(l) > 0 {
@@ -9298,7 +9822,7 @@ func (cmd *DeleteSnapshot) dryRun(renv env.Running, params map[string]interface{
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DeleteSnapshot call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: delete snapshot ok")
-			return fakeDryRunId("snapshot"), nil
+			return fakeDryRunId(renv, "snapshot"), nil
 		}
 	}
 
@@ -9309,6 +9833,10 @@ func (cmd *DeleteSnapshot) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteSnapshot) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteStack(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteStack {
 	cmd := new(DeleteStack)
 	if len(l) > 0 {
@@ -9381,13 +9909,18 @@ func (cmd *DeleteStack) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *DeleteStack) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("stack"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "stack"), nil
 }
 
 func (cmd *DeleteStack) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteStack) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteSubnet(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSubnet {
 	cmd := new(DeleteSubnet)
 	if len(l) > 0 {
@@ -9477,7 +10010,7 @@ func (cmd *DeleteSubnet) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DeleteSubnet call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: delete subnet ok")
-			return fakeDryRunId("subnet"), nil
+			return fakeDryRunId(renv, "subnet"), nil
 		}
 	}
 
@@ -9488,6 +10021,10 @@ func (cmd *DeleteSubnet) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteSubnet) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteSubscription(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSubscription {
 	cmd := new(DeleteSubscription)
 	if len(l) > 0 {
@@ -9560,13 +10097,18 @@ func (cmd *DeleteSubscription) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *DeleteSubscription) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("subscription"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "subscription"), nil
 }
 
 func (cmd *DeleteSubscription) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteSubscription) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteTag(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteTag {
 	cmd := new(DeleteTag)
 	if len(l) > 0 {
@@ -9636,6 +10178,10 @@ func (cmd *DeleteTag) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteTag) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteTargetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteTargetgroup {
 	cmd := new(DeleteTargetgroup)
 	if len(l) > 0 {
@@ -9708,13 +10254,18 @@ func (cmd *DeleteTargetgroup) run(renv env.Running, params map[string]interface{
 }
 
 func (cmd *DeleteTargetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("targetgroup"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "targetgroup"), nil
 }
 
 func (cmd *DeleteTargetgroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteTargetgroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteTopic(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteTopic {
 	cmd := new(DeleteTopic)
 	if len(l) > 0 {
@@ -9787,13 +10338,18 @@ func (cmd *DeleteTopic) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *DeleteTopic) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("topic"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "topic"), nil
 }
 
 func (cmd *DeleteTopic) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteTopic) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteUser(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteUser {
 	cmd := new(DeleteUser)
 	if len(l) > 0 {
@@ -9866,13 +10422,18 @@ func (cmd *DeleteUser) run(renv env.Running, params map[string]interface{}) (int
 }
 
 func (cmd *DeleteUser) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("user"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "user"), nil
 }
 
 func (cmd *DeleteUser) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteUser) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteVolume {
 	cmd := new(DeleteVolume)
 	if len(l) > 0 {
@@ -9962,7 +10523,7 @@ func (cmd *DeleteVolume) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DeleteVolume call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: delete volume ok")
-			return fakeDryRunId("volume"), nil
+			return fakeDryRunId(renv, "volume"), nil
 		}
 	}
 
@@ -9973,6 +10534,10 @@ func (cmd *DeleteVolume) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteVolume) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteVpc(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteVpc {
 	cmd := new(DeleteVpc)
 	if len(l) > 0 {
@@ -10062,7 +10627,7 @@ func (cmd *DeleteVpc) dryRun(renv env.Running, params map[string]interface{}) (i
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DeleteVpc call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: delete vpc ok")
-			return fakeDryRunId("vpc"), nil
+			return fakeDryRunId(renv, "vpc"), nil
 		}
 	}
 
@@ -10073,6 +10638,10 @@ func (cmd *DeleteVpc) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteVpc) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDeleteZone(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteZone {
 	cmd := new(DeleteZone)
 	if len(l) > 0 {
@@ -10145,13 +10714,18 @@ func (cmd *DeleteZone) run(renv env.Running, params map[string]interface{}) (int
 }
 
 func (cmd *DeleteZone) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("zone"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "zone"), nil
 }
 
 func (cmd *DeleteZone) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DeleteZone) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachAlarm {
 	cmd := new(DetachAlarm)
 	if len(l) > 0 {
@@ -10218,13 +10792,18 @@ func (cmd *DetachAlarm) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *DetachAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("alarm"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "alarm"), nil
 }
 
 func (cmd *DetachAlarm) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachAlarm) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachContainertask {
 	cmd := new(DetachContainertask)
 	if len(l) > 0 {
@@ -10291,13 +10870,18 @@ func (cmd *DetachContainertask) run(renv env.Running, params map[string]interfac
 }
 
 func (cmd *DetachContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("containertask"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "containertask"), nil
 }
 
 func (cmd *DetachContainertask) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachContainertask) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachElasticip(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachElasticip {
 	cmd := new(DetachElasticip)
 	if len(l) > 0 {
@@ -10387,7 +10971,7 @@ func (cmd *DetachElasticip) dryRun(renv env.Running, params map[string]interface
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DisassociateAddress call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: detach elasticip ok")
-			return fakeDryRunId("elasticip"), nil
+			return fakeDryRunId(renv, "elasticip"), nil
 		}
 	}
 
@@ -10398,6 +10982,10 @@ func (cmd *DetachElasticip) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachElasticip) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachInstance {
 	cmd := new(DetachInstance)
 	if len(l) > 0 {
@@ -10470,13 +11058,18 @@ func (cmd *DetachInstance) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *DetachInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("instance"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "instance"), nil
 }
 
 func (cmd *DetachInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachInstance) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachInstanceprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachInstanceprofile {
 	cmd := new(DetachInstanceprofile)
 	if len(l) > 0 {
@@ -10543,13 +11136,18 @@ func (cmd *DetachInstanceprofile) run(renv env.Running, params map[string]interf
 }
 
 func (cmd *DetachInstanceprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("instanceprofile"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "instanceprofile"), nil
 }
 
 func (cmd *DetachInstanceprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachInstanceprofile) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachInternetgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachInternetgateway {
 	cmd := new(DetachInternetgateway)
 	if len(l) > 0 {
@@ -10639,7 +11237,7 @@ func (cmd *DetachInternetgateway) dryRun(renv env.Running, params map[string]int
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DetachInternetGateway call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: detach internetgateway ok")
-			return fakeDryRunId("internetgateway"), nil
+			return fakeDryRunId(renv, "internetgateway"), nil
 		}
 	}
 
@@ -10650,6 +11248,10 @@ func (cmd *DetachInternetgateway) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachInternetgateway) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachMfadevice(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachMfadevice {
 	cmd := new(DetachMfadevice)
 	if len(l) > 0 {
@@ -10722,13 +11324,18 @@ func (cmd *DetachMfadevice) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *DetachMfadevice) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("mfadevice"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "mfadevice"), nil
 }
 
 func (cmd *DetachMfadevice) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachMfadevice) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachNetworkinterface {
 	cmd := new(DetachNetworkinterface)
 	if len(l) > 0 {
@@ -10798,6 +11405,10 @@ func (cmd *DetachNetworkinterface) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachNetworkinterface) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachPolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachPolicy {
 	cmd := new(DetachPolicy)
 	if len(l) > 0 {
@@ -10864,13 +11475,18 @@ func (cmd *DetachPolicy) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *DetachPolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("policy"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "policy"), nil
 }
 
 func (cmd *DetachPolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachPolicy) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachRole(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachRole {
 	cmd := new(DetachRole)
 	if len(l) > 0 {
@@ -10943,13 +11559,18 @@ func (cmd *DetachRole) run(renv env.Running, params map[string]interface{}) (int
 }
 
 func (cmd *DetachRole) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("role"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "role"), nil
 }
 
 func (cmd *DetachRole) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachRole) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachRoutetable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachRoutetable {
 	cmd := new(DetachRoutetable)
 	if len(l) > 0 {
@@ -11039,7 +11660,7 @@ func (cmd *DetachRoutetable) dryRun(renv env.Running, params map[string]interfac
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DisassociateRouteTable call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: detach routetable ok")
-			return fakeDryRunId("routetable"), nil
+			return fakeDryRunId(renv, "routetable"), nil
 		}
 	}
 
@@ -11050,6 +11671,10 @@ func (cmd *DetachRoutetable) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachRoutetable) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachSecuritygroup {
 	cmd := new(DetachSecuritygroup)
 	if len(l) > 0 {
@@ -11116,13 +11741,18 @@ func (cmd *DetachSecuritygroup) run(renv env.Running, params map[string]interfac
 }
 
 func (cmd *DetachSecuritygroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("securitygroup"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "securitygroup"), nil
 }
 
 func (cmd *DetachSecuritygroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachSecuritygroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachUser(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachUser {
 	cmd := new(DetachUser)
 	if len(l) > 0 {
@@ -11195,13 +11825,18 @@ func (cmd *DetachUser) run(renv env.Running, params map[string]interface{}) (int
 }
 
 func (cmd *DetachUser) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("user"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "user"), nil
 }
 
 func (cmd *DetachUser) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachUser) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewDetachVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachVolume {
 	cmd := new(DetachVolume)
 	if len(l) > 0 {
@@ -11291,7 +11926,7 @@ func (cmd *DetachVolume) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.DetachVolume call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: detach volume ok")
-			return fakeDryRunId("volume"), nil
+			return fakeDryRunId(renv, "volume"), nil
 		}
 	}
 
@@ -11302,6 +11937,10 @@ func (cmd *DetachVolume) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *DetachVolume) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewImportImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *ImportImage {
 	cmd := new(ImportImage)
 	if len(l) > 0 {
@@ -11391,7 +12030,7 @@ func (cmd *ImportImage) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.ImportImage call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: import image ok")
-			return fakeDryRunId("image"), nil
+			return fakeDryRunId(renv, "image"), nil
 		}
 	}
 
@@ -11402,6 +12041,10 @@ func (cmd *ImportImage) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *ImportImage) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewRestartDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *RestartDatabase {
 	cmd := new(RestartDatabase)
 	if len(l) > 0 {
@@ -11474,13 +12117,18 @@ func (cmd *RestartDatabase) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *RestartDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("database"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "database"), nil
 }
 
 func (cmd *RestartDatabase) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *RestartDatabase) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewRestartInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *RestartInstance {
 	cmd := new(RestartInstance)
 	if len(l) > 0 {
@@ -11570,7 +12218,7 @@ func (cmd *RestartInstance) dryRun(renv env.Running, params map[string]interface
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.RebootInstances call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: restart instance ok")
-			return fakeDryRunId("instance"), nil
+			return fakeDryRunId(renv, "instance"), nil
 		}
 	}
 
@@ -11581,6 +12229,10 @@ func (cmd *RestartInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *RestartInstance) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewStartAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartAlarm {
 	cmd := new(StartAlarm)
 	if len(l) > 0 {
@@ -11653,13 +12305,18 @@ func (cmd *StartAlarm) run(renv env.Running, params map[string]interface{}) (int
 }
 
 func (cmd *StartAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("alarm"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "alarm"), nil
 }
 
 func (cmd *StartAlarm) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *StartAlarm) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewStartContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartContainertask {
 	cmd := new(StartContainertask)
 	if len(l) > 0 {
@@ -11726,13 +12383,18 @@ func (cmd *StartContainertask) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *StartContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("containertask"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "containertask"), nil
 }
 
 func (cmd *StartContainertask) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *StartContainertask) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewStartDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartDatabase {
 	cmd := new(StartDatabase)
 	if len(l) > 0 {
@@ -11805,13 +12467,18 @@ func (cmd *StartDatabase) run(renv env.Running, params map[string]interface{}) (
 }
 
 func (cmd *StartDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("database"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "database"), nil
 }
 
 func (cmd *StartDatabase) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *StartDatabase) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewStartInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartInstance {
 	cmd := new(StartInstance)
 	if len(l) > 0 {
@@ -11901,7 +12568,7 @@ func (cmd *StartInstance) dryRun(renv env.Running, params map[string]interface{}
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.StartInstances call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: start instance ok")
-			return fakeDryRunId("instance"), nil
+			return fakeDryRunId(renv, "instance"), nil
 		}
 	}
 
@@ -11912,6 +12579,10 @@ func (cmd *StartInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *StartInstance) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewStopAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StopAlarm {
 	cmd := new(StopAlarm)
 	if len(l) > 0 {
@@ -11984,13 +12655,18 @@ func (cmd *StopAlarm) run(renv env.Running, params map[string]interface{}) (inte
 }
 
 func (cmd *StopAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("alarm"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "alarm"), nil
 }
 
 func (cmd *StopAlarm) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *StopAlarm) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewStopContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StopContainertask {
 	cmd := new(StopContainertask)
 	if len(l) > 0 {
@@ -12057,13 +12733,18 @@ func (cmd *StopContainertask) run(renv env.Running, params map[string]interface{
 }
 
 func (cmd *StopContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("containertask"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "containertask"), nil
 }
 
 func (cmd *StopContainertask) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *StopContainertask) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewStopDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StopDatabase {
 	cmd := new(StopDatabase)
 	if len(l) > 0 {
@@ -12136,13 +12817,18 @@ func (cmd *StopDatabase) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *StopDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("database"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "database"), nil
 }
 
 func (cmd *StopDatabase) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *StopDatabase) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewStopInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StopInstance {
 	cmd := new(StopInstance)
 	if len(l) > 0 {
@@ -12232,7 +12918,7 @@ func (cmd *StopInstance) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.StopInstances call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: stop instance ok")
-			return fakeDryRunId("instance"), nil
+			return fakeDryRunId(renv, "instance"), nil
 		}
 	}
 
@@ -12243,6 +12929,10 @@ func (cmd *StopInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *StopInstance) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateBucket(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateBucket {
 	cmd := new(UpdateBucket)
 	if len(l) > 0 {
@@ -12309,13 +12999,18 @@ func (cmd *UpdateBucket) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *UpdateBucket) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("bucket"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "bucket"), nil
 }
 
 func (cmd *UpdateBucket) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateBucket) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateContainertask {
 	cmd := new(UpdateContainertask)
 	if len(l) > 0 {
@@ -12388,13 +13083,18 @@ func (cmd *UpdateContainertask) run(renv env.Running, params map[string]interfac
 }
 
 func (cmd *UpdateContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("containertask"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "containertask"), nil
 }
 
 func (cmd *UpdateContainertask) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateContainertask) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateDistribution(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateDistribution {
 	cmd := new(UpdateDistribution)
 	if len(l) > 0 {
@@ -12461,13 +13161,18 @@ func (cmd *UpdateDistribution) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *UpdateDistribution) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("distribution"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "distribution"), nil
 }
 
 func (cmd *UpdateDistribution) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateDistribution) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateImage {
 	cmd := new(UpdateImage)
 	if len(l) > 0 {
@@ -12537,6 +13242,10 @@ func (cmd *UpdateImage) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateImage) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateInstance {
 	cmd := new(UpdateInstance)
 	if len(l) > 0 {
@@ -12626,7 +13335,7 @@ func (cmd *UpdateInstance) dryRun(renv env.Running, params map[string]interface{
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			renv.Log().ExtraVerbosef("dry run: ec2.ModifyInstanceAttribute call took %s", time.Since(start))
 			renv.Log().Verbose("dry run: update instance ok")
-			return fakeDryRunId("instance"), nil
+			return fakeDryRunId(renv, "instance"), nil
 		}
 	}
 
@@ -12637,6 +13346,10 @@ func (cmd *UpdateInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateInstance) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateLoginprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateLoginprofile {
 	cmd := new(UpdateLoginprofile)
 	if len(l) > 0 {
@@ -12709,13 +13422,18 @@ func (cmd *UpdateLoginprofile) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *UpdateLoginprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("loginprofile"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "loginprofile"), nil
 }
 
 func (cmd *UpdateLoginprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateLoginprofile) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdatePolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdatePolicy {
 	cmd := new(UpdatePolicy)
 	if len(l) > 0 {
@@ -12788,13 +13506,18 @@ func (cmd *UpdatePolicy) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *UpdatePolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("policy"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "policy"), nil
 }
 
 func (cmd *UpdatePolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdatePolicy) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateRecord(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateRecord {
 	cmd := new(UpdateRecord)
 	if len(l) > 0 {
@@ -12861,13 +13584,18 @@ func (cmd *UpdateRecord) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *UpdateRecord) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("record"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "record"), nil
 }
 
 func (cmd *UpdateRecord) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateRecord) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateS3object(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateS3object {
 	cmd := new(UpdateS3object)
 	if len(l) > 0 {
@@ -12940,13 +13668,18 @@ func (cmd *UpdateS3object) run(renv env.Running, params map[string]interface{})
 }
 
 func (cmd *UpdateS3object) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("s3object"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "s3object"), nil
 }
 
 func (cmd *UpdateS3object) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateS3object) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateScalinggroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateScalinggroup {
 	cmd := new(UpdateScalinggroup)
 	if len(l) > 0 {
@@ -13019,13 +13752,18 @@ func (cmd *UpdateScalinggroup) run(renv env.Running, params map[string]interface
 }
 
 func (cmd *UpdateScalinggroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("scalinggroup"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "scalinggroup"), nil
 }
 
 func (cmd *UpdateScalinggroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateScalinggroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateSecuritygroup {
 	cmd := new(UpdateSecuritygroup)
 	if len(l) > 0 {
@@ -13095,6 +13833,10 @@ func (cmd *UpdateSecuritygroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateSecuritygroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateStack(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateStack {
 	cmd := new(UpdateStack)
 	if len(l) > 0 {
@@ -13167,13 +13909,18 @@ func (cmd *UpdateStack) run(renv env.Running, params map[string]interface{}) (in
 }
 
 func (cmd *UpdateStack) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("stack"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "stack"), nil
 }
 
 func (cmd *UpdateStack) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateStack) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateSubnet(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateSubnet {
 	cmd := new(UpdateSubnet)
 	if len(l) > 0 {
@@ -13246,13 +13993,18 @@ func (cmd *UpdateSubnet) run(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *UpdateSubnet) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("subnet"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "subnet"), nil
 }
 
 func (cmd *UpdateSubnet) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func (cmd *UpdateSubnet) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
+
 func NewUpdateTargetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateTargetgroup {
 	cmd := new(UpdateTargetgroup)
 	if len(l) > 0 {
@@ -13319,9 +14071,14 @@ func (cmd *UpdateTargetgroup) run(renv env.Running, params map[string]interface{
 }
 
 func (cmd *UpdateTargetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("targetgroup"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "targetgroup"), nil
 }
 
 func (cmd *UpdateTargetgroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
+
+func (cmd *UpdateTargetgroup) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
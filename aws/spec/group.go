@@ -36,6 +36,13 @@ func (cmd *CreateGroup) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("name")))
 }
 
+func (cmd *CreateGroup) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	return []string{revertLine("delete", "group", revertParam("name", params["name"]))}, nil
+}
+
 func (cmd *CreateGroup) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*iam.CreateGroupOutput).Group.GroupId)
 }
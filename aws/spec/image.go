@@ -50,6 +50,10 @@ func (cmd *CreateImage) ParamsSpec() params.Spec {
 		})
 }
 
+func (cmd *CreateImage) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "image", "id", result), nil
+}
+
 func (cmd *CreateImage) BeforeRun(renv env.Running) error {
 	if reboot := cmd.Reboot; reboot != nil && *reboot {
 		cmd.Reboot = nil
@@ -133,7 +137,7 @@ func (cmd *UpdateImage) dryRun(renv env.Running, params map[string]interface{})
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 			cmd.logger.ExtraVerbosef("dry run: ec2.ec2.ModifyImageAttribute call took %s", time.Since(start))
 			cmd.logger.Verbose("dry run: update image ok")
-			return fakeDryRunId("image"), nil
+			return fakeDryRunId(renv, "image"), nil
 		}
 	}
 
@@ -158,6 +162,10 @@ func (cmd *CopyImage) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CopyImage) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "image", "id", result, "delete-snapshots=true"), nil
+}
+
 func (cmd *CopyImage) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CopyImageOutput).ImageId)
 }
@@ -231,7 +239,7 @@ func (cmd *DeleteImage) dryRun(renv env.Running, params map[string]interface{})
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound):
-			id := fakeDryRunId("image")
+			id := fakeDryRunId(renv, "image")
 			cmd.logger.Verbose("dry run: delete image ok")
 			return id, nil
 		}
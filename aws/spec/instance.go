@@ -36,7 +36,7 @@ type CreateInstance struct {
 	graph          cloud.GraphAPI
 	api            ec2iface.EC2API
 	Image          *string   `awsName:"ImageId" awsType:"awsstr" templateName:"image"`
-	Count          *int64    `awsName:"MaxCount,MinCount" awsType:"awsin64" templateName:"count"`
+	Count          *int64    `awsName:"MaxCount,MinCount" awsType:"awsint64" templateName:"count"`
 	Type           *string   `awsName:"InstanceType" awsType:"awsstr" templateName:"type"`
 	Name           *string   `templateName:"name"`
 	Subnet         *string   `awsName:"SubnetId" awsType:"awsstr" templateName:"subnet"`
@@ -61,6 +61,16 @@ func (cmd *CreateInstance) ParamsSpec() params.Spec {
 	return builder.Done()
 }
 
+func (cmd *CreateInstance) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	return []string{
+		revertLine("delete", "instance", revertResultParam("id", result)),
+		fmt.Sprintf("check instance %s state=terminated timeout=180", revertResultParam("id", result)),
+	}, nil
+}
+
 func (cmd *CreateInstance) convertDistroToAMI(values map[string]interface{}) (map[string]interface{}, error) {
 	if distro, ok := values["distro"].(string); ok {
 		query, err := ParseImageQuery(distro)
@@ -109,6 +119,36 @@ func (cmd *UpdateInstance) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id"), params.Opt("lock", "type")))
 }
 
+func (cmd *UpdateInstance) SnapshotState(renv env.Running, params map[string]interface{}) (map[string]interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, err
+	}
+	snapshot := make(map[string]interface{})
+	if cmd.Type != nil {
+		out, err := cmd.api.DescribeInstanceAttribute(&ec2.DescribeInstanceAttributeInput{InstanceId: cmd.Id, Attribute: String("instanceType")})
+		if err != nil {
+			return nil, err
+		}
+		if out.InstanceType != nil {
+			snapshot["type"] = StringValue(out.InstanceType.Value)
+		}
+	}
+	if cmd.Lock != nil {
+		out, err := cmd.api.DescribeInstanceAttribute(&ec2.DescribeInstanceAttributeInput{InstanceId: cmd.Id, Attribute: String("disableApiTermination")})
+		if err != nil {
+			return nil, err
+		}
+		if out.DisableApiTermination != nil {
+			snapshot["lock"] = BoolValue(out.DisableApiTermination.Value)
+		}
+	}
+	return snapshot, nil
+}
+
+func (cmd *UpdateInstance) RevertSnapshot(params, snapshot map[string]interface{}) ([]string, error) {
+	return revertSnapshot("instance", params, snapshot, "id"), nil
+}
+
 type DeleteInstance struct {
 	_      string `action:"delete" entity:"instance" awsAPI:"ec2" awsCall:"TerminateInstances" awsInput:"ec2.TerminateInstancesInput" awsOutput:"ec2.TerminateInstancesOutput" awsDryRun:""`
 	logger *logger.Logger
@@ -137,6 +177,17 @@ func (cmd *StartInstance) ParamsSpec() params.Spec {
 	return builder.Done()
 }
 
+func (cmd *StartInstance) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	lines, err := revertIdsCheck("instance", params["ids"], "running", 180)
+	if err != nil {
+		return nil, err
+	}
+	return append(lines, revertLine("stop", "instance", revertParamsExcept(params)...)), nil
+}
+
 func (cmd *StartInstance) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.StartInstancesOutput).StartingInstances[0].InstanceId)
 }
@@ -155,6 +206,17 @@ func (cmd *StopInstance) ParamsSpec() params.Spec {
 	return builder.Done()
 }
 
+func (cmd *StopInstance) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	lines, err := revertIdsCheck("instance", params["ids"], "stopped", 180)
+	if err != nil {
+		return nil, err
+	}
+	return append(lines, revertLine("start", "instance", revertParamsExcept(params)...)), nil
+}
+
 func (cmd *StopInstance) ExtractResult(i interface{}) string {
 	return StringValue(i.(*ec2.StopInstancesOutput).StoppingInstances[0].InstanceId)
 }
@@ -250,6 +312,10 @@ func (cmd *AttachInstance) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *AttachInstance) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "instance", revertParamsExcept(params, "port")...)}, nil
+}
+
 type DetachInstance struct {
 	_           string `action:"detach" entity:"instance" awsAPI:"elbv2" awsCall:"DeregisterTargets" awsInput:"elbv2.DeregisterTargetsInput" awsOutput:"elbv2.DeregisterTargetsOutput"`
 	logger      *logger.Logger
@@ -263,6 +329,10 @@ func (cmd *DetachInstance) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id"), params.Key("targetgroup")))
 }
 
+func (cmd *DetachInstance) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("attach", "instance", revertParamsExcept(params)...)}, nil
+}
+
 func idToIds(values map[string]interface{}) (map[string]interface{}, error) {
 	if id, hasID := values["id"]; hasID {
 		return map[string]interface{}{"ids": id}, nil
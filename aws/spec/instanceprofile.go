@@ -43,6 +43,10 @@ func (cmd *CreateInstanceprofile) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("name")))
 }
 
+func (cmd *CreateInstanceprofile) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("delete", "instanceprofile", revertParam("name", params["name"]))}, nil
+}
+
 type DeleteInstanceprofile struct {
 	_      string `action:"delete" entity:"instanceprofile" awsAPI:"iam" awsCall:"DeleteInstanceProfile" awsInput:"iam.DeleteInstanceProfileInput" awsOutput:"iam.DeleteInstanceProfileOutput"`
 	logger *logger.Logger
@@ -55,6 +59,10 @@ func (cmd *DeleteInstanceprofile) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("name")))
 }
 
+func (cmd *DeleteInstanceprofile) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("create", "instanceprofile", revertParam("name", params["name"]))}, nil
+}
+
 type AttachInstanceprofile struct {
 	_        string `action:"attach" entity:"instanceprofile" awsAPI:"ec2" awsDryRun:"manual"`
 	logger   *logger.Logger
@@ -71,6 +79,10 @@ func (cmd *AttachInstanceprofile) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *AttachInstanceprofile) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "instanceprofile", revertParamsExcept(params)...)}, nil
+}
+
 func (cmd *AttachInstanceprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
@@ -92,7 +104,7 @@ func (cmd *AttachInstanceprofile) dryRun(renv env.Running, params map[string]int
 		}
 	}
 	cmd.logger.Verbose("params dry run: attach instanceprofile ok")
-	return fakeDryRunId("instanceprofile"), nil
+	return fakeDryRunId(renv, "instanceprofile"), nil
 }
 
 func (cmd *AttachInstanceprofile) ManualRun(renv env.Running) (interface{}, error) {
@@ -164,6 +176,10 @@ func (cmd *DetachInstanceprofile) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("instance"), params.Key("name")))
 }
 
+func (cmd *DetachInstanceprofile) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("attach", "instanceprofile", revertParamsExcept(params)...)}, nil
+}
+
 func (cmd *DetachInstanceprofile) ManualRun(renv env.Running) (interface{}, error) {
 	instanceId := StringValue(cmd.Instance)
 	profileName := StringValue(cmd.Name)
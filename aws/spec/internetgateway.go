@@ -35,6 +35,10 @@ func (cmd *CreateInternetgateway) ParamsSpec() params.Spec {
 	return params.NewSpec(params.None())
 }
 
+func (cmd *CreateInternetgateway) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "internetgateway", "id", result), nil
+}
+
 func (cmd *CreateInternetgateway) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CreateInternetGatewayOutput).InternetGateway.InternetGatewayId)
 }
@@ -64,6 +68,10 @@ func (cmd *AttachInternetgateway) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id"), params.Key("vpc")))
 }
 
+func (cmd *AttachInternetgateway) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "internetgateway", revertParamsExcept(params)...)}, nil
+}
+
 type DetachInternetgateway struct {
 	_      string `action:"detach" entity:"internetgateway" awsAPI:"ec2" awsCall:"DetachInternetGateway" awsInput:"ec2.DetachInternetGatewayInput" awsOutput:"ec2.DetachInternetGatewayOutput" awsDryRun:""`
 	logger *logger.Logger
@@ -76,3 +84,7 @@ type DetachInternetgateway struct {
 func (cmd *DetachInternetgateway) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id"), params.Key("vpc")))
 }
+
+func (cmd *DetachInternetgateway) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("attach", "internetgateway", revertParamsExcept(params)...)}, nil
+}
@@ -63,6 +63,10 @@ func (cmd *CreateKeypair) ParamsSpec() params.Spec {
 		})
 }
 
+func (cmd *CreateKeypair) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "keypair", "name", result), nil
+}
+
 func (cmd *CreateKeypair) BeforeRun(renv env.Running) error {
 	var encryptedMsg string
 	var encrypted bool
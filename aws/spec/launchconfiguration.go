@@ -52,6 +52,10 @@ func (cmd *CreateLaunchconfiguration) ParamsSpec() params.Spec {
 	return builder.Done()
 }
 
+func (cmd *CreateLaunchconfiguration) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "launchconfiguration", "name", result), nil
+}
+
 func (cmd *CreateLaunchconfiguration) ExtractResult(i interface{}) string {
 	return StringValue(cmd.Name)
 }
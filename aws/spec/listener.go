@@ -44,6 +44,10 @@ func (cmd *CreateListener) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateListener) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "listener", "id", result), nil
+}
+
 func (cmd *CreateListener) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*elbv2.CreateListenerOutput).Listeners[0].ListenerArn)
 }
@@ -50,6 +50,16 @@ func (cmd *CreateLoadbalancer) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateLoadbalancer) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	return []string{
+		revertLine("delete", "loadbalancer", revertResultParam("id", result)),
+		fmt.Sprintf("check loadbalancer %s state=not-found timeout=180", revertResultParam("id", result)),
+	}, nil
+}
+
 func (cmd *CreateLoadbalancer) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*elbv2.CreateLoadBalancerOutput).LoadBalancers[0].LoadBalancerArn)
 }
@@ -40,6 +40,13 @@ func (cmd *CreateLoginprofile) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateLoginprofile) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	return []string{revertLine("delete", "loginprofile", revertParam("username", params["username"]))}, nil
+}
+
 func (cmd *CreateLoginprofile) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*iam.CreateLoginProfileOutput).LoginProfile.UserName)
 }
@@ -50,6 +50,10 @@ func (cmd *CreateMfadevice) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("name")))
 }
 
+func (cmd *CreateMfadevice) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "mfadevice", "id", result), nil
+}
+
 func (cmd *CreateMfadevice) ManualRun(renv env.Running) (interface{}, error) {
 	name := StringValue(cmd.Name)
 	input := &iam.CreateVirtualMFADeviceInput{
@@ -121,6 +125,10 @@ func (cmd *AttachMfadevice) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *AttachMfadevice) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "mfadevice", revertParam("id", params["id"]), revertParam("user", params["user"]))}, nil
+}
+
 func (cmd *AttachMfadevice) AfterRun(renv env.Running, output interface{}) error {
 	if !BoolValue(cmd.NoPrompt) {
 		if promptConfirm("\nDo you want to create a profile for this MFA device in %s?", awsConfigFilepath) {
@@ -174,6 +182,10 @@ func (cmd *DetachMfadevice) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id"), params.Key("user")))
 }
 
+func (cmd *DetachMfadevice) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("attach", "mfadevice", revertParamsExcept(params)...)}, nil
+}
+
 func displayQRCode(w io.Writer, qrCode barcode.Barcode) {
 	white := color.New(color.BgWhite)
 	black := color.New(color.BgBlack)
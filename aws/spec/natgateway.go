@@ -43,6 +43,16 @@ func (cmd *CreateNatgateway) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("elasticip-id"), params.Key("subnet")))
 }
 
+func (cmd *CreateNatgateway) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	return []string{
+		revertLine("delete", "natgateway", revertResultParam("id", result)),
+		fmt.Sprintf("check natgateway %s state=deleted timeout=180", revertResultParam("id", result)),
+	}, nil
+}
+
 func (cmd *CreateNatgateway) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CreateNatGatewayOutput).NatGateway.NatGatewayId)
 }
@@ -50,6 +50,10 @@ func (cmd *CreateNetworkinterface) ParamsSpec() params.Spec {
 	)
 }
 
+func (cmd *CreateNetworkinterface) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "networkinterface", "id", result), nil
+}
+
 func (cmd *CreateNetworkinterface) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CreateNetworkInterfaceOutput).NetworkInterface.NetworkInterfaceId)
 }
@@ -80,6 +84,10 @@ func (cmd *AttachNetworkinterface) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("device-index"), params.Key("id"), params.Key("instance")))
 }
 
+func (cmd *AttachNetworkinterface) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "networkinterface", revertResultParam("attachment", result))}, nil
+}
+
 func (cmd *AttachNetworkinterface) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.AttachNetworkInterfaceOutput).AttachmentId)
 }
@@ -103,6 +111,10 @@ func (cmd *DetachNetworkinterface) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *DetachNetworkinterface) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("attach", "networkinterface", revertParamsExcept(params)...)}, nil
+}
+
 func (cmd *DetachNetworkinterface) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
@@ -136,7 +148,7 @@ func (cmd *DetachNetworkinterface) dryRun(renv env.Running, params map[string]in
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound):
-			id := fakeDryRunId("networkinterface")
+			id := fakeDryRunId(renv, "networkinterface")
 			cmd.logger.Verbose("dry run: detach networkinterface ok")
 			return id, nil
 		}
@@ -58,6 +58,10 @@ func (cmd *CreatePolicy) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreatePolicy) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "policy", "arn", result, "all-versions=true"), nil
+}
+
 func (cmd *CreatePolicy) BeforeRun(renv env.Running) error {
 	stat, err := buildStatementFromParams(cmd.Effect, cmd.Resource, cmd.Action, cmd.Conditions)
 	if err != nil {
@@ -223,6 +227,10 @@ func (cmd *AttachPolicy) ParamsSpec() params.Spec {
 	return builder.Done()
 }
 
+func (cmd *AttachPolicy) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "policy", revertParamsExcept(params)...)}, nil
+}
+
 func transformAccessServiceToARN(values map[string]interface{}) (map[string]interface{}, error) {
 	service, hasService := values["service"].(string)
 	access, hasAccess := values["access"].(string)
@@ -287,6 +295,10 @@ func (cmd *DetachPolicy) ParamsSpec() params.Spec {
 	return builder.Done()
 }
 
+func (cmd *DetachPolicy) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("attach", "policy", revertParamsExcept(params)...)}, nil
+}
+
 func (cmd *DetachPolicy) ManualRun(renv env.Running) (interface{}, error) {
 	start := time.Now()
 	switch {
@@ -45,6 +45,10 @@ func (cmd *CreateQueue) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateQueue) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "queue", "url", result), nil
+}
+
 func (cmd *CreateQueue) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*sqs.CreateQueueOutput).QueueUrl)
 }
@@ -52,6 +52,10 @@ func (cmd *CreateRecord) ParamsSpec() params.Spec {
 	return builder.Done()
 }
 
+func (cmd *CreateRecord) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("delete", "record", revertParamsExcept(params, "comment")...)}, nil
+}
+
 func (cmd *CreateRecord) ManualRun(renv env.Running) (interface{}, error) {
 	start := time.Now()
 	output, err := changeResourceRecordSets(cmd.api, String("CREATE"), cmd.Zone, cmd.Name, cmd.Type, cmd.Values, cmd.Comment, cmd.Ttl)
@@ -152,6 +156,10 @@ func (cmd *DeleteRecord) ParamsSpec() params.Spec {
 	return builder.Done()
 }
 
+func (cmd *DeleteRecord) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("create", "record", revertParamsExcept(params)...)}, nil
+}
+
 func (cmd *DeleteRecord) ManualRun(renv env.Running) (interface{}, error) {
 	start := time.Now()
 	output, err := changeResourceRecordSets(cmd.api, String("DELETE"), cmd.Zone, cmd.Name, cmd.Type, cmd.Values, nil, cmd.Ttl)
@@ -36,6 +36,13 @@ func (cmd *CreateRepository) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("name")))
 }
 
+func (cmd *CreateRepository) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	return []string{revertLine("delete", "repository", revertParam("name", params["name"]))}, nil
+}
+
 func (cmd *CreateRepository) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ecr.CreateRepositoryOutput).Repository.RepositoryArn)
 }
@@ -0,0 +1,117 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wallix/awless/template/params"
+)
+
+func revertLine(action, entity string, params ...string) string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s", action, entity, strings.Join(params, " ")))
+}
+
+func revertParam(key string, v interface{}) string {
+	return fmt.Sprintf("%s=%s", key, printItem(v))
+}
+
+func revertParamsExcept(params map[string]interface{}, skip ...string) (out []string) {
+	for k, v := range params {
+		if contains(skip, k) {
+			continue
+		}
+		out = append(out, revertParam(k, v))
+	}
+	return
+}
+
+func revertResultParam(key string, result interface{}) string {
+	return fmt.Sprintf("%s=%s", key, quoteParamIfNeeded(result))
+}
+
+func hasResult(result interface{}) bool {
+	v, ok := result.(string)
+	return ok && v != ""
+}
+
+// revertWithResult reverts commands identified by their result: no result, no revert
+func revertWithResult(action, entity, key string, result interface{}, extras ...string) []string {
+	if !hasResult(result) {
+		return nil
+	}
+	return []string{revertLine(action, entity, append([]string{revertResultParam(key, result)}, extras...)...)}
+}
+
+// revertSnapshot updates back the snapshotted values of the resource identified by the given params
+func revertSnapshot(entity string, params, snapshot map[string]interface{}, idKeys ...string) []string {
+	if len(snapshot) == 0 {
+		return nil
+	}
+	var revert []string
+	for _, k := range idKeys {
+		revert = append(revert, revertParam(k, params[k]))
+	}
+	revert = append(revert, revertParamsExcept(snapshot)...)
+	return []string{revertLine("update", entity, revert...)}
+}
+
+func revertIdsCheck(entity string, ids interface{}, state string, timeout int) ([]string, error) {
+	var lines []string
+	switch vv := ids.(type) {
+	case string:
+		lines = append(lines, fmt.Sprintf("check %s id=%s state=%s timeout=%d", entity, printItem(vv), state, timeout))
+	case []interface{}:
+		for _, s := range vv {
+			lines = append(lines, fmt.Sprintf("check %s id=%v state=%s timeout=%d", entity, printItem(s), state, timeout))
+		}
+	default:
+		return nil, fmt.Errorf("unexpected type of ids: %T", vv)
+	}
+	return lines, nil
+}
+
+func printItem(i interface{}) string {
+	switch ii := i.(type) {
+	case string:
+		return quoteParamIfNeeded(i)
+	case []interface{}:
+		var out []string
+		for _, e := range ii {
+			out = append(out, printItem(e))
+		}
+		return "[" + strings.Join(out, ",") + "]"
+	case float64: // numbers of snapshots restored from JSON
+		return strconv.FormatFloat(ii, 'f', -1, 64)
+	default:
+		return fmt.Sprint(ii)
+	}
+}
+
+func quoteParamIfNeeded(param interface{}) string {
+	input := fmt.Sprint(param)
+	if params.SimpleStringValue.MatchString(input) {
+		return input
+	} else {
+		if strings.ContainsRune(input, '\'') {
+			return "\"" + input + "\""
+		} else {
+			return "'" + input + "'"
+		}
+	}
+}
@@ -47,6 +47,13 @@ func (cmd *CreateRole) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateRole) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	return []string{revertLine("delete", "role", revertParam("name", params["name"]))}, nil
+}
+
 func (cmd *CreateRole) ManualRun(renv env.Running) (interface{}, error) {
 	princ := new(principal)
 	if cmd.PrincipalAccount != nil {
@@ -158,6 +165,10 @@ func (cmd *AttachRole) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("instanceprofile"), params.Key("name")))
 }
 
+func (cmd *AttachRole) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "role", revertParamsExcept(params)...)}, nil
+}
+
 type DetachRole struct {
 	_               string `action:"detach" entity:"role" awsAPI:"iam" awsCall:"RemoveRoleFromInstanceProfile" awsInput:"iam.RemoveRoleFromInstanceProfileInput" awsOutput:"iam.RemoveRoleFromInstanceProfileOutput"`
 	logger          *logger.Logger
@@ -170,3 +181,7 @@ type DetachRole struct {
 func (cmd *DetachRole) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("instanceprofile"), params.Key("name")))
 }
+
+func (cmd *DetachRole) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("attach", "role", revertParamsExcept(params)...)}, nil
+}
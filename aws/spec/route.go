@@ -39,6 +39,10 @@ func (cmd *CreateRoute) ParamsSpec() params.Spec {
 		params.Validators{"cidr": params.IsCIDR})
 }
 
+func (cmd *CreateRoute) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("delete", "route", revertParamsExcept(params, "gateway")...)}, nil
+}
+
 type DeleteRoute struct {
 	_      string `action:"delete" entity:"route" awsAPI:"ec2" awsCall:"DeleteRoute" awsInput:"ec2.DeleteRouteInput" awsOutput:"ec2.DeleteRouteOutput" awsDryRun:""`
 	logger *logger.Logger
@@ -36,6 +36,10 @@ func (cmd *CreateRoutetable) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("vpc")))
 }
 
+func (cmd *CreateRoutetable) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "routetable", "id", result), nil
+}
+
 func (cmd *CreateRoutetable) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CreateRouteTableOutput).RouteTable.RouteTableId)
 }
@@ -65,6 +69,10 @@ func (cmd *AttachRoutetable) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id"), params.Key("subnet")))
 }
 
+func (cmd *AttachRoutetable) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "routetable", revertResultParam("association", result))}, nil
+}
+
 func (cmd *AttachRoutetable) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.AssociateRouteTableOutput).AssociationId)
 }
@@ -49,6 +49,10 @@ func (cmd *CreateS3object) ParamsSpec() params.Spec {
 	)
 }
 
+func (cmd *CreateS3object) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "s3object", "name", result, revertParam("bucket", params["bucket"])), nil
+}
+
 func (cmd *CreateS3object) ManualRun(env.Running) (interface{}, error) {
 	input := &s3.PutObjectInput{}
 
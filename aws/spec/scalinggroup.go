@@ -17,6 +17,7 @@ package awsspec
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/wallix/awless/cloud"
@@ -52,6 +53,17 @@ func (cmd *CreateScalinggroup) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateScalinggroup) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	return []string{
+		fmt.Sprintf("update scalinggroup %s max-size=0 min-size=0", revertResultParam("name", result)),
+		fmt.Sprintf("check scalinggroup count=0 %s timeout=600", revertResultParam("name", result)),
+		revertLine("delete", "scalinggroup", revertResultParam("name", result), "force=true"),
+	}, nil
+}
+
 func (cmd *CreateScalinggroup) ExtractResult(i interface{}) string {
 	return StringValue(cmd.Name)
 }
@@ -79,6 +91,57 @@ func (cmd *UpdateScalinggroup) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *UpdateScalinggroup) SnapshotState(renv env.Running, params map[string]interface{}) (map[string]interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, err
+	}
+	out, err := cmd.api.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{AutoScalingGroupNames: []*string{cmd.Name}})
+	if err != nil {
+		return nil, err
+	}
+	if len(out.AutoScalingGroups) != 1 {
+		return nil, fmt.Errorf("scalinggroup %s not found", StringValue(cmd.Name))
+	}
+	group := out.AutoScalingGroups[0]
+	snapshot := make(map[string]interface{})
+	if cmd.Cooldown != nil {
+		snapshot["cooldown"] = Int64AsIntValue(group.DefaultCooldown)
+	}
+	if cmd.DesiredCapacity != nil {
+		snapshot["desired-capacity"] = Int64AsIntValue(group.DesiredCapacity)
+	}
+	if cmd.HealthcheckGracePeriod != nil {
+		snapshot["healthcheck-grace-period"] = Int64AsIntValue(group.HealthCheckGracePeriod)
+	}
+	if cmd.HealthcheckType != nil {
+		snapshot["healthcheck-type"] = StringValue(group.HealthCheckType)
+	}
+	if cmd.Launchconfiguration != nil {
+		snapshot["launchconfiguration"] = StringValue(group.LaunchConfigurationName)
+	}
+	if cmd.MaxSize != nil {
+		snapshot["max-size"] = Int64AsIntValue(group.MaxSize)
+	}
+	if cmd.MinSize != nil {
+		snapshot["min-size"] = Int64AsIntValue(group.MinSize)
+	}
+	if cmd.NewInstancesProtected != nil {
+		snapshot["new-instances-protected"] = BoolValue(group.NewInstancesProtectedFromScaleIn)
+	}
+	if len(cmd.Subnets) > 0 {
+		var subnets []interface{}
+		for _, s := range strings.Split(StringValue(group.VPCZoneIdentifier), ",") {
+			subnets = append(subnets, s)
+		}
+		snapshot["subnets"] = subnets
+	}
+	return snapshot, nil
+}
+
+func (cmd *UpdateScalinggroup) RevertSnapshot(params, snapshot map[string]interface{}) ([]string, error) {
+	return revertSnapshot("scalinggroup", params, snapshot, "name"), nil
+}
+
 type DeleteScalinggroup struct {
 	_      string `action:"delete" entity:"scalinggroup" awsAPI:"autoscaling" awsCall:"DeleteAutoScalingGroup" awsInput:"autoscaling.DeleteAutoScalingGroupInput" awsOutput:"autoscaling.DeleteAutoScalingGroupOutput"`
 	logger *logger.Logger
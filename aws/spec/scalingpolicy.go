@@ -43,6 +43,10 @@ func (cmd *CreateScalingpolicy) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateScalingpolicy) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "scalingpolicy", "id", result), nil
+}
+
 func (cmd *CreateScalingpolicy) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*autoscaling.PutScalingPolicyOutput).PolicyARN)
 }
@@ -47,6 +47,16 @@ func (cmd *CreateSecuritygroup) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("description"), params.Key("name"), params.Key("vpc")))
 }
 
+func (cmd *CreateSecuritygroup) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	return []string{
+		fmt.Sprintf("check securitygroup %s state=unused timeout=300", revertResultParam("id", result)),
+		revertLine("delete", "securitygroup", revertResultParam("id", result)),
+	}, nil
+}
+
 func (cmd *CreateSecuritygroup) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CreateSecurityGroupOutput).GroupId)
 }
@@ -86,6 +96,23 @@ func (cmd *UpdateSecuritygroup) ParamsSpec() params.Spec {
 		})
 }
 
+func (cmd *UpdateSecuritygroup) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	var revert []string
+	for k, v := range params {
+		if k == "inbound" || k == "outbound" {
+			switch fmt.Sprint(v) {
+			case "authorize":
+				revert = append(revert, fmt.Sprintf("%s=revoke", k))
+			case "revoke":
+				revert = append(revert, fmt.Sprintf("%s=authorize", k))
+			}
+			continue
+		}
+		revert = append(revert, revertParam(k, v))
+	}
+	return []string{revertLine("update", "securitygroup", revert...)}, nil
+}
+
 func (cmd *UpdateSecuritygroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
@@ -266,6 +293,10 @@ func (cmd *AttachSecuritygroup) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id"), params.Key("instance")))
 }
 
+func (cmd *AttachSecuritygroup) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "securitygroup", revertParamsExcept(params)...)}, nil
+}
+
 func (cmd *AttachSecuritygroup) ManualRun(renv env.Running) (interface{}, error) {
 	groups, err := fetchInstanceSecurityGroups(cmd.api, StringValue(cmd.Instance))
 	if err != nil {
@@ -298,6 +329,10 @@ func (cmd *DetachSecuritygroup) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id"), params.Key("instance")))
 }
 
+func (cmd *DetachSecuritygroup) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("attach", "securitygroup", revertParamsExcept(params)...)}, nil
+}
+
 func (cmd *DetachSecuritygroup) ManualRun(renv env.Running) (interface{}, error) {
 	groups, err := fetchInstanceSecurityGroups(cmd.api, StringValue(cmd.Instance))
 	if err != nil {
@@ -577,31 +577,10 @@ func structSetter(s interface{}, params map[string]interface{}) error {
 	for i := 0; i < stru.NumField(); i++ {
 		field := stru.Field(i)
 		tplName := field.Tag.Get("templateName")
-		var fieldType string
 		if v, ok := params[tplName]; ok {
-			kind := field.Type.Kind()
-			if kind == reflect.Ptr {
-				switch field.Type.Elem().Kind() {
-				case reflect.String:
-					fieldType = awsstr
-				case reflect.Int64:
-					fieldType = awsint64
-				case reflect.Bool:
-					fieldType = awsbool
-				case reflect.Float64:
-					fieldType = awsfloat
-				default:
-					return fmt.Errorf("unknown type %s for parameter %s in struct setter", tplName, field.Type.String())
-				}
-			} else if kind == reflect.Slice && field.Type.Elem().Kind() == reflect.Ptr {
-				switch field.Type.Elem().Elem().Kind() {
-				case reflect.String:
-					fieldType = awsstringslice
-				case reflect.Int64:
-					fieldType = awsint64slice
-				default:
-					return fmt.Errorf("unknown type in slice %s for parameter %s", field.Type.String(), tplName)
-				}
+			fieldType, err := structFieldType(field)
+			if err != nil {
+				return err
 			}
 			if err := setFieldWithType(v, s, field.Name, fieldType); err != nil {
 				return fmt.Errorf("%s: %s", tplName, err)
@@ -611,6 +590,54 @@ func structSetter(s interface{}, params map[string]interface{}) error {
 	return nil
 }
 
+// structParamType returns the awsType of a template param, defaulting to the type its value is set with
+func structParamType(s interface{}, key string) (string, bool) {
+	stru := reflect.TypeOf(s).Elem()
+	for i := 0; i < stru.NumField(); i++ {
+		field := stru.Field(i)
+		if field.Tag.Get("templateName") != key {
+			continue
+		}
+		if awsType, ok := field.Tag.Lookup("awsType"); ok {
+			return awsType, true
+		}
+		if fieldType, err := structFieldType(field); err == nil && fieldType != "" {
+			return fieldType, true
+		}
+		return "", false
+	}
+	return "", false
+}
+
+func structFieldType(field reflect.StructField) (string, error) {
+	tplName := field.Tag.Get("templateName")
+	kind := field.Type.Kind()
+	if kind == reflect.Ptr {
+		switch field.Type.Elem().Kind() {
+		case reflect.String:
+			return awsstr, nil
+		case reflect.Int64:
+			return awsint64, nil
+		case reflect.Bool:
+			return awsbool, nil
+		case reflect.Float64:
+			return awsfloat, nil
+		default:
+			return "", fmt.Errorf("unknown type %s for parameter %s in struct setter", tplName, field.Type.String())
+		}
+	} else if kind == reflect.Slice && field.Type.Elem().Kind() == reflect.Ptr {
+		switch field.Type.Elem().Elem().Kind() {
+		case reflect.String:
+			return awsstringslice, nil
+		case reflect.Int64:
+			return awsint64slice, nil
+		default:
+			return "", fmt.Errorf("unknown type in slice %s for parameter %s", field.Type.String(), tplName)
+		}
+	}
+	return "", nil
+}
+
 func structInjector(src, dest interface{}, ctx map[string]interface{}) error {
 	val := reflect.ValueOf(src).Elem()
 	stru := val.Type()
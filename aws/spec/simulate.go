@@ -0,0 +1,240 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"fmt"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/match"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/template/env"
+)
+
+// simulate checks the preconditions of a command whose API has no dry run. As the local
+// graph may be stale, failed checks are reported as warnings rather than failing the dry run.
+func simulate(renv env.Running, cmd interface{}, params map[string]interface{}) {
+	v, ok := implementsSimulator(cmd)
+	if !ok {
+		return
+	}
+	if err := v.Simulate(simulationOf(renv), params); err != nil && renv != nil {
+		renv.Log().Warningf("dry run: %s", err)
+	}
+}
+
+func simulationOf(renv env.Running) *env.Simulation {
+	if renv == nil {
+		return env.NewSimulation()
+	}
+	return renv.Simulation()
+}
+
+// simulateExists fails if a referenced resource is not in the local graph nor created earlier in the template,
+// or if deleted earlier in the template. Resources of types never synced locally cannot be verified and pass.
+func simulateExists(sim *env.Simulation, g cloud.GraphAPI, resourceType, property string, value interface{}) error {
+	for _, v := range simulatedValues(value) {
+		if sim.IsCreated(resourceType, v) {
+			continue
+		}
+		if sim.IsDeleted(resourceType, v) {
+			return fmt.Errorf("%s '%s' deleted earlier in template", resourceType, v)
+		}
+		found, known, err := findInLocalGraph(g, resourceType, property, v)
+		if err != nil {
+			return err
+		}
+		if known && !found {
+			return fmt.Errorf("%s '%s' not found in local graph", resourceType, v)
+		}
+	}
+	return nil
+}
+
+// simulateUnused fails if a resource with the same property value is already in the local graph
+// (unless deleted earlier in the template) or created earlier in the template
+func simulateUnused(sim *env.Simulation, g cloud.GraphAPI, resourceType, property string, value interface{}) error {
+	for _, v := range simulatedValues(value) {
+		if sim.IsCreated(resourceType, v) {
+			return fmt.Errorf("%s '%s' created earlier in template", resourceType, v)
+		}
+		if sim.IsDeleted(resourceType, v) {
+			continue
+		}
+		found, _, err := findInLocalGraph(g, resourceType, property, v)
+		if err != nil {
+			return err
+		}
+		if found {
+			return fmt.Errorf("%s '%s' already exists", resourceType, v)
+		}
+	}
+	return nil
+}
+
+func simulateCreate(sim *env.Simulation, resourceType string, value interface{}) {
+	for _, v := range simulatedValues(value) {
+		sim.Create(resourceType, v)
+	}
+}
+
+func simulateDelete(sim *env.Simulation, resourceType string, value interface{}) {
+	for _, v := range simulatedValues(value) {
+		sim.Delete(resourceType, v)
+	}
+}
+
+func findInLocalGraph(g cloud.GraphAPI, resourceType, property, value string) (found bool, known bool, err error) {
+	if g == nil {
+		return false, false, nil
+	}
+	all, err := g.Find(cloud.NewQuery(resourceType))
+	if err != nil || len(all) == 0 {
+		return false, false, err
+	}
+	matching, err := g.Find(cloud.NewQuery(resourceType).Match(match.Property(property, value)))
+	return len(matching) > 0, true, err
+}
+
+func simulatedValues(value interface{}) (values []string) {
+	switch v := value.(type) {
+	case nil:
+	case []interface{}:
+		for _, e := range v {
+			values = append(values, simulatedValues(e)...)
+		}
+	case []string:
+		values = append(values, v...)
+	default:
+		values = append(values, fmt.Sprint(v))
+	}
+	return
+}
+
+func (cmd *CreateBucket) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateCreate(sim, cloud.Bucket, params["name"])
+	return simulateUnused(sim, cmd.graph, cloud.Bucket, properties.ID, params["name"])
+}
+
+func (cmd *DeleteBucket) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateDelete(sim, cloud.Bucket, params["name"])
+	return simulateExists(sim, cmd.graph, cloud.Bucket, properties.ID, params["name"])
+}
+
+func (cmd *CreateUser) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateCreate(sim, cloud.User, params["name"])
+	return simulateUnused(sim, cmd.graph, cloud.User, properties.Name, params["name"])
+}
+
+func (cmd *DeleteUser) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateDelete(sim, cloud.User, params["name"])
+	if err := simulateExists(sim, cmd.graph, cloud.User, properties.Name, params["name"]); err != nil {
+		return err
+	}
+	if cmd.graph == nil {
+		return nil
+	}
+	keys, err := cmd.graph.Find(cloud.NewQuery(cloud.AccessKey).Match(match.Property(properties.Username, params["name"])))
+	if err != nil {
+		return err
+	}
+	var remaining int
+	for _, key := range keys {
+		if !sim.IsDeleted(cloud.AccessKey, key.Id()) {
+			remaining++
+		}
+	}
+	if remaining > 0 {
+		return fmt.Errorf("user '%s' still has %d access key(s): delete them first", params["name"], remaining)
+	}
+	return nil
+}
+
+func (cmd *DeleteAccesskey) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateDelete(sim, cloud.AccessKey, params["id"])
+	return simulateExists(sim, cmd.graph, cloud.AccessKey, properties.ID, params["id"])
+}
+
+func (cmd *CreateGroup) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateCreate(sim, cloud.Group, params["name"])
+	return simulateUnused(sim, cmd.graph, cloud.Group, properties.Name, params["name"])
+}
+
+func (cmd *DeleteGroup) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateDelete(sim, cloud.Group, params["name"])
+	return simulateExists(sim, cmd.graph, cloud.Group, properties.Name, params["name"])
+}
+
+func (cmd *CreateRole) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateCreate(sim, cloud.Role, params["name"])
+	return simulateUnused(sim, cmd.graph, cloud.Role, properties.Name, params["name"])
+}
+
+func (cmd *DeleteRole) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateDelete(sim, cloud.Role, params["name"])
+	return simulateExists(sim, cmd.graph, cloud.Role, properties.Name, params["name"])
+}
+
+func (cmd *CreateLoadbalancer) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateCreate(sim, cloud.LoadBalancer, params["name"])
+	if err := simulateUnused(sim, cmd.graph, cloud.LoadBalancer, properties.Name, params["name"]); err != nil {
+		return err
+	}
+	if err := simulateExists(sim, cmd.graph, cloud.Subnet, properties.ID, params["subnets"]); err != nil {
+		return err
+	}
+	return simulateExists(sim, cmd.graph, cloud.SecurityGroup, properties.ID, params["securitygroups"])
+}
+
+func (cmd *DeleteLoadbalancer) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateDelete(sim, cloud.LoadBalancer, params["id"])
+	return simulateExists(sim, cmd.graph, cloud.LoadBalancer, properties.ID, params["id"])
+}
+
+func (cmd *CreateTargetgroup) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	return simulateExists(sim, cmd.graph, cloud.Vpc, properties.ID, params["vpc"])
+}
+
+func (cmd *CreateListener) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	if err := simulateExists(sim, cmd.graph, cloud.LoadBalancer, properties.ID, params["loadbalancer"]); err != nil {
+		return err
+	}
+	return simulateExists(sim, cmd.graph, cloud.TargetGroup, properties.ID, params["targetgroup"])
+}
+
+func (cmd *CreateDatabase) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateCreate(sim, cloud.Database, params["id"])
+	if err := simulateUnused(sim, cmd.graph, cloud.Database, properties.ID, params["id"]); err != nil {
+		return err
+	}
+	if err := simulateExists(sim, cmd.graph, cloud.DbSubnetGroup, properties.Name, params["subnetgroup"]); err != nil {
+		return err
+	}
+	return simulateExists(sim, cmd.graph, cloud.SecurityGroup, properties.ID, params["vpcsecuritygroups"])
+}
+
+func (cmd *DeleteDatabase) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateDelete(sim, cloud.Database, params["id"])
+	return simulateExists(sim, cmd.graph, cloud.Database, properties.ID, params["id"])
+}
+
+func (cmd *CreateDbsubnetgroup) Simulate(sim *env.Simulation, params map[string]interface{}) error {
+	defer simulateCreate(sim, cloud.DbSubnetGroup, params["name"])
+	if err := simulateUnused(sim, cmd.graph, cloud.DbSubnetGroup, properties.Name, params["name"]); err != nil {
+		return err
+	}
+	return simulateExists(sim, cmd.graph, cloud.Subnet, properties.ID, params["subnets"])
+}
@@ -0,0 +1,110 @@
+package awsspec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/env"
+)
+
+func TestSimulateDryRun(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Bucket("my-bucket").Build(),
+		resourcetest.User("AIDA1").Prop(properties.Name, "jdoe").Build(),
+		resourcetest.User("AIDA2").Prop(properties.Name, "john").Build(),
+		resourcetest.AccessKey("AKIA1").Prop(properties.Username, "jdoe").Build(),
+		resourcetest.Subnet("sub-1").Build(),
+		resourcetest.SecurityGroup("sg-1").Build(),
+		resourcetest.VPC("vpc-1").Build(),
+	)
+	createdVpc := dryRunId("vpc")
+
+	tcases := []struct {
+		cmd    interface{}
+		params map[string]interface{}
+		expErr string
+	}{
+		{cmd: &CreateBucket{graph: g}, params: map[string]interface{}{"name": "new-bucket"}},
+		{cmd: &CreateBucket{graph: g}, params: map[string]interface{}{"name": "my-bucket"}, expErr: "bucket 'my-bucket' already exists"},
+		{cmd: &DeleteBucket{graph: g}, params: map[string]interface{}{"name": "my-bucket"}},
+		{cmd: &DeleteBucket{graph: g}, params: map[string]interface{}{"name": "unknown"}, expErr: "bucket 'unknown' not found in local graph"},
+		{cmd: &DeleteUser{graph: g}, params: map[string]interface{}{"name": "john"}},
+		{cmd: &DeleteUser{graph: g}, params: map[string]interface{}{"name": "jdoe"}, expErr: "user 'jdoe' still has 1 access key(s)"},
+		{cmd: &CreateUser{graph: g}, params: map[string]interface{}{"name": "john"}, expErr: "user 'john' already exists"},
+		{cmd: &CreateLoadbalancer{graph: g}, params: map[string]interface{}{"name": "lb", "subnets": []interface{}{"sub-1"}, "securitygroups": []interface{}{"sg-1"}}},
+		{cmd: &CreateLoadbalancer{graph: g}, params: map[string]interface{}{"name": "lb", "subnets": []interface{}{"sub-1", "sub-2"}}, expErr: "subnet 'sub-2' not found in local graph"},
+		{cmd: &CreateTargetgroup{graph: g}, params: map[string]interface{}{"vpc": "vpc-1"}},
+		{cmd: &CreateTargetgroup{graph: g}, params: map[string]interface{}{"vpc": createdVpc}},
+		{cmd: &CreateTargetgroup{graph: g}, params: map[string]interface{}{"vpc": "vpc-2"}, expErr: "vpc 'vpc-2' not found in local graph"},
+		// types never synced locally cannot be verified
+		{cmd: &DeleteRole{graph: g}, params: map[string]interface{}{"name": "any"}},
+		{cmd: &DeleteDatabase{graph: g}, params: map[string]interface{}{"id": "any"}},
+		{cmd: &DeleteBucket{}, params: map[string]interface{}{"name": "unknown"}},
+	}
+
+	for i, tcase := range tcases {
+		sim := env.NewSimulation()
+		sim.Create("vpc", createdVpc)
+		err := tcase.cmd.(Simulator).Simulate(sim, tcase.params)
+		if tcase.expErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tcase.expErr) {
+				t.Fatalf("%d: got %v, want error containing %s", i+1, err, tcase.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+	}
+
+	t.Run("statements earlier in template", func(t *testing.T) {
+		sim := env.NewSimulation()
+		if err := (&DeleteAccesskey{graph: g}).Simulate(sim, map[string]interface{}{"id": "AKIA1"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := (&DeleteUser{graph: g}).Simulate(sim, map[string]interface{}{"name": "jdoe"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := (&DeleteUser{graph: g}).Simulate(sim, map[string]interface{}{"name": "jdoe"}); err == nil || !strings.Contains(err.Error(), "deleted earlier in template") {
+			t.Fatalf("got %v, want deleted earlier error", err)
+		}
+		if err := (&CreateUser{graph: g}).Simulate(sim, map[string]interface{}{"name": "jdoe"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := (&CreateUser{graph: g}).Simulate(sim, map[string]interface{}{"name": "jdoe"}); err == nil || !strings.Contains(err.Error(), "created earlier in template") {
+			t.Fatalf("got %v, want created earlier error", err)
+		}
+	})
+
+	t.Run("failed checks are warnings scoped to a dry run", func(t *testing.T) {
+		var buff bytes.Buffer
+		cenv := template.NewEnv().WithLog(logger.New("", 0, &buff)).Build()
+		renv := template.NewRunEnv(cenv)
+		renv.SetDryRun(true)
+		res, err := (&DeleteUser{graph: g}).dryRun(renv, map[string]interface{}{"name": "jdoe"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res == nil {
+			t.Fatal("expected placeholder id")
+		}
+		if !strings.Contains(buff.String(), "still has 1 access key(s)") {
+			t.Fatalf("expected warning, got %q", buff.String())
+		}
+		vpc := fakeDryRunId(renv, "vpc")
+		if !renv.Simulation().IsCreated("vpc", vpc) {
+			t.Fatal("expected created vpc in simulation")
+		}
+		renv.SetDryRun(true)
+		if renv.Simulation().IsCreated("vpc", vpc) {
+			t.Fatal("expected simulation reset on new dry run")
+		}
+	})
+}
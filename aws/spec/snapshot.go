@@ -39,6 +39,10 @@ func (cmd *CreateSnapshot) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateSnapshot) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "snapshot", "id", result), nil
+}
+
 func (cmd *CreateSnapshot) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.Snapshot).SnapshotId)
 }
@@ -72,6 +76,10 @@ func (cmd *CopySnapshot) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CopySnapshot) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "snapshot", "id", result), nil
+}
+
 func (cmd *CopySnapshot) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CopySnapshotOutput).SnapshotId)
 }
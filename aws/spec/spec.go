@@ -34,6 +34,29 @@ type ResultExtractor interface {
 	ExtractResult(interface{}) string
 }
 
+// Reverter is implemented by commands that can be undone. Given the params and the result
+// of a successful run, Revert returns the template statements reverting the command.
+// No statements means this run cannot be reverted.
+// Checks ending the revert of the first command of a template are dropped since nothing depends on them.
+type Reverter interface {
+	Revert(params map[string]interface{}, result interface{}) ([]string, error)
+}
+
+// Snapshotter is implemented by commands updating existing resources. SnapshotState is called
+// before running and returns the current values of the params about to be updated, keyed by
+// param name. RevertSnapshot returns the template statements restoring those values.
+type Snapshotter interface {
+	SnapshotState(renv env.Running, params map[string]interface{}) (map[string]interface{}, error)
+	RevertSnapshot(params, snapshot map[string]interface{}) ([]string, error)
+}
+
+// Simulator is implemented by commands whose API has no dry run. Simulate checks
+// the preconditions of the command against the local graph and the resources created
+// or deleted earlier in the template, and records its own effect in the simulation.
+type Simulator interface {
+	Simulate(sim *env.Simulation, params map[string]interface{}) error
+}
+
 type command interface {
 	ParamsSpec() params.Spec
 	inject(map[string]interface{}) error
@@ -50,12 +73,23 @@ func implementsAfterRun(i interface{}) (AfterRunner, bool) {
 	return v, ok
 }
 
+func implementsSimulator(i interface{}) (Simulator, bool) {
+	v, ok := i.(Simulator)
+	return v, ok
+}
+
 func implementsResultExtractor(i interface{}) (ResultExtractor, bool) {
 	v, ok := i.(ResultExtractor)
 	return v, ok
 }
 
-func fakeDryRunId(entity string) string {
+func fakeDryRunId(renv env.Running, entity string) string {
+	id := dryRunId(entity)
+	simulationOf(renv).Create(entity, id)
+	return id
+}
+
+func dryRunId(entity string) string {
 	suffix := rand.Intn(1e6)
 	switch entity {
 	case cloud.Instance:
@@ -65,6 +65,13 @@ func (cmd *CreateStack) ParamsSpec() params.Spec {
 	)
 }
 
+func (cmd *CreateStack) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	return []string{revertLine("delete", "stack", revertParam("name", params["name"]))}, nil
+}
+
 func (cmd *CreateStack) ExtractResult(i interface{}) string {
 	return StringValue(i.(*cloudformation.CreateStackOutput).StackId)
 }
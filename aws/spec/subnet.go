@@ -16,6 +16,8 @@ limitations under the License.
 package awsspec
 
 import (
+	"fmt"
+
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
@@ -43,6 +45,10 @@ func (cmd *CreateSubnet) ParamsSpec() params.Spec {
 		params.Validators{"cidr": params.IsCIDR})
 }
 
+func (cmd *CreateSubnet) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "subnet", "id", result), nil
+}
+
 func (cmd *CreateSubnet) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CreateSubnetOutput).Subnet.SubnetId)
 }
@@ -78,6 +84,28 @@ func (cmd *UpdateSubnet) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id"), params.Opt("public")))
 }
 
+func (cmd *UpdateSubnet) SnapshotState(renv env.Running, params map[string]interface{}) (map[string]interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, err
+	}
+	snapshot := make(map[string]interface{})
+	if cmd.Public != nil {
+		out, err := cmd.api.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: []*string{cmd.Id}})
+		if err != nil {
+			return nil, err
+		}
+		if len(out.Subnets) != 1 {
+			return nil, fmt.Errorf("subnet %s not found", StringValue(cmd.Id))
+		}
+		snapshot["public"] = BoolValue(out.Subnets[0].MapPublicIpOnLaunch)
+	}
+	return snapshot, nil
+}
+
+func (cmd *UpdateSubnet) RevertSnapshot(params, snapshot map[string]interface{}) ([]string, error) {
+	return revertSnapshot("subnet", params, snapshot, "id"), nil
+}
+
 type DeleteSubnet struct {
 	_      string `action:"delete" entity:"subnet" awsAPI:"ec2" awsCall:"DeleteSubnet" awsInput:"ec2.DeleteSubnetInput" awsOutput:"ec2.DeleteSubnetOutput" awsDryRun:""`
 	logger *logger.Logger
@@ -38,6 +38,10 @@ func (cmd *CreateSubscription) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("endpoint"), params.Key("protocol"), params.Key("topic")))
 }
 
+func (cmd *CreateSubscription) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "subscription", "id", result), nil
+}
+
 func (cmd *CreateSubscription) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*sns.SubscribeOutput).SubscriptionArn)
 }
@@ -46,6 +46,10 @@ func (cmd *CreateTag) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("key"), params.Key("resource"), params.Key("value")))
 }
 
+func (cmd *CreateTag) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("delete", "tag", revertParamsExcept(params)...)}, nil
+}
+
 func (cmd *CreateTag) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("dry run: cannot set params on command struct: %s", err)
@@ -65,7 +69,7 @@ func (cmd *CreateTag) dryRun(renv env.Running, params map[string]interface{}) (i
 		case code == dryRunOperation, strings.HasSuffix(code, notFound):
 			cmd.logger.ExtraVerbosef("dry run: ec2.CreateTags call took %s", time.Since(start))
 			cmd.logger.Verbose("dry run: create tag ok")
-			return fakeDryRunId("tag"), nil
+			return fakeDryRunId(renv, "tag"), nil
 		}
 	}
 
@@ -124,7 +128,7 @@ func (cmd *DeleteTag) dryRun(renv env.Running, params map[string]interface{}) (i
 		case code == dryRunOperation, strings.HasSuffix(code, notFound):
 			cmd.logger.ExtraVerbosef("dry run: ec2.DeleteTags call took %s", time.Since(start))
 			cmd.logger.Verbose("dry run: create tag ok")
-			return fakeDryRunId("tag"), nil
+			return fakeDryRunId(renv, "tag"), nil
 		}
 	}
 
@@ -145,6 +149,9 @@ func (cmd *DeleteTag) ManualRun(renv env.Running) (interface{}, error) {
 }
 
 func createNameTag(resource, name *string, renv env.Running) error {
+	if name == nil {
+		return nil
+	}
 	createTag := CommandFactory.Build("createtag")().(*CreateTag)
 	entries := map[string]interface{}{
 		"key":      "Name",
@@ -52,6 +52,10 @@ func (cmd *CreateTargetgroup) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateTargetgroup) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "targetgroup", "id", result), nil
+}
+
 func (cmd *CreateTargetgroup) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*elbv2.CreateTargetGroupOutput).TargetGroups[0].TargetGroupArn)
 }
@@ -36,6 +36,10 @@ func (cmd *CreateTopic) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("name")))
 }
 
+func (cmd *CreateTopic) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "topic", "id", result), nil
+}
+
 func (cmd *CreateTopic) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*sns.CreateTopicOutput).TopicArn)
 }
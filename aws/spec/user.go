@@ -36,6 +36,13 @@ func (cmd *CreateUser) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("name")))
 }
 
+func (cmd *CreateUser) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	if !hasResult(result) {
+		return nil, nil
+	}
+	return []string{revertLine("delete", "user", revertParam("name", params["name"]))}, nil
+}
+
 func (cmd *CreateUser) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*iam.CreateUserOutput).User.UserId)
 }
@@ -65,6 +72,10 @@ func (cmd *AttachUser) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("group"), params.Key("name")))
 }
 
+func (cmd *AttachUser) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("detach", "user", revertParamsExcept(params)...)}, nil
+}
+
 type DetachUser struct {
 	_      string `action:"detach" entity:"user" awsAPI:"iam" awsCall:"RemoveUserFromGroup" awsInput:"iam.RemoveUserFromGroupInput" awsOutput:"iam.RemoveUserFromGroupOutput"`
 	logger *logger.Logger
@@ -77,3 +88,7 @@ type DetachUser struct {
 func (cmd *DetachUser) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("group"), params.Key("name")))
 }
+
+func (cmd *DetachUser) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("attach", "user", revertParamsExcept(params)...)}, nil
+}
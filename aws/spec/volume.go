@@ -42,6 +42,10 @@ func (cmd *CreateVolume) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("availabilityzone"), params.Key("size")))
 }
 
+func (cmd *CreateVolume) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "volume", "id", result), nil
+}
+
 func (cmd *CreateVolume) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.Volume).VolumeId)
 }
@@ -122,6 +126,13 @@ type AttachVolume struct {
 func (cmd *AttachVolume) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("device"), params.Key("id"), params.Key("instance")))
 }
+
+func (cmd *AttachVolume) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{
+		revertLine("detach", "volume", revertParamsExcept(params)...),
+		fmt.Sprintf("check volume id=%s state=available timeout=180", printItem(params["id"])),
+	}, nil
+}
 func (cmd *AttachVolume) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.VolumeAttachment).VolumeId)
 }
@@ -143,6 +154,10 @@ func (cmd *DetachVolume) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *DetachVolume) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return []string{revertLine("attach", "volume", revertParamsExcept(params, "force")...)}, nil
+}
+
 func (cmd *DetachVolume) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.VolumeAttachment).VolumeId)
 }
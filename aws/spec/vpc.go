@@ -41,6 +41,10 @@ func (cmd *CreateVpc) ParamsSpec() params.Spec {
 		params.Validators{"cidr": params.IsCIDR})
 }
 
+func (cmd *CreateVpc) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "vpc", "id", result), nil
+}
+
 func (cmd *CreateVpc) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CreateVpcOutput).Vpc.VpcId)
 }
@@ -43,6 +43,10 @@ func (cmd *CreateZone) ParamsSpec() params.Spec {
 	))
 }
 
+func (cmd *CreateZone) Revert(params map[string]interface{}, result interface{}) ([]string, error) {
+	return revertWithResult("delete", "zone", "id", result), nil
+}
+
 func (cmd *CreateZone) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*route53.CreateHostedZoneOutput).HostedZone.Id)
 }
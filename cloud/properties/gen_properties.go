@@ -45,6 +45,7 @@ const (
 	AutoUpgrade                       = "AutoUpgrade"
 	AvailabilityZone                  = "AvailabilityZone"
 	AvailabilityZones                 = "AvailabilityZones"
+	AWSProfile                        = "AWSProfile"
 	BackupRetentionPeriod             = "BackupRetentionPeriod"
 	Bucket                            = "Bucket"
 	CallerReference                   = "CallerReference"
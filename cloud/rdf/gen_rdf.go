@@ -47,6 +47,7 @@ const (
 	AutoUpgrade                       = "cloud:autoUpgrade"
 	AvailabilityZone                  = "cloud:availabilityZone"
 	AvailabilityZones                 = "cloud:availabilityZones"
+	AWSProfile                        = "cloud:awsProfile"
 	BackupRetentionPeriod             = "cloud:backupRetentionPeriod"
 	Bucket                            = "cloud:bucketName"
 	CallerReference                   = "cloud:callerReference"
@@ -258,6 +259,7 @@ var Labels = map[string]string{
 	properties.AutoUpgrade:                       AutoUpgrade,
 	properties.AvailabilityZone:                  AvailabilityZone,
 	properties.AvailabilityZones:                 AvailabilityZones,
+	properties.AWSProfile:                        AWSProfile,
 	properties.BackupRetentionPeriod:             BackupRetentionPeriod,
 	properties.Bucket:                            Bucket,
 	properties.CallerReference:                   CallerReference,
@@ -469,6 +471,7 @@ var Properties = RDFProperties{
 	AutoUpgrade:             {ID: AutoUpgrade, RdfType: "rdf:Property", RdfsLabel: "AutoUpgrade", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	AvailabilityZone:        {ID: AvailabilityZone, RdfType: "rdf:Property", RdfsLabel: "AvailabilityZone", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	AvailabilityZones:       {ID: AvailabilityZones, RdfType: "rdf:Property", RdfsLabel: "AvailabilityZones", RdfsDefinedBy: "rdfs:list", RdfsDataType: "rdfs:Class"},
+	AWSProfile:              {ID: AWSProfile, RdfType: "rdf:Property", RdfsLabel: "AWSProfile", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	BackupRetentionPeriod:   {ID: BackupRetentionPeriod, RdfType: "rdf:Property", RdfsLabel: "BackupRetentionPeriod", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:dateTime"},
 	Bucket:                  {ID: Bucket, RdfType: "rdf:Property", RdfsLabel: "Bucket", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	CallerReference:         {ID: CallerReference, RdfType: "rdf:Property", RdfsLabel: "CallerReference", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -17,109 +17,89 @@ limitations under the License.
 package commands
 
 import (
+	"errors"
 	"fmt"
-	"os"
-
-	"github.com/wallix/awless/aws/services"
+	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/config"
-	"github.com/wallix/awless/console"
-	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
 )
 
-var (
-	showProperties bool
-)
-
 func init() {
 	RootCmd.AddCommand(historyCmd)
-
-	historyCmd.Flags().BoolVar(&showProperties, "properties", false, "Full diff with resources properties")
 }
 
 var historyCmd = &cobra.Command{
-	Use:               "history",
-	Hidden:            true,
-	Short:             "(in progress) Show a infra resource history & changes using your locally sync snapshots",
+	Use:   "history REFERENCE",
+	Short: "Show the timeline of a resource (any service) through your locally synced revisions: creation, property & relation changes, deletion",
+	Example: `  awless history i-8d43b21b   # history of an instance via its ref
+  awless history @my-bucket   # forcing search by name`,
 	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
 
 	RunE: func(cmd *cobra.Command, args []string) error {
-		region := config.GetAWSRegion()
-
-		root := graph.InitResource(cloud.Region, region)
-
-		var diffs []*sync.Diff
+		if len(args) < 1 {
+			return errors.New("REFERENCE required. See examples.")
+		}
+		ref := args[0]
+
+		id := deprefix(ref)
+		_, resources, _ := resolveResourceFromRefInAllLocalRegion(ref)
+		switch len(resources) {
+		case 0:
+			logger.Verbosef("'%s' not found in data synced locally (i.e. deleted resource), using it as an id", id)
+		case 1:
+			id = resources[0].Id()
+		default:
+			logger.Infof("%d resources found with reference '%s'. Show the history of a specific resource with:", len(resources), id)
+			for _, res := range resources {
+				logger.Infof("\t`awless history %s` for the %s", res.Id(), res.Type())
+			}
+			return nil
+		}
 
-		all, err := sync.DefaultSyncer.List()
+		changes, err := sync.ResourceHistory(sync.DefaultSyncer, config.GetAWSProfile(), id)
 		exitOn(err)
-
-		for i := 1; i < len(all); i++ {
-			from, err := sync.DefaultSyncer.LoadRev(all[i-1].Id)
-			exitOn(err)
-
-			to, err := sync.DefaultSyncer.LoadRev(all[i].Id)
-			exitOn(err)
-
-			d, err := sync.BuildDiff(from, to, root.Id())
-			exitOn(err)
-
-			diffs = append(diffs, d)
+		if len(changes) == 0 {
+			return fmt.Errorf("no history found for '%s' in revisions synced for profile '%s'", id, config.GetAWSProfile())
 		}
-
-		for _, diff := range diffs {
-			displayRevisionDiff(diff, awsservices.InfraService.Name(), root, verboseGlobalFlag)
+		for _, change := range changes {
+			displayResourceChange(change)
 		}
-
 		return nil
 	},
 }
 
-func displayRevisionDiff(diff *sync.Diff, cloudService string, root *graph.Resource, verbose bool) {
-	fromRevision := "repository creation"
-	if diff.From.Id != "" {
-		fromRevision = diff.From.Id[:7] + " on " + diff.From.Date.Format("Monday January 2, 15:04")
+func displayResourceChange(change *sync.ResourceChange) {
+	header := fmt.Sprintf("▶ %s (%s)", change.Rev.DateString(), change.Rev.Id[:7])
+	switch change.Kind {
+	case sync.ResourceAppeared:
+		fmt.Println(header, renderGreenFn(fmt.Sprintf("%s appeared in %s", change.Resource, change.Region)))
+	case sync.ResourceDisappeared:
+		fmt.Println(header, renderRedFn(fmt.Sprintf("%s disappeared from %s", change.Resource, change.Region)))
+	default:
+		fmt.Println(header, renderYellowFn(fmt.Sprintf("%s changed", change.Resource)))
 	}
-
-	var graphdiff *graph.Diff
-	if cloudService == awsservices.InfraService.Name() {
-		graphdiff = diff.InfraDiff
+	for _, prop := range change.Properties {
+		fmt.Printf("\t%s: %s → %s\n", prop.Name, historyValue(prop.Old), historyValue(prop.New))
 	}
-
-	if showProperties {
-		if graphdiff.HasDiff() {
-			fmt.Println("▶", cloudService, "properties, from", fromRevision,
-				"to", diff.To.Id[:7], "on", diff.To.Date.Format("Monday January 2, 15:04"))
-			displayer, err := console.BuildOptions(
-				console.WithFormat("table"),
-				console.WithRootNode(root),
-			).SetSource(graphdiff).Build()
-			exitOn(err)
-			exitOn(displayer.Print(os.Stdout))
-			fmt.Println()
-		} else if verbose {
-			fmt.Println("▶", cloudService, "properties, from", fromRevision,
-				"to", diff.To.Id[:7], "on", diff.To.Date.Format("Monday January 2, 15:04"))
-			fmt.Println("No changes.")
-		}
-	} else {
-		if graphdiff.HasDiff() {
-			fmt.Println("▶", cloudService, "resources, from", fromRevision,
-				"to", diff.To.Id[:7], "on", diff.To.Date.Format("Monday January 2, 15:04"))
-			displayer, err := console.BuildOptions(
-				console.WithFormat("tree"),
-				console.WithRootNode(root),
-			).SetSource(graphdiff).Build()
-			exitOn(err)
-			exitOn(displayer.Print(os.Stdout))
-			fmt.Println()
-		} else if verbose {
-			fmt.Println("▶", cloudService, "resources, from", fromRevision,
-				"to", diff.To.Id[:7], "on", diff.To.Date.Format("Monday January 2, 15:04"))
-			fmt.Println("No resource changes.")
+	for _, rel := range change.Relations {
+		if rel.Added {
+			fmt.Printf("\t%s %s %s\n", renderGreenFn("+"), rel.Relation, rel.Resource)
+		} else {
+			fmt.Printf("\t%s %s %s\n", renderRedFn("-"), rel.Relation, rel.Resource)
 		}
 	}
 }
+
+func historyValue(i interface{}) string {
+	if i == nil {
+		return "<none>"
+	}
+	if s, ok := i.([]string); ok {
+		return "[" + strings.Join(s, ", ") + "]"
+	}
+	return fmt.Sprint(i)
+}
@@ -46,6 +46,7 @@ func initAwlessEnvHook(cmd *cobra.Command, args []string) error {
 	if err := config.InitAwlessEnv(); err != nil {
 		return fmt.Errorf("cannot init awless environment: %s", err)
 	}
+	sync.BinarySnapshots = config.GetSyncSnapshots()
 
 	return applyRegionAndProfilePrecedence()
 }
@@ -147,12 +148,27 @@ func includeHookIf(cond *bool, hook func(*cobra.Command, []string) error) func(*
 func initSyncerHook(cmd *cobra.Command, args []string) error {
 	if noSyncGlobalFlag {
 		sync.DefaultSyncer = sync.NoOpSyncer()
+	} else if events := syncEventsTarget(cmd); events != "" {
+		sync.DefaultSyncer = sync.NewSyncerWithEvents(sync.NewEventPublisher(events), logger.DefaultLogger)
 	} else {
 		sync.DefaultSyncer = sync.NewSyncer(logger.DefaultLogger)
 	}
 	return nil
 }
 
+// syncEventsTarget returns where the change events of the syncs of cmd are published. Only `awless sync`
+// publishes to stdout, the autosyncs of other commands publishing to stderr to keep stdout to their output
+func syncEventsTarget(cmd *cobra.Command) string {
+	target := config.GetSyncEvents()
+	if syncEventsFlag != "" {
+		target = syncEventsFlag
+	}
+	if target == "stdout" && cmd.CommandPath() != "awless sync" {
+		return "stderr"
+	}
+	return target
+}
+
 func initLoggerHook(cmd *cobra.Command, args []string) error {
 	var flag int
 	if verboseGlobalFlag {
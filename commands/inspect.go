@@ -67,6 +67,9 @@ var inspectCmd = &cobra.Command{
 
 		g, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
 		exitOn(err)
+		for _, failed := range failedSyncTypes() {
+			logger.Warningf("%s: unknown: not synced", failed)
+		}
 
 		exitOn(inspector.Inspect(g))
 
@@ -0,0 +1,124 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/env"
+)
+
+var (
+	fmtWriteFlag bool
+	fmtListFlag  bool
+)
+
+func init() {
+	RootCmd.AddCommand(fmtCmd)
+	RootCmd.AddCommand(lintCmd)
+	fmtCmd.Flags().BoolVarP(&fmtWriteFlag, "write", "w", false, "Write the result to the template file instead of stdout")
+	fmtCmd.Flags().BoolVarP(&fmtListFlag, "list", "l", false, "List the templates whose formatting differs")
+	// fmt works offline on files: shadowing the global --local frees its -l shorthand for --list
+	fmtCmd.Flags().BoolVar(&localGlobalFlag, "local", false, "Work offline only using locally synced resources")
+	fmtCmd.Flags().MarkHidden("local")
+}
+
+var fmtCmd = &cobra.Command{
+	Use:              "fmt PATH...",
+	Short:            "Print templates in their canonical format, keeping comments",
+	Example:          "  awless fmt mytemplate.aws\n  awless fmt -w mytemplates/*.aws",
+	PersistentPreRun: applyHooks(initLoggerHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("missing PATH arg")
+		}
+
+		for _, path := range args {
+			content, err := ioutil.ReadFile(path)
+			exitOn(err)
+
+			tpl, err := template.Parse(string(content))
+			if err != nil {
+				exitOn(fmt.Errorf("%s: %s", path, err))
+			}
+			formatted := tpl.Format()
+
+			if fmtListFlag {
+				if formatted != string(content) {
+					fmt.Println(path)
+				}
+				continue
+			}
+			if fmtWriteFlag {
+				if formatted != string(content) {
+					exitOn(ioutil.WriteFile(path, []byte(formatted), 0644))
+				}
+				continue
+			}
+			fmt.Print(formatted)
+		}
+		return nil
+	},
+}
+
+var lintCmd = &cobra.Command{
+	Use:              "lint PATH...",
+	Short:            "Report the problems of templates without running them",
+	Long:             "Report the problems of templates without running them: unknown params, invalid param values, unused declarations, references used before their declaration, holes without default value and non revertible commands in templates with a '# Revertible: true' header",
+	Example:          "  awless lint mytemplate.aws\n  awless lint mytemplates/*.aws",
+	PersistentPreRun: applyHooks(initLoggerHook, initAwlessEnvHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("missing PATH arg")
+		}
+
+		var hasErrors bool
+		for _, path := range args {
+			content, fullPath, err := getTemplateText(path)
+			exitOn(err)
+
+			tpl, err := template.Parse(string(content))
+			if err != nil {
+				fmt.Printf("%s: %s\n", path, err)
+				hasErrors = true
+				continue
+			}
+
+			cenv := template.NewEnv().WithLookupCommandFunc(lookupCommandFunc).WithIncludeFunc(includeTemplateFunc(fullPath)).WithLog(logger.DefaultLogger).Build()
+			cenv.Push(env.FILLERS, config.Defaults)
+
+			for _, issue := range template.Lint(tpl, cenv) {
+				fmt.Printf("%s:%s\n", path, issue)
+				hasErrors = hasErrors || !issue.Warning
+			}
+		}
+
+		if hasErrors {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
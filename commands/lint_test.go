@@ -0,0 +1,16 @@
+package commands
+
+import "testing"
+
+func TestFmtFlagShorthands(t *testing.T) {
+	defer func() { fmtWriteFlag, fmtListFlag = false, false }()
+	if err := fmtCmd.ParseFlags([]string{"-l", "-w"}); err != nil {
+		t.Fatal(err)
+	}
+	if !fmtListFlag || !fmtWriteFlag {
+		t.Fatalf("got list %t, write %t, want both set", fmtListFlag, fmtWriteFlag)
+	}
+	if localGlobalFlag {
+		t.Fatal("expected -l not to set --local")
+	}
+}
@@ -43,6 +43,8 @@ var (
 	noHeadersFlag              bool
 	sortBy                     []string
 	reverseFlag                bool
+	listAllRegionsFlag         bool
+	listAllProfilesFlag        bool
 )
 
 func init() {
@@ -74,13 +76,15 @@ func init() {
 	listCmd.PersistentFlags().BoolVar(&listOnlyIDs, "ids", false, "List only ids")
 	listCmd.PersistentFlags().BoolVar(&noHeadersFlag, "no-headers", false, "Do not display headers")
 	listCmd.PersistentFlags().BoolVar(&reverseFlag, "reverse", false, "Use in conjunction with --sort to reverse sort")
+	listCmd.PersistentFlags().BoolVar(&listAllRegionsFlag, "all-regions", false, "List resources of all the regions synced locally (see `awless sync --regions`), with their region")
+	listCmd.PersistentFlags().BoolVar(&listAllProfilesFlag, "all-profiles", false, "List resources of all the AWS profiles synced locally (see `awless sync --profiles`), with their account and profile")
 	listCmd.PersistentFlags().StringSliceVar(&sortBy, "sort", []string{"Id"}, "Sort tables by column(s) name(s)")
 }
 
 var listCmd = &cobra.Command{
 	Use:               "list",
 	Aliases:           []string{"ls"},
-	Example:           "  awless list instances --sort uptime\n  awless list users --format csv\n  awless list volumes --filter state=use --filter type=gp2\n  awless list volumes --tag-value Purchased\n  awless list vpcs --tag-key Dept --tag-key Internal\n  awless list instances --tag Env=Production,Dept=Marketing\n  awless list instances --filter state=running,type=micro\n  awless list s3objects --filter bucket=pdf-bucket ",
+	Example:           "  awless list instances --sort uptime\n  awless list users --format csv\n  awless list volumes --filter state=use --filter type=gp2\n  awless list volumes --tag-value Purchased\n  awless list vpcs --tag-key Dept --tag-key Internal\n  awless list instances --tag Env=Production,Dept=Marketing\n  awless list instances --filter state=running,type=micro\n  awless list s3objects --filter bucket=pdf-bucket\n  awless list instances --all-regions\n  awless list buckets --all-profiles",
 	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, firstInstallDoneHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
 	Short:             "List resources: sorting, filtering via tag/properties, output formatting, etc...",
@@ -109,9 +113,30 @@ var listSpecificResourceCmd = func(resType string) *cobra.Command {
 			}
 			var g cloud.GraphAPI
 
-			if localGlobalFlag {
+			if listAllRegionsFlag || listAllProfilesFlag {
+				srvName, ok := awsservices.ServicePerResourceType[resType]
+				if !ok {
+					exitOn(fmt.Errorf("cannot find service for resource type %s", resType))
+				}
+				profiles := []string{config.GetAWSProfile()}
+				if listAllProfilesFlag {
+					profiles = allProfiles(config.GetAWSProfile())
+				}
+				var regions []string
+				if !listAllRegionsFlag {
+					regions = append(regions, config.GetAWSRegion())
+				}
+				var err error
+				g, err = sync.LoadLocalGraphForType(srvName, resType, profiles, regions...)
+				exitOn(err)
+			} else if localGlobalFlag {
 				if srvName, ok := awsservices.ServicePerResourceType[resType]; ok {
-					g = sync.LoadLocalGraphForService(srvName, config.GetAWSProfile(), config.GetAWSRegion())
+					g = sync.LoadLocalGraphForService(srvName, config.GetAWSProfile(), config.GetAWSRegion(), resType)
+					if err := notSyncedError(resType); err != nil {
+						logger.Warning(err)
+						return
+					}
+					warnStaleLocalData(resType)
 				} else {
 					exitOn(fmt.Errorf("cannot find service for resource type %s", resType))
 				}
@@ -158,6 +183,8 @@ func printResources(g cloud.GraphAPI, resType string) {
 		console.WithMaxWidth(console.GetTerminalWidth()),
 		console.WithFormat(listingFormat),
 		console.WithIDsOnly(listOnlyIDs),
+		console.WithRegionColumn(listAllRegionsFlag && !listOnlyIDs),
+		console.WithProfileColumns(listAllProfilesFlag && !listOnlyIDs),
 		console.WithSortBy(sortBy...),
 		console.WithReverseSort(reverseFlag),
 		console.WithNoHeaders(noHeadersFlag),
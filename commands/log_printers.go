@@ -112,14 +112,14 @@ func writeLogHeader(t *template.TemplateExecution, w io.Writer) {
 	if t.Locale != "" {
 		fmt.Fprintf(w, " in %s", renderBlueFn(t.Locale))
 	}
-	if !template.IsRevertible(t.Template) {
+	if !template.IsRevertible(t.Template, lookupCommandFunc) {
 		fmt.Fprintf(w, " (not revertible)")
 	}
 }
 
 func writeMultilineLogHeader(t *template.TemplateExecution, w io.Writer) {
 	color.New(color.FgYellow).Fprintf(w, "id %s", t.ID)
-	if !template.IsRevertible(t.Template) {
+	if !template.IsRevertible(t.Template, lookupCommandFunc) {
 		fmt.Fprintln(w, " (not revertible)")
 	} else {
 		fmt.Fprintln(w)
@@ -0,0 +1,59 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/lsp"
+	"github.com/wallix/awless/sync"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/env"
+)
+
+func init() {
+	RootCmd.AddCommand(lspCmd)
+}
+
+var lspCmd = &cobra.Command{
+	Use:              "lsp",
+	Short:            "Run a language server for awless templates over stdio, for editors supporting the Language Server Protocol",
+	Long:             "Run a language server for awless templates over stdio: completion of actions, entities, params, resource ids and aliases (from the local graph), hover docs and diagnostics of `awless lint`. Logs go to stderr",
+	Example:          "  awless lsp",
+	PersistentPreRun: applyHooks(initLoggerHook, initAwlessEnvHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		server := &lsp.Server{
+			Version: config.Version,
+			Log:     logger.DefaultLogger,
+			LoadGraph: func() (cloud.GraphAPI, error) {
+				return sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+			},
+			NewEnv: func(path string) env.Compiling {
+				cenv := template.NewEnv().WithLookupCommandFunc(lookupCommandFunc).WithIncludeFunc(includeTemplateFunc(path)).WithLog(logger.DiscardLogger).Build()
+				cenv.Push(env.FILLERS, config.Defaults)
+				return cenv
+			},
+		}
+		exitOn(server.Serve(os.Stdin, os.Stdout))
+		return nil
+	},
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+	"github.com/wallix/awless/template"
+)
+
+var planOutputFlag string
+
+func init() {
+	RootCmd.AddCommand(planCmd)
+	RootCmd.AddCommand(applyCmd)
+	planCmd.Flags().StringVarP(&planOutputFlag, "output", "o", "", "Write the plan in this file instead of stdout")
+}
+
+var planCmd = &cobra.Command{
+	Use:               "plan PATH",
+	Short:             "Compile and simulate a template into a plan file to review, then run it with `awless apply`",
+	Example:           "  awless plan ~/templates/my-infra.aws -o plan.json\n  awless plan repo:create_vpc cidr=10.0.0.0/16 -o plan.json",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("missing PATH arg (filepath or url)")
+		}
+
+		content, fullPath, err := getTemplateText(args[0])
+		exitOn(err)
+
+		templ, err := template.Parse(string(content))
+		exitOn(err)
+
+		extraParams, err := template.ParseParams(strings.Join(args[1:], " "))
+		exitOn(err)
+
+		sources := map[string]string{fullPath: template.Hash(content)}
+		runner := NewRunnerRequiredParamsOnly(templ, "", fullPath, config.Defaults, extraParams)
+		includeFunc := runner.IncludeFunc
+		runner.IncludeFunc = func(path, from string) (string, string, error) {
+			text, expanded, err := includeFunc(path, from)
+			if err == nil {
+				sources[expanded] = template.Hash([]byte(text))
+			}
+			return text, expanded, err
+		}
+
+		plan, err := runner.Plan()
+		exitOn(err)
+
+		compiled, err := plan.ParseTemplate()
+		exitOn(err)
+		plan.Sources = sources
+		plan.GraphHash, err = planGraphHash(compiled)
+		exitOn(err)
+
+		var w io.Writer = os.Stdout
+		if planOutputFlag != "" {
+			f, err := os.OpenFile(planOutputFlag, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+			exitOn(err)
+			defer f.Close()
+			w = f
+		}
+		exitOn(plan.Write(w))
+
+		printPlanSummary(plan)
+		if planOutputFlag != "" {
+			logger.Infof("Plan written to %s. Run it with `awless apply %s`", planOutputFlag, planOutputFlag)
+		}
+		return nil
+	},
+}
+
+var applyCmd = &cobra.Command{
+	Use:               "apply PLANFILE",
+	Short:             "Run a plan made with `awless plan`, provided the template and the cloud resources have not changed since",
+	Example:           "  awless apply plan.json",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("missing PLANFILE arg")
+		}
+
+		f, err := os.Open(args[0])
+		exitOn(err)
+		plan, err := template.ReadPlan(f)
+		f.Close()
+		exitOn(err)
+
+		templ, err := plan.ParseTemplate()
+		exitOn(err)
+
+		exitOn(verifyPlan(plan, templ))
+
+		msg := fmt.Sprintf("Apply plan %s", args[0])
+		if plan.TemplatePath != "" {
+			msg = fmt.Sprintf("Apply plan of %s", plan.TemplatePath)
+		}
+		runner := NewRunnerRequiredParamsOnly(templ, msg, plan.TemplatePath)
+		runner.BeforeRun = func(tplExec *template.TemplateExecution) (bool, error) {
+			resolveTemplateAuthor(tplExec)
+			return true, nil
+		}
+		exitOn(runner.Run())
+
+		return nil
+	},
+}
+
+// verifyPlan refuses plans made for another account or region,
+// or whose templates or cloud resources have changed since
+func verifyPlan(plan *template.Plan, compiled *template.Template) error {
+	if plan.Profile != config.GetAWSProfile() || plan.Locale != config.GetAWSRegion() {
+		return fmt.Errorf("plan was made with profile %s in region %s: apply with `-p %s -r %s`", plan.Profile, plan.Locale, plan.Profile, plan.Locale)
+	}
+
+	var paths []string
+	for path := range plan.Sources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		content, _, err := getTemplateText(path)
+		if err != nil {
+			return fmt.Errorf("cannot verify template %s: %s", path, err)
+		}
+		if template.Hash(content) != plan.Sources[path] {
+			return fmt.Errorf("template %s has changed since the plan was made: run `awless plan` again", path)
+		}
+	}
+
+	graphHash, err := planGraphHash(compiled)
+	if err != nil {
+		return err
+	}
+	if graphHash != plan.GraphHash {
+		return errors.New("cloud resources have changed since the plan was made: run `awless plan` again")
+	}
+	return nil
+}
+
+// planGraphHash syncs, then fingerprints the resources referenced by the template in the local graphs of
+// the services it targets, so that the plan is not invalidated by changes of resources it does not act on
+func planGraphHash(tpl *template.Template) (string, error) {
+	services := awsservices.GetCloudServicesForAPIs(tpl.UniqueDefinitions(awsspec.APIPerTemplateDefName)...)
+	if _, err := sync.DefaultSyncer.Sync(services...); err != nil {
+		logger.Warningf("syncing before comparing resources with plan: %s", err)
+	}
+
+	refs := tpl.References()
+	var fingerprints []string
+	for _, srv := range services {
+		g := sync.LoadLocalGraphForService(srv.Name(), config.GetAWSProfile(), config.GetAWSRegion())
+		for _, ref := range refs {
+			resources, err := g.FindWithProperties(map[string]interface{}{properties.ID: ref})
+			if err != nil {
+				return "", fmt.Errorf("cannot load local graph for %s: %s", srv.Name(), err)
+			}
+			for _, res := range resources {
+				props, err := json.Marshal(res.Properties())
+				if err != nil {
+					return "", fmt.Errorf("fingerprinting %s %s: %s", res.Type(), res.Id(), err)
+				}
+				fingerprints = append(fingerprints, fmt.Sprintf("%s %s %s", res.Type(), res.Id(), props))
+			}
+		}
+	}
+	sort.Strings(fingerprints)
+	return template.Hash([]byte(strings.Join(fingerprints, "\n"))), nil
+}
+
+func printPlanSummary(plan *template.Plan) {
+	fmt.Fprintln(os.Stderr)
+	for _, cmd := range plan.Commands {
+		if cmd.Result != "" {
+			fmt.Fprintf(os.Stderr, "%s %s\n", renderGreenFn(cmd.Line), renderYellowFn("("+cmd.Result+")"))
+		} else {
+			fmt.Fprintln(os.Stderr, renderGreenFn(cmd.Line))
+		}
+	}
+	fmt.Fprintln(os.Stderr)
+	for _, res := range plan.Additions {
+		fmt.Fprintf(os.Stderr, "  + %s %s %s\n", res.Type, res.ID, res.Name)
+	}
+	for _, res := range plan.Removals {
+		fmt.Fprintf(os.Stderr, "  - %s %s\n", res.Type, res.ID)
+	}
+	logger.Infof("Plan: %d command(s), %d resource(s) to add, %d to remove", len(plan.Commands), len(plan.Additions), len(plan.Removals))
+}
@@ -19,12 +19,12 @@ package commands
 import (
 	"bufio"
 	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -48,6 +48,8 @@ import (
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
 	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/catalog"
+	"github.com/wallix/awless/template/integrity"
 	"github.com/wallix/awless/template/params"
 )
 
@@ -55,6 +57,8 @@ var (
 	scheduleRunInFlag       string
 	scheduleRevertInFlag    string
 	runLogMessage           string
+	runSHA256Flag           string
+	runOutputFlag           string
 	listRemoteTemplatesFlag bool
 	noSuggestedParamsFlag   bool
 	allSuggestedParamsFlag  bool
@@ -62,10 +66,13 @@ var (
 
 func init() {
 	RootCmd.AddCommand(runCmd)
-	runCmd.Flags().BoolVar(&listRemoteTemplatesFlag, "list", false, "List templates available at https://github.com/wallix/awless-templates")
+	runCmd.Flags().BoolVar(&listRemoteTemplatesFlag, "list", false, "List templates of https://github.com/wallix/awless-templates and of the catalogs set with `awless config set template.repo.<name> <dir|git url|http base>`")
 	runCmd.Flags().StringVar(&scheduleRunInFlag, "run-in", "", "Postpone the execution of this template")
 	runCmd.Flags().StringVar(&scheduleRevertInFlag, "revert-in", "", "Schedule the revertion of this template")
 	runCmd.Flags().StringVarP(&runLogMessage, "message", "m", "", "Add a message for this template execution to be persisted in your logs")
+	runCmd.Flags().StringVar(&runSHA256Flag, "sha256", "", "Refuse to run the template if its content does not have this SHA-256 hex digest, and refuse its remote includes")
+	runCmd.Flags().StringVar(&runOutputFlag, "output", "", "Write a report of the run to stdout in this format: json (logs and prompts then go to stderr)")
+	runCmd.SetHelpFunc(templateHelpFunc)
 
 	var actions []string
 	for a := range awsspec.DriverSupportedActions {
@@ -83,38 +90,49 @@ func init() {
 	}
 }
 
-const maxMsgLen = 140
+const (
+	maxMsgLen     = 140
+	jsonRunOutput = "json"
+)
 
 var runCmd = &cobra.Command{
 	Use:               "run PATH",
 	Short:             "Run a template given a filepath or URL",
-	Example:           "  awless run ~/templates/my-infra.txt\n  awless run https://raw.githubusercontent.com/wallix/awless-templates/master/create_vpc.awls\n  awless run repo:create_vpc",
+	Example:           "  awless run ~/templates/my-infra.txt\n  awless run https://raw.githubusercontent.com/wallix/awless-templates/master/create_vpc.awls\n  awless run repo:create_vpc\n  awless run myrepo:create_vpc@v1.2.0",
 	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
 
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if listRemoteTemplatesFlag {
-			exitOn(listRemoteTemplates())
+			exitOn(listCatalogsTemplates())
 			return nil
 		}
 		if len(args) < 1 {
 			return errors.New("missing PATH arg (filepath or url)")
 		}
 
+		if runOutputFlag != "" && runOutputFlag != jsonRunOutput {
+			exitOn(fmt.Errorf("invalid output format '%s': expecting %s", runOutputFlag, jsonRunOutput))
+		}
+
 		if len(runLogMessage) > maxMsgLen {
 			exitOn(fmt.Errorf("message to be persisted should not exceed %d characters", maxMsgLen))
 		}
 
 		content, fullPath, err := getTemplateText(args[0])
-		exitOn(err)
+		exitOnRunError(err)
+
+		if runSHA256Flag != "" {
+			exitOnRunError(integrity.VerifySHA256(content, runSHA256Flag))
+		}
 
 		logger.Verbosef("Loaded template text:\n\n%s\n", removeComments(content))
 
 		templ, err := template.Parse(string(content))
-		exitOn(err)
+		exitOnRunError(err)
 
 		extraParams, err := template.ParseParams(strings.Join(args[1:], " "))
-		exitOn(err)
+		exitOnRunError(err)
 
 		tplExec := &template.TemplateExecution{
 			Template: templ,
@@ -125,17 +143,65 @@ var runCmd = &cobra.Command{
 			Source:   templ.String(),
 		}
 
-		exitOn(NewRunnerRequiredParamsOnly(tplExec.Template, tplExec.Message, tplExec.Path, config.Defaults, extraParams).Run())
+		exitOnRunError(NewRunnerRequiredParamsOnly(tplExec.Template, tplExec.Message, tplExec.Path, config.Defaults, extraParams).Run())
 
 		return nil
 	},
 }
 
-func missingHolesStdinFunc() func(string, []string, bool) string {
+// runInteractionsOutput is where the template to confirm and the prompts are printed,
+// keeping stdout for the report of the run when requested
+func runInteractionsOutput() io.Writer {
+	if runOutputFlag == jsonRunOutput {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+func writeRunReport(report *template.RunReport) {
+	if runOutputFlag != jsonRunOutput {
+		return
+	}
+	if err := report.Write(os.Stdout); err != nil {
+		logger.Errorf("cannot write run report: %s", err)
+	}
+}
+
+// exitOnRunError reports a template that could not be run before exiting
+func exitOnRunError(err error) {
+	if err != nil {
+		writeRunReport(template.NewFailedRunReport(err))
+	}
+	exitOn(err)
+}
+
+// templateHelpFunc prints the declared params of the template given as arg (i.e. `awless run tpl.aws --help`)
+func templateHelpFunc(c *cobra.Command, args []string) {
+	if c.Flags().NArg() < 1 {
+		c.Parent().HelpFunc()(c, args)
+		return
+	}
+	path := c.Flags().Arg(0)
+	content, _, err := getTemplateText(path)
+	exitOn(err)
+	templ, err := template.Parse(string(content))
+	exitOn(err)
+	decls, err := templ.ParamDeclarations()
+	exitOn(err)
+
+	fmt.Printf("Usage:\n  awless run %s [param=value ...]\n\n", path)
+	if len(decls) == 0 {
+		fmt.Println("No params declared in this template (declare them with `# Param: <hole> <type> \"description\" default=<value> allowed=<value>,<value>`)")
+		return
+	}
+	fmt.Printf("Params:\n%s", decls.Doc())
+}
+
+func missingHolesStdinFunc(decls template.ParamDeclarations) func(string, []string, bool) string {
 	var count int
 	return func(hole string, paramPaths []string, optional bool) (response string) {
 		if count < 1 {
-			fmt.Println("Please specify (Ctrl+C to quit, Tab for completion, Enter to skip optionals):")
+			fmt.Fprintln(runInteractionsOutput(), "Please specify (Ctrl+C to quit, Tab for completion, Enter to skip optionals):")
 		}
 		var docs, enums []string
 		var typedParam *awsdoc.ParamType
@@ -154,6 +220,10 @@ func missingHolesStdinFunc() func(string, []string, bool) string {
 				typedParam = tparam
 			}
 		}
+		decl, declared := decls.Get(hole)
+		if declared && decl.Description != "" {
+			docs = []string{decl.Description}
+		}
 		if len(docs) > 0 {
 			fmt.Fprintln(os.Stderr, strings.Join(docs, "; ")+":")
 		}
@@ -162,18 +232,34 @@ func missingHolesStdinFunc() func(string, []string, bool) string {
 		if typedParam != nil {
 			autocomplete = typedParamCompletionFunc(allGraphsOnce.mustLoad(), typedParam.ResourceType, typedParam.PropertyName)
 		}
+		if declared && decl.Type == template.ResourceIDParam {
+			autocomplete = typedParamCompletionFunc(allGraphsOnce.mustLoad(), decl.ResourceType, properties.ID)
+		}
 
 		if len(enums) > 0 {
 			autocomplete = enumCompletionFunc(enums)
 		}
+		if declared && len(decl.Allowed) > 0 {
+			autocomplete = enumCompletionFunc(decl.Allowed)
+		}
 
 		var promptSuffix string
+		if declared {
+			promptSuffix = fmt.Sprintf(" (%s)", decl.TypeString())
+		}
 		if optional {
-			promptSuffix = " (optional)"
+			promptSuffix += " (optional)"
 		}
 		var err error
-		for response, err = askHole(hole, promptSuffix, autocomplete); err != nil; response, err = askHole(hole, promptSuffix, autocomplete) {
-			if optional {
+		for {
+			response, err = askHole(hole, promptSuffix, autocomplete)
+			if err == nil && declared {
+				err = decl.Validate(response)
+			}
+			if err == nil {
+				break
+			}
+			if optional && response == "" {
 				return ""
 			}
 			logger.Error(err)
@@ -189,6 +275,7 @@ func askHole(hole, promptSuffix string, autocomplete readline.AutoCompleter) (st
 		AutoComplete:    autocomplete,
 		InterruptPrompt: "^C",
 		EOFPrompt:       "exit",
+		Stdout:          runInteractionsOutput(),
 	})
 	if err != nil {
 		exitOn(err)
@@ -376,6 +463,15 @@ func runSyncFor(tplExec *template.TemplateExecution) {
 }
 
 func resolveAliasFunc(paramPath, alias string) string {
+	gph, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+	if err != nil {
+		logger.Errorf("resolve alias '%s': cannot load local graphs for region %s: %s", alias, config.GetAWSRegion(), err)
+		return ""
+	}
+	return resolveAliasInGraph(gph, paramPath, alias)
+}
+
+func resolveAliasInGraph(gph cloud.GraphAPI, paramPath, alias string) string {
 	splits := strings.Split(paramPath, ".")
 	if len(splits) != 3 {
 		logger.Errorf("resolve alias: invalid param path: %s", paramPath)
@@ -387,11 +483,6 @@ func resolveAliasFunc(paramPath, alias string) string {
 		typedParam = tparam
 	}
 
-	gph, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
-	if err != nil {
-		fmt.Printf("resolve alias '%s': cannot load local graphs for region %s: %s\n", alias, config.GetAWSRegion(), err)
-		return ""
-	}
 	resType := key
 	if typedParam != nil {
 		resType = typedParam.ResourceType
@@ -444,22 +535,53 @@ const (
 	FILE_EXT            = ".aws"
 )
 
-type templateMetadata struct {
-	Title, Name, MinimalVersion string
-	Tags                        []string
+const defaultCatalogName = "repo"
+
+var (
+	catalogsOnce stdsync.Once
+	catalogs     map[string]catalog.Catalog
+)
+
+// templateCatalogs returns the public templates catalog (`repo:`) and the ones set in config
+func templateCatalogs() map[string]catalog.Catalog {
+	catalogsOnce.Do(func() {
+		locations := map[string]string{defaultCatalogName: DEFAULT_REPO_PREFIX}
+		for name, location := range config.GetTemplateRepositories() {
+			locations[name] = location
+		}
+		catalogs = make(map[string]catalog.Catalog)
+		for name, location := range locations {
+			catalogs[name] = catalog.New(name, location, filepath.Join(config.AwlessHome, "templates", name))
+		}
+	})
+	return catalogs
 }
 
-func getTemplateText(path string) (content []byte, expanded string, err error) {
-	if strings.HasPrefix(path, "repo:") {
-		path = fmt.Sprintf("%s/%s", DEFAULT_REPO_PREFIX, strings.TrimPrefix(path[5:], "/"))
-		path = fmt.Sprintf("%s%s", strings.TrimSuffix(path, FILE_EXT), FILE_EXT)
+// catalogRef returns the reference of a template of a known catalog (i.e. `myrepo:name@version`)
+func catalogRef(path string) (*catalog.Ref, catalog.Catalog, bool) {
+	ref, ok := catalog.ParseRef(path)
+	if !ok {
+		return nil, nil, false
 	}
+	cat, ok := templateCatalogs()[ref.Catalog]
+	return ref, cat, ok
+}
 
+func getTemplateText(path string) (content []byte, expanded string, err error) {
 	expanded = path
 
-	if strings.HasPrefix(path, "http") {
+	if ref, cat, ok := catalogRef(path); ok {
+		logger.ExtraVerbosef("loading template %s from catalog at '%s'", ref, cat.Location())
+		content, expanded, err = cat.Get(ref.Name, ref.Version)
+		if err == nil && !catalog.IsLocal(cat) {
+			err = verifyTemplateSignature(ref.String(), content, func() ([]byte, error) { return cat.Signature(ref.Name, ref.Version) })
+		}
+	} else if strings.HasPrefix(path, "http") {
 		logger.ExtraVerbosef("fetching remote template at '%s'", path)
 		content, err = readHttpContent(path)
+		if err == nil {
+			err = verifyTemplateSignature(path, content, func() ([]byte, error) { return readHttpSignature(path + catalog.SignatureExt) })
+		}
 	} else {
 		f, ferr := os.Open(path)
 		if ferr != nil {
@@ -490,6 +612,80 @@ func getTemplateText(path string) (content []byte, expanded string, err error) {
 	return content, expanded, nil
 }
 
+// verifyTemplateSignature verifies the detached signature of a remote template against the trusted keys
+// in config, refusing unsigned templates when required in config
+func verifyTemplateSignature(location string, content []byte, signature func() ([]byte, error)) error {
+	sig, err := signature()
+	if err != nil {
+		return fmt.Errorf("cannot fetch signature of template %s: %s", location, err)
+	}
+	requireSigned := config.GetTemplateRequireSigned()
+	if sig == nil {
+		if requireSigned {
+			return fmt.Errorf("refusing to run unsigned remote template %s, as required in config (template.requiresigned)", location)
+		}
+		logger.Verbosef("remote template %s is not signed", location)
+		return nil
+	}
+
+	var keys []*integrity.PublicKey
+	for name, encoded := range config.GetTemplateTrustedKeys() {
+		key, err := integrity.ParsePublicKey(name, encoded)
+		if err != nil {
+			return fmt.Errorf("config: template.trustedkey.%s: %s", name, err)
+		}
+		keys = append(keys, key)
+	}
+	key, err := integrity.Verify(content, sig, keys)
+	switch {
+	case err == integrity.ErrNoTrustedKeys && !requireSigned:
+		logger.Warningf("cannot verify signature of template %s: no trusted keys in config (add one with `awless config set template.trustedkey.<name> <key>`)", location)
+		return nil
+	case err != nil:
+		return fmt.Errorf("template %s: %s", location, err)
+	}
+	logger.Verbosef("verified signature of template %s with trusted key %s", location, key)
+	return nil
+}
+
+// includeTemplateFunc loads the templates included by the template at rootPath.
+// Relative paths are resolved against the including template location.
+// Remote includes are refused when the template is pinned with --sha256, as their content is not.
+func includeTemplateFunc(rootPath string) func(path, from string) (string, string, error) {
+	return func(path, from string) (string, string, error) {
+		if from == "" {
+			from = rootPath
+		}
+		switch {
+		case isCatalogRef(path), strings.HasPrefix(path, "http"), filepath.IsAbs(path), from == "":
+		case isCatalogRef(from):
+			ref, _, _ := catalogRef(from)
+			resolved, err := ref.Resolve(path)
+			if err != nil {
+				return "", "", err
+			}
+			path = resolved.String()
+		case strings.HasPrefix(from, "http"):
+			base, err := url.Parse(from)
+			if err != nil {
+				return "", "", err
+			}
+			rel, err := url.Parse(path)
+			if err != nil {
+				return "", "", err
+			}
+			path = base.ResolveReference(rel).String()
+		default:
+			path = filepath.Join(filepath.Dir(from), path)
+		}
+		if runSHA256Flag != "" && isRemoteTemplate(path) {
+			return "", "", fmt.Errorf("refusing to include remote template %s in a template pinned with --sha256: include a local copy instead", path)
+		}
+		content, expanded, err := getTemplateText(path)
+		return string(content), expanded, err
+	}
+}
+
 func removeComments(b []byte) []byte {
 	scn := bufio.NewScanner(bytes.NewReader(b))
 	var cleaned bytes.Buffer
@@ -521,24 +717,42 @@ func detectMinimalVersionInTemplate(content []byte) (string, bool) {
 	return "", false
 }
 
-func listRemoteTemplates() error {
-	manifestFile, err := readHttpContent(DEFAULT_REPO_PREFIX + "/manifest.json")
-	if err != nil {
-		return err
+func isCatalogRef(path string) bool {
+	_, _, ok := catalogRef(path)
+	return ok
+}
+
+// isRemoteTemplate tells if the template at path is fetched over the network (URL, git or HTTP catalog)
+func isRemoteTemplate(path string) bool {
+	if _, cat, ok := catalogRef(path); ok {
+		return !catalog.IsLocal(cat)
 	}
-	var remoteTemplates []*templateMetadata
-	if err = json.Unmarshal(manifestFile, &remoteTemplates); err != nil {
-		return err
+	return strings.HasPrefix(path, "http")
+}
+
+func listCatalogsTemplates() error {
+	var names []string
+	for name := range templateCatalogs() {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "Title\tTags\tRun it with")
-	fmt.Fprintln(w, "-----\t----\t-----------")
-	for _, tpl := range remoteTemplates {
-		if tpl.MinimalVersion == "" {
-			tpl.MinimalVersion = config.Version
+	fmt.Fprintln(w, "Title\tTags\tVersions\tRun it with")
+	fmt.Fprintln(w, "-----\t----\t--------\t-----------")
+	for _, name := range names {
+		templates, err := templateCatalogs()[name].List()
+		if err != nil {
+			logger.Warningf("cannot list templates of catalog %s: %s", name, err)
+			continue
 		}
-		if comp, err := config.CompareSemver(tpl.MinimalVersion, config.Version); comp < 1 && err == nil {
-			fmt.Fprintln(w, fmt.Sprintf("%s\t%s\tawless run repo:%s -v", tpl.Title, strings.Join(tpl.Tags, ","), tpl.Name))
+		for _, tpl := range templates {
+			if tpl.MinimalVersion == "" {
+				tpl.MinimalVersion = config.Version
+			}
+			if comp, err := config.CompareSemver(tpl.MinimalVersion, config.Version); comp < 1 && err == nil {
+				fmt.Fprintln(w, fmt.Sprintf("%s\t%s\t%s\tawless run %s:%s -v", tpl.Title, strings.Join(tpl.Tags, ","), strings.Join(tpl.Versions, ","), name, tpl.Name))
+			}
 		}
 	}
 	w.Flush()
@@ -559,6 +773,23 @@ func readHttpContent(path string) ([]byte, error) {
 	return ioutil.ReadAll(resp.Body)
 }
 
+func readHttpSignature(path string) ([]byte, error) {
+	resp, err := http.Get(path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return ioutil.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("'%s' when fetching '%s'", resp.Status, path)
+	}
+}
+
 func isQuoted(s string) bool {
 	if strings.HasPrefix(s, "@") {
 		return isQuoted(s[1:])
@@ -1,6 +1,16 @@
 package commands
 
-import "testing"
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/template/catalog"
+	"golang.org/x/crypto/ed25519"
+)
 
 func TestIsCSV(t *testing.T) {
 	tcases := []struct {
@@ -43,3 +53,107 @@ func TestJoinSentence(t *testing.T) {
 		}
 	}
 }
+
+type mockCatalog map[string]string
+
+func (c mockCatalog) Name() string                       { return "mine" }
+func (c mockCatalog) Location() string                   { return "mock" }
+func (c mockCatalog) List() ([]*catalog.Metadata, error) { return nil, nil }
+func (c mockCatalog) Signature(name, version string) ([]byte, error) {
+	return nil, nil
+}
+func (c mockCatalog) Get(name, version string) ([]byte, string, error) {
+	content, ok := c[name+"@"+version]
+	if !ok {
+		return nil, "", fmt.Errorf("%s@%s not found", name, version)
+	}
+	ref := &catalog.Ref{Catalog: "mine", Name: name, Version: version}
+	return []byte(content), ref.String(), nil
+}
+
+func TestGetTemplateFromCatalog(t *testing.T) {
+	catalogsOnce.Do(func() {})
+	catalogs = map[string]catalog.Catalog{"mine": mockCatalog{
+		"infra/instance@v1": "include \"../network/vpc\"\ncreate instance subnet=$subnet",
+		"network/vpc@v1":    "subnet = create subnet cidr=10.0.0.0/24 vpc=vpc-1234",
+	}}
+	defer func() { catalogs = nil }()
+
+	content, expanded, err := getTemplateText("mine:infra/instance@v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := expanded, "mine:infra/instance@v1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := string(content), "include \"../network/vpc\"\ncreate instance subnet=$subnet"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	included, includedPath, err := includeTemplateFunc(expanded)("../network/vpc", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := includedPath, "mine:network/vpc@v1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := included, "subnet = create subnet cidr=10.0.0.0/24 vpc=vpc-1234"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if _, _, err = getTemplateText("mine:unknown@v1"); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, _, err = includeTemplateFunc(expanded)("../../../outside", ""); err == nil || !strings.Contains(err.Error(), "escapes the root of catalog mine") {
+		t.Fatalf("got %v, want include escaping the catalog refused", err)
+	}
+
+	runSHA256Flag = "0123"
+	defer func() { runSHA256Flag = "" }()
+	if _, _, err = includeTemplateFunc(expanded)("../network/vpc", ""); err == nil || !strings.Contains(err.Error(), "pinned with --sha256") {
+		t.Fatalf("got %v, want remote include refused", err)
+	}
+	if _, _, err = includeTemplateFunc("/templates/infra.aws")("https://example.com/vpc.aws", ""); err == nil || !strings.Contains(err.Error(), "pinned with --sha256") {
+		t.Fatalf("got %v, want remote include refused", err)
+	}
+}
+
+func TestVerifyTemplateSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("create vpc cidr=10.0.0.0/16")
+	signed := func() ([]byte, error) {
+		return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, content))), nil
+	}
+	unsigned := func() ([]byte, error) { return nil, nil }
+	defer func() { config.Config = map[string]interface{}{} }()
+
+	tcases := []struct {
+		config    map[string]interface{}
+		signature func() ([]byte, error)
+		expErr    bool
+	}{
+		{config: map[string]interface{}{}, signature: unsigned},
+		{config: map[string]interface{}{}, signature: signed},
+		{config: map[string]interface{}{"template.requiresigned": true}, signature: unsigned, expErr: true},
+		{config: map[string]interface{}{"template.requiresigned": true}, signature: signed, expErr: true},
+		{config: map[string]interface{}{"template.requiresigned": true, "template.trustedkey.me": base64.StdEncoding.EncodeToString(pub)}, signature: signed},
+		{config: map[string]interface{}{"template.trustedkey.me": base64.StdEncoding.EncodeToString(pub)}, signature: signed},
+		{config: map[string]interface{}{"template.trustedkey.me": base64.StdEncoding.EncodeToString(pub)}, signature: func() ([]byte, error) {
+			return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte("tampered")))), nil
+		}, expErr: true},
+		{config: map[string]interface{}{"template.trustedkey.me": "invalid"}, signature: signed, expErr: true},
+	}
+	for i, tcase := range tcases {
+		config.Config = tcase.config
+		err := verifyTemplateSignature("mine:vpc", content, tcase.signature)
+		if tcase.expErr && err == nil {
+			t.Fatalf("%d: expected error", i+1)
+		}
+		if !tcase.expErr && err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+	}
+}
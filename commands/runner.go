@@ -33,7 +33,9 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 	runner.TemplatePath = tplPath
 	runner.Fillers = fillers
 	runner.AliasFunc = resolveAliasFunc
-	runner.MissingHolesFunc = missingHolesStdinFunc()
+	decls, _ := tpl.ParamDeclarations() // invalid declarations are reported when running
+	runner.MissingHolesFunc = missingHolesStdinFunc(decls)
+	runner.IncludeFunc = includeTemplateFunc(tplPath)
 	if allSuggestedParamsFlag {
 		runner.ParamsSuggested = env.ALL_PARAMS
 	}
@@ -49,24 +51,19 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 		&template.ParamIsSetValidator{Action: "create", Entity: "instance", Param: "keypair", WarningMessage: "This instance has no access keypair. You might not be able to connect to it. Use `awless create instance keypair=my-keypair ...`"},
 	}
 
-	runner.CmdLookuper = func(tokens ...string) interface{} {
-		newCommandFunc := awsspec.CommandFactory.Build(strings.Join(tokens, ""))
-		if newCommandFunc == nil {
-			return nil
-		}
-		return newCommandFunc()
-	}
+	runner.CmdLookuper = lookupCommandFunc
 
 	runner.BeforeRun = func(tplExec *template.TemplateExecution) (bool, error) {
 		var yesorno string
 		if forceGlobalFlag {
 			yesorno = "y"
 		} else {
-			fmt.Printf("%s\n\n", renderGreenFn(tplExec.Template))
+			out := runInteractionsOutput()
+			fmt.Fprintf(out, "%s\n\n", renderGreenFn(tplExec.Template))
 			if isSchedulingMode() {
-				fmt.Print("Confirm scheduling? [y/N] ")
+				fmt.Fprint(out, "Confirm scheduling? [y/N] ")
 			} else {
-				fmt.Print("Confirm? [y/N] ")
+				fmt.Fprint(out, "Confirm? [y/N] ")
 			}
 			if _, err := fmt.Scanln(&yesorno); err != nil && err.Error() != "unexpected newline" {
 				return false, err
@@ -74,15 +71,13 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 		}
 
 		if strings.TrimSpace(strings.ToLower(yesorno)) == "y" {
-			me, err := awsservices.AccessService.(*awsservices.Access).GetIdentity()
-			if err != nil {
-				logger.Warningf("cannot resolve template author identity: %s", err)
-			} else {
-				tplExec.Author = me.ResourcePath
-				logger.ExtraVerbosef("resolved template author: %s", tplExec.Author)
-			}
+			resolveTemplateAuthor(tplExec)
 			if isSchedulingMode() {
-				return false, scheduleTemplate(tplExec.Template, scheduleRunInFlag, scheduleRevertInFlag)
+				if err := scheduleTemplate(tplExec.Template, scheduleRunInFlag, scheduleRevertInFlag); err != nil {
+					return false, err
+				}
+				writeRunReport(template.NewScheduledRunReport(tplExec))
+				return false, nil
 			}
 			return true, nil
 		}
@@ -110,10 +105,12 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 			logger.Errorf("Cannot save executed template in awless logs: %s", err)
 		}
 
-		if template.IsRevertible(tplExec.Template) {
-			fmt.Println()
+		revertible := template.IsRevertible(tplExec.Template, lookupCommandFunc)
+		if revertible {
+			fmt.Fprintln(runInteractionsOutput())
 			logger.Infof("Revert this template with `awless revert %s`", tplExec.Template.ID)
 		}
+		writeRunReport(template.NewRunReport(tplExec, revertible))
 
 		runSyncFor(tplExec)
 
@@ -122,3 +119,26 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 
 	return runner
 }
+
+func resolveTemplateAuthor(tplExec *template.TemplateExecution) {
+	me, err := awsservices.AccessService.(*awsservices.Access).GetIdentity()
+	if err != nil {
+		logger.Warningf("cannot resolve template author identity: %s", err)
+		return
+	}
+	tplExec.Author = me.ResourcePath
+	logger.ExtraVerbosef("resolved template author: %s", tplExec.Author)
+}
+
+func lookupCommandFunc(tokens ...string) interface{} {
+	factory := awsspec.CommandFactory
+	if factory == nil {
+		// commands are then only looked up for their definitions (i.e. revert), not run
+		factory = &awsspec.AWSFactory{Log: logger.DiscardLogger}
+	}
+	newCommandFunc := factory.Build(strings.Join(tokens, ""))
+	if newCommandFunc == nil {
+		return nil
+	}
+	return newCommandFunc()
+}
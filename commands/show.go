@@ -78,17 +78,24 @@ var showCmd = &cobra.Command{
 
 		resource, gph = findResourceInLocalGraphs(ref)
 
-		if resource == nil && localGlobalFlag {
-			exitOn(decorateWithSuggestion(notFound, ref))
-		} else if resource == nil {
+		if resource == nil && !localGlobalFlag {
 			runFullSync()
+			resource, gph = findResourceInLocalGraphs(ref)
+		}
 
-			if resource, gph = findResourceInLocalGraphs(ref); resource == nil {
-				exitOn(decorateWithSuggestion(notFound, ref))
+		var inOtherProfile bool
+		if resource == nil {
+			if resource, gph = findResourceInOtherProfiles(ref); resource == nil {
+				err := decorateWithSuggestion(notFound, ref)
+				if failed := failedSyncTypes(); len(failed) > 0 {
+					err = fmt.Errorf("%s\n\tunknown: not synced %s", err, strings.Join(failed, ", "))
+				}
+				exitOn(err)
 			}
+			inOtherProfile = true
 		}
 
-		if !localGlobalFlag && config.GetAutosync() {
+		if !localGlobalFlag && !inOtherProfile && config.GetAutosync() {
 			var services []cloud.Service
 			if resource.Type() == cloud.Region {
 				services = append(services, cloud.AllServices()...)
@@ -103,6 +110,8 @@ var showCmd = &cobra.Command{
 				logger.Verbose(err)
 			}
 			resource, gph = findResourceInLocalGraphs(ref)
+		} else if !inOtherProfile {
+			warnStaleLocalData(resource.Type())
 		}
 
 		if resource != nil {
@@ -236,11 +245,22 @@ func runFullSync() {
 }
 
 func findResourceInLocalGraphs(ref string) (cloud.Resource, cloud.GraphAPI) {
-	g, resources, _ := resolveResourceFromRefInCurrentRegion(ref)
+	types, indexed := sync.LocalReferenceTypes(config.GetAWSProfile(), deprefix(ref))
+	if indexed && len(types) == 0 {
+		return nil, nil
+	}
+	g, resources, _ := resolveResourceFromRefInCurrentRegion(ref, types...)
 	switch len(resources) {
 	case 0:
 		return nil, nil
 	case 1:
+		// the resources were resolved from the sections of the referenced types only,
+		// while showing the lineage, applied on and siblings needs all types
+		if len(types) > 0 && len(showPropertiesValuesOnlyFlag) == 0 {
+			full, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+			exitOn(err)
+			return resources[0], full
+		}
 		return resources[0], g
 	default:
 		logger.Infof("%d resources found with name '%s' in region '%s' for profile '%s'. Show a specific resource with:", len(resources), deprefix(ref), config.GetAWSRegion(), config.GetAWSProfile())
@@ -259,8 +279,57 @@ func findResourceInLocalGraphs(ref string) (cloud.Resource, cloud.GraphAPI) {
 	return nil, nil
 }
 
-func resolveResourceFromRefInCurrentRegion(ref string) (cloud.GraphAPI, []cloud.Resource, string) {
-	g, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+// findResourceInOtherProfiles resolves a resource in the data synced locally for the other profiles (see `awless sync --profiles`)
+func findResourceInOtherProfiles(ref string) (cloud.Resource, cloud.GraphAPI) {
+	type found struct {
+		profile  string
+		resource cloud.Resource
+		gph      cloud.GraphAPI
+		partial  bool
+	}
+	var all []found
+	for _, profile := range allProfiles(config.GetAWSProfile())[1:] {
+		types, indexed := sync.LocalReferenceTypes(profile, deprefix(ref))
+		if indexed && len(types) == 0 {
+			continue
+		}
+		g, err := sync.LoadAllLocalGraphs(profile, types...)
+		if err != nil {
+			logger.Verbosef("loading local data of profile '%s': %s", profile, err)
+			continue
+		}
+		_, resources, _ := resolveResourceFromRef(g, ref)
+		for _, res := range resources {
+			all = append(all, found{profile: profile, resource: res, gph: g, partial: len(types) > 0})
+		}
+	}
+	switch len(all) {
+	case 0:
+		return nil, nil
+	case 1:
+		account := sync.LocalAccount(all[0].profile)
+		if account == "" {
+			account = "unknown"
+		}
+		logger.Infof("%s found in data synced for profile '%s' (account %s)", all[0].resource, all[0].profile, account)
+		if all[0].partial && len(showPropertiesValuesOnlyFlag) == 0 {
+			full, err := sync.LoadAllLocalGraphs(all[0].profile)
+			exitOn(err)
+			return all[0].resource, full
+		}
+		return all[0].resource, all[0].gph
+	default:
+		logger.Infof("%d resources found with reference '%s' in other profiles. Show a specific resource with:", len(all), deprefix(ref))
+		for _, f := range all {
+			logger.Infof("\t`awless show %s -p %s --local` to show the %s", f.resource.Id(), f.profile, f.resource.Type())
+		}
+		os.Exit(0)
+	}
+	return nil, nil
+}
+
+func resolveResourceFromRefInCurrentRegion(ref string, resourceTypes ...string) (cloud.GraphAPI, []cloud.Resource, string) {
+	g, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion(), resourceTypes...)
 	exitOn(err)
 	return resolveResourceFromRef(g, ref)
 }
@@ -112,6 +112,7 @@ var sshCmd = &cobra.Command{
 		firsHopClient.SetLogger(logger.DefaultLogger)
 		firsHopClient.SetStrictHostKeyChecking(!disableStrictHostKeyCheckingFlag)
 		firsHopClient.InteractiveTerminalFunc = console.InteractiveTerminal
+		firsHopClient.HostKeyFingerprintsFunc = consoleHostKeyFingerprints(connectionCtx.resourcesGraph)
 		if proxyInstanceThroughFlag != "" {
 			firsHopClient.Port = sshTroughPortFlag
 		} else {
@@ -187,6 +188,29 @@ var sshCmd = &cobra.Command{
 	},
 }
 
+func consoleHostKeyFingerprints(g cloud.GraphAPI) func(string) ([]string, error) {
+	return func(hostname string) ([]string, error) {
+		ip, _, err := net.SplitHostPort(hostname)
+		if err != nil {
+			ip = hostname
+		}
+		inst, err := g.FindOne(cloud.NewQuery(cloud.Instance).Match(match.Or(match.Property(properties.PublicIP, ip), match.Property(properties.PrivateIP, ip))))
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve instance with IP %s: %s", ip, err)
+		}
+		logger.ExtraVerbosef("fetching console output of instance %s to verify its host key", inst.Id())
+		infra, ok := awsservices.InfraService.(*awsservices.Infra)
+		if !ok {
+			return nil, fmt.Errorf("cannot fetch console output of instance %s: unexpected infra service %T", inst.Id(), awsservices.InfraService)
+		}
+		output, err := infra.InstanceConsoleOutput(inst.Id())
+		if err != nil {
+			return nil, err
+		}
+		return ssh.ParseHostKeyFingerprints(output), nil
+	}
+}
+
 func isConnectionRefusedErr(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "connection refused")
 }
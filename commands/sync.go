@@ -17,30 +17,67 @@ limitations under the License.
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"runtime"
 	"runtime/pprof"
+	"sort"
 	"strings"
+	gosync "sync"
+	"syscall"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/config"
 	"github.com/wallix/awless/aws/services"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/console"
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
+	"github.com/wallix/awless/sync/repo"
 )
 
 var (
 	servicesToSyncFlags map[string]*bool
 	profileSyncFlag     bool
+	syncRegionsFlag     string
+	syncProfilesFlag    string
+	syncEventsFlag      string
+	syncWatchFlag       bool
+	syncTypesFlag       string
+	syncTypes           []string
+	syncRetention       repo.Retention
+	syncRemoteFlag      string
+	syncPullForceFlag   bool
+	syncGCForceFlag     bool
 )
 
 func init() {
 	RootCmd.AddCommand(syncCmd)
 	syncCmd.Flags().BoolVar(&profileSyncFlag, "profile-sync", false, "Will dump a cpu and mem profiling file")
+	syncCmd.Flags().StringVar(&syncProfilesFlag, "profiles", "", "Sync the given comma separated AWS profiles (i.e. accounts), or 'all' for all profiles found in the AWS config files. Ex: --profiles prod,staging")
+	syncCmd.Flags().StringVar(&syncEventsFlag, "events", "", "Publish the resource changes of this sync as JSON lines to 'stdout', a file path or an http(s) webhook URL (overrides the 'sync.events' config)")
+	syncCmd.Flags().BoolVar(&syncWatchFlag, "watch", false, "Keep running and re-sync each service on its own interval (config sync.interval or aws.<service>.sync.interval, in minutes)")
+	syncCmd.Flags().StringVar(&syncTypesFlag, "types", "", "Sync the given comma separated resource types only, merging them into the local data of their services. Ex: --types instance,securitygroup")
+	syncCmd.Flags().StringVar(&syncRegionsFlag, "regions", "", "Sync the given comma separated regions concurrently, or 'all' for all standard regions. Ex: --regions eu-west-1,us-east-1")
+
+	syncCmd.AddCommand(syncGCCmd)
+	syncCmd.AddCommand(syncStatsCmd)
+	syncCmd.AddCommand(syncPushCmd)
+	syncCmd.AddCommand(syncPullCmd)
+	for _, cmd := range []*cobra.Command{syncPushCmd, syncPullCmd} {
+		cmd.Flags().StringVar(&syncRemoteFlag, "remote", "", "Remote store: a git repository URL or path, or a S3 bucket as s3://<bucket>/<prefix> (overrides the 'sync.remote' config)")
+	}
+	syncPullCmd.Flags().BoolVar(&syncPullForceFlag, "force", false, "Replace the local revisions with the remote ones when they diverged")
+	syncGCCmd.Flags().IntVar(&syncRetention.Hourly, "keep-hourly", 24, "Number of latest hours for which to keep the last revision")
+	syncGCCmd.Flags().IntVar(&syncRetention.Daily, "keep-daily", 30, "Number of latest days for which to keep the last revision")
+	syncGCCmd.Flags().IntVar(&syncRetention.Monthly, "keep-monthly", 12, "Number of latest months for which to keep the last revision")
+	syncGCCmd.Flags().BoolVar(&syncGCForceFlag, "force", false, "Also remove the old revisions already pushed to the remote store: the local store then diverges from the remote one and cannot be pushed anymore (teammates have to 'awless sync pull --force')")
 
 	servicesToSyncFlags = make(map[string]*bool)
 	for _, service := range awsservices.ServiceNames {
@@ -56,28 +93,78 @@ var syncCmd = &cobra.Command{
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
 
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var services []cloud.Service
-		displayAllServices := true
-		for _, srv := range cloud.ServiceRegistry {
-			if *servicesToSyncFlags[srv.Name()] {
-				displayAllServices = false
-			}
+		regions, err := resolveSyncRegions(syncRegionsFlag, config.GetAWSRegion())
+		exitOn(err)
+
+		profiles, err := resolveSyncProfiles(syncProfilesFlag, config.GetAWSProfile())
+		exitOn(err)
+
+		syncTypes, err = resolveSyncTypes(syncTypesFlag)
+		exitOn(err)
+		if len(syncTypes) > 0 && syncWatchFlag {
+			exitOn(errors.New("--types cannot be used with --watch"))
 		}
-		for _, srv := range cloud.ServiceRegistry {
-			if displayAllServices || *servicesToSyncFlags[srv.Name()] {
-				services = append(services, srv)
+
+		var targets []*syncTarget
+		for _, profile := range profiles {
+			for i, region := range regions {
+				target := &syncTarget{profile: profile, region: region}
+				var services []cloud.Service
+				if profile == config.GetAWSProfile() && region == config.GetAWSRegion() {
+					services = cloud.AllServices()
+				} else if services, err = awsservices.ServicesFor(profile, region); err != nil {
+					logger.Errorf("cannot sync: %s", err)
+					break
+				}
+				if i == 0 && len(profiles) > 1 {
+					if account, err := awsservices.AccountOf(services); err != nil {
+						logger.Warningf("cannot get account of profile %s: %s", profile, err)
+					} else if err = sync.WriteLocalAccount(profile, account); err != nil {
+						logger.Warningf("cannot record account of profile %s: %s", profile, err)
+					}
+				}
+				for _, srv := range services {
+					if i == 0 || srv.Region() != sync.GlobalRegion { // global services are synced only once per profile
+						target.services = append(target.services, srv)
+					}
+				}
+				target.services = selectServicesToSync(target.services)
+				targets = append(targets, target)
 			}
 		}
-		localGraphs := make(map[string]cloud.GraphAPI)
-		for _, service := range services {
-			localGraphs[service.Name()] = sync.LoadLocalGraphForService(service.Name(), config.GetAWSProfile(), config.GetAWSRegion())
+		logger.Infof("running sync for region '%s'", strings.Join(regions, "', '"))
+		if len(profiles) > 1 {
+			logger.Infof("running sync for profile '%s'", strings.Join(profiles, "', '"))
+		}
+
+		if syncWatchFlag {
+			return watchSync(targets, len(profiles) > 1)
 		}
-		logger.Infof("running sync for region '%s'", config.GetAWSRegion())
 
-		var syncErr error
-		var graphs map[string]cloud.GraphAPI
+		var syncErrs []error
 		syncFn := func() {
-			graphs, syncErr = sync.DefaultSyncer.Sync(services...)
+			var wg gosync.WaitGroup
+			var mu gosync.Mutex
+			for _, target := range targets {
+				wg.Add(1)
+				go func(target *syncTarget) {
+					defer wg.Done()
+					var graphs map[string]cloud.GraphAPI
+					var err error
+					if len(syncTypes) > 0 {
+						graphs, err = sync.DefaultSyncer.SyncTypes(syncTypes, target.services...)
+					} else {
+						graphs, err = sync.DefaultSyncer.Sync(target.services...)
+					}
+					mu.Lock()
+					defer mu.Unlock()
+					if err != nil {
+						syncErrs = append(syncErrs, err)
+					}
+					target.graphs = graphs
+				}(target)
+			}
+			wg.Wait()
 		}
 
 		start := time.Now()
@@ -86,12 +173,18 @@ var syncCmd = &cobra.Command{
 		} else {
 			syncFn()
 		}
-		if syncErr != nil {
-			logger.Verbose(syncErr)
+		for _, err := range syncErrs {
+			logger.Verbose(err)
 		}
 
-		for k, g := range graphs {
-			displaySyncStats(k, g)
+		for _, target := range targets {
+			for k, g := range target.graphs {
+				if len(targets) == 1 {
+					displaySyncStats(k, g)
+				} else {
+					displaySyncStats(k, g, target.label(k, len(profiles) > 1))
+				}
+			}
 		}
 		logger.Infof("sync took %s", time.Since(start))
 
@@ -99,6 +192,464 @@ var syncCmd = &cobra.Command{
 	},
 }
 
+var syncGCCmd = &cobra.Command{
+	Use:               "gc",
+	Short:             "Remove the old revisions of the local store, keeping the last revision of the latest hours, days and months",
+	Example:           "  awless sync gc\n  awless sync gc --keep-hourly 24 --keep-daily 30 --keep-monthly 12\n  awless sync gc --force",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initSyncerHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		before, err := sync.DefaultSyncer.Stats()
+		exitOn(err)
+		removed, err := sync.DefaultSyncer.GC(syncRetention, syncGCForceFlag)
+		exitOn(err)
+		after, err := sync.DefaultSyncer.Stats()
+		exitOn(err)
+		logger.Infof("removed %d revisions, %d left, local store size from %s to %s", removed, after.Revisions,
+			console.HumanizeStorage(uint64(before.Size), 0), console.HumanizeStorage(uint64(after.Size), 0))
+		return nil
+	},
+}
+
+var syncStatsCmd = &cobra.Command{
+	Use:               "stats",
+	Short:             "Show the size of the local store, its number of revisions and the size of each synced snapshot",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initSyncerHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stats, err := sync.DefaultSyncer.Stats()
+		exitOn(err)
+
+		fmt.Printf("Local store: %s\n", sync.DefaultSyncer.BaseDir())
+		fmt.Printf("Size: %s\n", console.HumanizeStorage(uint64(stats.Size), 0))
+		fmt.Printf("Revisions: %d\n\n", stats.Revisions)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "Snapshot\tSize\tVersions")
+		fmt.Fprintln(w, "--------\t----\t--------")
+		for _, f := range stats.Files {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", f.Path, console.HumanizeStorage(uint64(f.Size), 0), f.Versions)
+		}
+		return w.Flush()
+	},
+}
+
+var syncPushCmd = &cobra.Command{
+	Use:               "push",
+	Short:             "Publish the revisions of the local store to the remote store shared with your team (fast-forward only)",
+	Example:           "  awless sync push --remote s3://my-team-bucket/awless\n  awless sync push --remote git@github.com:my-team/inventory.git",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initSyncerHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		remote, err := syncRemote(cmd, args)
+		exitOn(err)
+		pushed, err := sync.DefaultSyncer.Push(remote)
+		if err == repo.ErrDiverged {
+			err = fmt.Errorf("%s: get the remote revisions first with `awless sync pull`", err)
+		}
+		exitOn(err)
+		if pushed {
+			logger.Info("local store pushed to remote store")
+		} else {
+			logger.Info("remote store already up to date")
+		}
+		return nil
+	},
+}
+
+var syncPullCmd = &cobra.Command{
+	Use:               "pull",
+	Short:             "Update the local store with the revisions of the remote store shared with your team (fast-forward only)",
+	Long:              "Update the local store with the revisions of the remote store shared with your team (fast-forward only).\nThe pulled inventory is then available with the --local flag, i.e. `awless ls instances --local`",
+	Example:           "  awless sync pull --remote s3://my-team-bucket/awless\n  awless sync pull --force",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initSyncerHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		remote, err := syncRemote(cmd, args)
+		exitOn(err)
+		pulled, err := sync.DefaultSyncer.Pull(remote, syncPullForceFlag)
+		if err == repo.ErrDiverged {
+			err = fmt.Errorf("%s: replace the local revisions with the remote ones with `awless sync pull --force`", err)
+		}
+		exitOn(err)
+		if pulled {
+			logger.Info("local store updated from remote store")
+		} else {
+			logger.Info("local store already up to date")
+		}
+		return nil
+	},
+}
+
+// syncRemote returns the remote store given by the --remote flag or the sync.remote config.
+// The AWS session is only loaded for S3 remotes
+func syncRemote(cmd *cobra.Command, args []string) (repo.Remote, error) {
+	url, endpoint := config.GetSyncRemote()
+	if syncRemoteFlag != "" {
+		url = syncRemoteFlag
+	}
+	if url == "" {
+		return nil, errors.New("no remote store: set one with `awless config set sync.remote <url>` or the --remote flag")
+	}
+	bucket, prefix, isS3 := parseS3URL(url)
+	if !isS3 {
+		return repo.NewGitRemote(url), nil
+	}
+	if err := initCloudServicesHook(cmd, args); err != nil {
+		return nil, err
+	}
+	api, err := awsservices.S3APIFor(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return repo.NewObjectStoreRemote(repo.NewS3ObjectStore(api, bucket, prefix)), nil
+}
+
+// parseS3URL parses s3://<bucket>/<prefix> URLs, the prefix being optional
+func parseS3URL(url string) (bucket, prefix string, ok bool) {
+	if !strings.HasPrefix(url, "s3://") {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(url, "s3://"), "/", 2)
+	bucket = parts[0]
+	if len(parts) == 2 {
+		prefix = parts[1]
+	}
+	return bucket, prefix, bucket != ""
+}
+
+// resolveSyncRegions returns the regions to sync given the --regions flag: 'all' for all the
+// standard AWS regions, or a comma separated list. The current region comes first when synced.
+func resolveSyncRegions(flag, current string) ([]string, error) {
+	if strings.TrimSpace(flag) == "" {
+		return []string{current}, nil
+	}
+	var given []string
+	if strings.TrimSpace(flag) == "all" {
+		given = awsconfig.StandardRegions()
+	} else {
+		for _, r := range strings.Split(flag, ",") {
+			if r = strings.TrimSpace(r); r == "" {
+				continue
+			}
+			if !awsconfig.IsValidRegion(r) {
+				return nil, fmt.Errorf("invalid region '%s' in --regions", r)
+			}
+			given = append(given, r)
+		}
+	}
+	if len(given) == 0 {
+		return nil, errors.New("no region given in --regions")
+	}
+	return currentFirst(given, current), nil
+}
+
+type syncTarget struct {
+	profile, region string
+	services        []cloud.Service
+	graphs          map[string]cloud.GraphAPI
+}
+
+// label returns the region, prefixed with the profile when several profiles are synced, of a synced service
+func (t *syncTarget) label(service string, withProfile bool) string {
+	region := t.region
+	if sync.IsGlobalService(service) {
+		region = sync.GlobalRegion
+	}
+	if withProfile {
+		return t.profile + "/" + region
+	}
+	return region
+}
+
+// watchSync syncs each service of the targets on its own interval until interrupted,
+// keeping the watch status file up to date for `awless list` and `awless show`
+func watchSync(targets []*syncTarget, withProfile bool) error {
+	lock, err := sync.LockWatch()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := lock.Release(); err != nil {
+			logger.Warningf("cannot release sync watch lock: %s", err)
+		}
+	}()
+
+	now := time.Now()
+	status := &sync.WatchStatus{PID: os.Getpid(), Started: now, Updated: now, Services: make(map[string]*sync.ServiceStatus)}
+	for _, target := range targets {
+		for _, srv := range target.services {
+			interval := config.GetSyncInterval(srv.Name())
+			status.Services[sync.ServiceStatusKey(target.profile, target.region, srv.Name())] = &sync.ServiceStatus{
+				Profile: target.profile, Region: srv.Region(), Service: srv.Name(), Interval: interval.String(), NextSync: now,
+			}
+			logger.Infof("watching %s every %s", srv.Name(), interval)
+		}
+	}
+	defer func() {
+		if err := sync.RemoveWatchStatus(); err != nil {
+			logger.Warningf("cannot remove sync watch status: %s", err)
+		}
+	}()
+
+	var statusMu gosync.Mutex
+	writeStatus := func() {
+		statusMu.Lock()
+		defer statusMu.Unlock()
+		status.Updated = time.Now()
+		if err := sync.WriteWatchStatus(status); err != nil {
+			logger.Warningf("cannot write sync watch status: %s", err)
+		}
+		if err := lock.Refresh(); err != nil {
+			logger.Warningf("cannot refresh sync watch lock: %s", err)
+		}
+	}
+
+	// the heartbeat is also refreshed while syncing, as a round can last longer than the heartbeat
+	stopHeartbeat, heartbeatDone := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		ticker := time.NewTicker(sync.WatchHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				writeStatus()
+			case <-stopHeartbeat:
+				return
+			}
+		}
+	}()
+	defer func() {
+		close(stopHeartbeat)
+		<-heartbeatDone
+	}()
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+
+	for {
+		watchSyncDueServices(targets, status, &statusMu, withProfile)
+		writeStatus()
+
+		timer := time.NewTimer(nextWatchWakeUp(status, time.Now()))
+		select {
+		case sig := <-sigc:
+			timer.Stop()
+			logger.Infof("received %s, stopping sync watch", sig)
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// watchSyncDueServices syncs the services due of the targets, updating their status while holding statusMu
+func watchSyncDueServices(targets []*syncTarget, status *sync.WatchStatus, statusMu *gosync.Mutex, withProfile bool) {
+	var wg gosync.WaitGroup
+	for _, target := range targets {
+		var due []cloud.Service
+		for _, srv := range target.services {
+			if st := status.Services[sync.ServiceStatusKey(target.profile, target.region, srv.Name())]; !time.Now().Before(st.NextSync) {
+				due = append(due, srv)
+			}
+		}
+		if len(due) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(target *syncTarget, due []cloud.Service) {
+			defer wg.Done()
+			graphs, err := sync.DefaultSyncer.Sync(due...)
+			if err != nil {
+				logger.Verbose(err)
+			}
+			statusMu.Lock()
+			defer statusMu.Unlock()
+			for _, srv := range due {
+				st := status.Services[sync.ServiceStatusKey(target.profile, target.region, srv.Name())]
+				synced := time.Now()
+				st.NextSync = synced.Add(config.GetSyncInterval(srv.Name()))
+				if g, ok := graphs[srv.Name()]; ok {
+					st.LastSync, st.LastError = synced, ""
+					displaySyncStats(srv.Name(), g, target.label(srv.Name(), withProfile))
+				} else if err != nil {
+					st.LastError = err.Error()
+					logger.Errorf("sync of %s failed: %s", srv.Name(), err)
+				}
+			}
+		}(target, due)
+	}
+	wg.Wait()
+}
+
+// nextWatchWakeUp returns the delay until the next service is due, no longer than the heartbeat of the status
+func nextWatchWakeUp(status *sync.WatchStatus, now time.Time) time.Duration {
+	wait := sync.WatchHeartbeat
+	for _, st := range status.Services {
+		if d := st.NextSync.Sub(now); d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// warnStaleLocalData warns when the local data of the services of the given resource types,
+// for the current profile and region, has not been synced for longer than config sync.staleafter
+func warnStaleLocalData(resourceTypes ...string) {
+	staleAfter := config.GetSyncStaleAfter()
+	if staleAfter <= 0 {
+		return
+	}
+	watch, _ := sync.ReadWatchStatus()
+	profile, region := config.GetAWSProfile(), config.GetAWSRegion()
+	warned := make(map[string]bool)
+	for _, rt := range resourceTypes {
+		service, ok := awsservices.ServicePerResourceType[rt]
+		if !ok || warned[service] {
+			continue
+		}
+		warned[service] = true
+		last, ok := sync.LastLocalSync(profile, region, service)
+		if !ok || time.Since(last) < staleAfter {
+			continue
+		}
+		age := time.Since(last).Truncate(time.Minute)
+		if watch != nil && watch.IsAlive(time.Now()) {
+			if st, ok := watch.Services[sync.ServiceStatusKey(profile, region, service)]; ok && st.LastError != "" {
+				logger.Warningf("local %s data is %s old: last sync of `awless sync --watch` (pid %d) failed: %s", service, age, watch.PID, st.LastError)
+				continue
+			}
+		}
+		logger.Warningf("local %s data is %s old: run `awless sync` (or keep it fresh with `awless sync --watch`)", service, age)
+	}
+}
+
+// resolveSyncTypes returns the resource types to sync given the --types flag, empty to sync all
+func resolveSyncTypes(flag string) ([]string, error) {
+	var types []string
+	for _, t := range strings.Split(flag, ",") {
+		if t = strings.TrimSpace(t); t == "" {
+			continue
+		}
+		if _, ok := awsservices.ServicePerResourceType[t]; !ok {
+			if singular := cloud.SingularizeResource(t); awsservices.ServicePerResourceType[singular] != "" {
+				t = singular
+			} else {
+				return nil, fmt.Errorf("unknown resource type '%s' in --types", t)
+			}
+		}
+		if !contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	if strings.TrimSpace(flag) != "" && len(types) == 0 {
+		return nil, errors.New("no resource type given in --types")
+	}
+	return types, nil
+}
+
+func contains(arr []string, s string) bool {
+	for _, a := range arr {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveSyncProfiles returns the profiles to sync given the --profiles flag: 'all' for all the
+// profiles found in the AWS config files, or a comma separated list. The current profile comes first.
+func resolveSyncProfiles(flag, current string) ([]string, error) {
+	if strings.TrimSpace(flag) == "" {
+		return []string{current}, nil
+	}
+	var given []string
+	if strings.TrimSpace(flag) == "all" {
+		given = allProfiles(current)
+	} else {
+		for _, p := range strings.Split(flag, ",") {
+			if p = strings.TrimSpace(p); p == "" {
+				continue
+			}
+			if p != current && !awsconfig.IsValidProfile(p) {
+				return nil, fmt.Errorf("unknown profile '%s' in --profiles", p)
+			}
+			given = append(given, p)
+		}
+	}
+	if len(given) == 0 {
+		return nil, errors.New("no profile given in --profiles")
+	}
+	return currentFirst(given, current), nil
+}
+
+// allProfiles returns the profiles found in the AWS config files, with the current profile first
+func allProfiles(current string) []string {
+	return currentFirst(append([]string{current}, awsconfig.AllProfiles()...), current)
+}
+
+// currentFirst deduplicates the given values, putting current first when present
+func currentFirst(values []string, current string) (result []string) {
+	seen := make(map[string]bool)
+	for _, v := range values {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		if v == current {
+			result = append([]string{v}, result...)
+		} else {
+			result = append(result, v)
+		}
+	}
+	return
+}
+
+// selectServicesToSync returns the services selected with the per service flags (all when none given)
+func selectServicesToSync(all []cloud.Service) (services []cloud.Service) {
+	displayAllServices := true
+	for _, selected := range servicesToSyncFlags {
+		if *selected {
+			displayAllServices = false
+		}
+	}
+	for _, srv := range all {
+		if displayAllServices || *servicesToSyncFlags[srv.Name()] {
+			services = append(services, srv)
+		}
+	}
+	return
+}
+
+// notSyncedError returns the error of a resource type whose local data is unknown, as its last sync
+// failed or is disabled, nil when synced or never synced
+func notSyncedError(resourceType string) error {
+	st, ok := sync.LocalFetchStatus(config.GetAWSProfile(), config.GetAWSRegion(), resourceType)
+	if !ok || st.IsSynced() {
+		return nil
+	}
+	return fmt.Errorf("%s: unknown: not synced (%s)", cloud.PluralizeResource(resourceType), st.Reason())
+}
+
+// failedSyncTypes returns the resource types whose last sync failed in the current profile and region, with the reason
+func failedSyncTypes() (failed []string) {
+	for t, st := range sync.LocalFetchStatuses(config.GetAWSProfile(), config.GetAWSRegion()) {
+		if st.Status == sync.TypeFetchFailed {
+			failed = append(failed, fmt.Sprintf("%s (%s)", t, st.Reason()))
+		}
+	}
+	sort.Strings(failed)
+	return
+}
+
 func withProfiling(fn func()) {
 	logger.Infof("sync profiling on")
 	mem, err := os.Create("mem-sync.prof")
@@ -126,10 +677,10 @@ func withProfiling(fn func()) {
 	logger.Infof("Generated profiling files %s and %s", cpu.Name(), mem.Name())
 }
 
-func displaySyncStats(serviceName string, g cloud.GraphAPI) {
+func displaySyncStats(serviceName string, g cloud.GraphAPI, region ...string) {
 	var strs []string
 	for rt, service := range awsservices.ServicePerResourceType {
-		if service == serviceName {
+		if service == serviceName && (len(syncTypes) == 0 || contains(syncTypes, rt)) {
 			res, err := g.Find(cloud.NewQuery(rt))
 			if err != nil {
 				continue
@@ -142,5 +693,9 @@ func displaySyncStats(serviceName string, g cloud.GraphAPI) {
 			}
 		}
 	}
-	logger.Infof("-> %s: %s", serviceName, strings.Join(strs, ", "))
+	if len(region) > 0 {
+		logger.Infof("-> [%s] %s: %s", region[0], serviceName, strings.Join(strs, ", "))
+	} else {
+		logger.Infof("-> %s: %s", serviceName, strings.Join(strs, ", "))
+	}
 }
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/sync"
+)
+
+func TestResolveSyncRegions(t *testing.T) {
+	tcases := []struct {
+		flag      string
+		expect    []string
+		expectErr bool
+	}{
+		{flag: "", expect: []string{"eu-west-1"}},
+		{flag: "us-east-1", expect: []string{"us-east-1"}},
+		{flag: "us-east-1, eu-west-1,us-east-1", expect: []string{"eu-west-1", "us-east-1"}},
+		{flag: "us-east-1,mars-1", expectErr: true},
+		{flag: ",", expectErr: true},
+	}
+	for i, tcase := range tcases {
+		regions, err := resolveSyncRegions(tcase.flag, "eu-west-1")
+		if tcase.expectErr {
+			if err == nil {
+				t.Fatalf("%d: expected error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := regions, tcase.expect; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+	}
+
+	all, err := resolveSyncRegions("all", "eu-west-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) < 10 || all[0] != "eu-west-1" {
+		t.Fatalf("unexpected all regions %v", all)
+	}
+}
+
+func TestResolveSyncProfiles(t *testing.T) {
+	profiles, err := resolveSyncProfiles("", "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := profiles, []string{"default"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if _, err := resolveSyncProfiles("default,not-a-configured-profile", "default"); err == nil {
+		t.Fatal("expected error")
+	}
+	all, err := resolveSyncProfiles("all", "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) == 0 || all[0] != "default" {
+		t.Fatalf("unexpected all profiles %v", all)
+	}
+
+	if got, want := currentFirst([]string{"prod", "dev", "default", "prod"}, "default"), []string{"default", "prod", "dev"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestResolveSyncTypes(t *testing.T) {
+	tcases := []struct {
+		flag      string
+		expect    []string
+		expectErr bool
+	}{
+		{flag: "", expect: nil},
+		{flag: "instance, securitygroup,instance", expect: []string{"instance", "securitygroup"}},
+		{flag: "instances,users", expect: []string{"instance", "user"}},
+		{flag: "instance,unicorn", expectErr: true},
+		{flag: ",", expectErr: true},
+	}
+	for i, tcase := range tcases {
+		types, err := resolveSyncTypes(tcase.flag)
+		if tcase.expectErr {
+			if err == nil {
+				t.Fatalf("%d: expected error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := types, tcase.expect; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestNextWatchWakeUp(t *testing.T) {
+	now := time.Now()
+	status := &sync.WatchStatus{Services: map[string]*sync.ServiceStatus{
+		"default/eu-west-1/infra": {NextSync: now.Add(5 * time.Minute)},
+		"default/global/access":   {NextSync: now.Add(10 * time.Second)},
+	}}
+	if got, want := nextWatchWakeUp(status, now), 10*time.Second; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	status.Services["default/global/access"].NextSync = now.Add(time.Hour)
+	if got, want := nextWatchWakeUp(status, now), sync.WatchHeartbeat; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	status.Services["default/eu-west-1/infra"].NextSync = now.Add(-time.Minute)
+	if got, want := nextWatchWakeUp(status, now), time.Duration(0); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestParseS3URL(t *testing.T) {
+	tcases := []struct {
+		url            string
+		bucket, prefix string
+		ok             bool
+	}{
+		{url: "s3://team-bucket/awless/inventory", bucket: "team-bucket", prefix: "awless/inventory", ok: true},
+		{url: "s3://team-bucket", bucket: "team-bucket", ok: true},
+		{url: "s3://", ok: false},
+		{url: "git@github.com:team/inventory.git", ok: false},
+		{url: "/tmp/inventory.git", ok: false},
+	}
+	for i, tcase := range tcases {
+		bucket, prefix, ok := parseS3URL(tcase.url)
+		if ok != tcase.ok {
+			t.Fatalf("%d: got %t, want %t", i+1, ok, tcase.ok)
+		}
+		if !ok {
+			continue
+		}
+		if bucket != tcase.bucket || prefix != tcase.prefix {
+			t.Fatalf("%d: got %s, %s, want %s, %s", i+1, bucket, prefix, tcase.bucket, tcase.prefix)
+		}
+	}
+}
+
+func TestSyncEventsTarget(t *testing.T) {
+	defer func(restore string) { syncEventsFlag = restore }(syncEventsFlag)
+	defer func() { config.Config = map[string]interface{}{} }()
+
+	config.Config = map[string]interface{}{"sync.events": "stdout"}
+	if got, want := syncEventsTarget(syncCmd), "stdout"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := syncEventsTarget(runCmd), "stderr"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	config.Config = map[string]interface{}{"sync.events": "/tmp/events.json"}
+	if got, want := syncEventsTarget(runCmd), "/tmp/events.json"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	syncEventsFlag = "stdout"
+	if got, want := syncEventsTarget(syncCmd), "stdout"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
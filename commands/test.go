@@ -0,0 +1,451 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	gosync "sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/env"
+)
+
+const templateTestFileSuffix = ".test.json"
+
+var testRunFlag string
+
+func init() {
+	RootCmd.AddCommand(testCmd)
+	testCmd.Flags().StringVar(&testRunFlag, "run", "", "Only run the tests whose name contains this string")
+}
+
+var testCmd = &cobra.Command{
+	Use:   "test PATH...",
+	Short: "Run offline the tests of templates declared in sidecar test files, against mocked AWS APIs",
+	Long: `Run offline the tests of templates declared in sidecar test files, against mocked AWS APIs.
+
+The tests of a template 'name.aws' are declared in 'name.test.json' in the same directory:
+
+  {
+    "tests": [{
+      "name": "create a vpc with a subnet",
+      "fillers": {"vpc.cidr": "10.0.0.0/16"},
+      "graph": "fixtures/infra.nt",
+      "calls": [
+        {"call": "CreateVpc", "input": {"CidrBlock": "10.0.0.0/16"}, "output": {"Vpc": {"VpcId": "vpc-1"}}},
+        {"call": "CreateSubnet", "output": {"Subnet": {"SubnetId": "subnet-1"}}}
+      ],
+      "results": ["vpc-1", "subnet-1"],
+      "revert": "delete subnet id=subnet-1\ndelete vpc id=vpc-1"
+    }]
+  }
+
+'fillers' fill the holes of the template, 'graph' is a fixture local graph (in N-Triples '.nt' or JSON '.json': [{"type": "subnet", "id": "subnet-1", "properties": {"Name": "my-subnet"}}]) used to resolve aliases.
+Expected AWS 'calls' are checked against the calls made: omit 'input' to not check it, and use 'error' to return an AWS error code instead of the 'output'.
+'results' are the expected results of the commands, 'revert' the expected revert template and 'error' an error expected when running the template.`,
+	Example:          "  awless test mytemplates/\n  awless test create_vpc.aws --run subnet",
+	PersistentPreRun: applyHooks(initLoggerHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("missing PATH arg (directory or template)")
+		}
+
+		var templates []string
+		for _, arg := range args {
+			found, err := findTestedTemplates(arg)
+			exitOn(err)
+			templates = append(templates, found...)
+		}
+		if len(templates) == 0 {
+			logger.Warningf("no templates with a %s sidecar test file found", templateTestFileSuffix)
+			return nil
+		}
+
+		var passed, failed int
+		for _, path := range templates {
+			tests, err := readTemplateTests(path)
+			exitOn(err)
+			for _, test := range tests {
+				if testRunFlag != "" && !strings.Contains(test.Name, testRunFlag) {
+					continue
+				}
+				if err := test.run(path); err != nil {
+					failed++
+					fmt.Printf("%s %s: %s\n", renderRedFn("FAIL"), path, test.Name)
+					fmt.Printf("\t%s\n", strings.Replace(err.Error(), "\n", "\n\t", -1))
+				} else {
+					passed++
+					fmt.Printf("%s   %s: %s\n", renderGreenFn("ok"), path, test.Name)
+				}
+			}
+		}
+
+		fmt.Printf("\n%d passed, %d failed\n", passed, failed)
+		if failed > 0 {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+type templateTestFile struct {
+	Tests []*templateTest `json:"tests"`
+}
+
+type templateTest struct {
+	Name    string             `json:"name"`
+	Region  string             `json:"region"`
+	Fillers map[string]string  `json:"fillers"`
+	Graph   string             `json:"graph"`
+	Calls   []*expectedAWSCall `json:"calls"`
+	Results []string           `json:"results"`
+	Revert  string             `json:"revert"`
+	Error   string             `json:"error"`
+	dir     string
+	graph   *graph.Graph
+	mock    *awsCallsMock
+}
+
+type expectedAWSCall struct {
+	Call   string          `json:"call"`
+	Input  json.RawMessage `json:"input"`
+	Output json.RawMessage `json:"output"`
+	Error  string          `json:"error"`
+	done   bool
+}
+
+func findTestedTemplates(path string) (templates []string, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(p) != FILE_EXT {
+			return nil
+		}
+		if _, serr := os.Stat(testFileOf(p)); serr == nil {
+			templates = append(templates, p)
+		}
+		return nil
+	})
+	return
+}
+
+func testFileOf(templatePath string) string {
+	return strings.TrimSuffix(templatePath, FILE_EXT) + templateTestFileSuffix
+}
+
+func readTemplateTests(templatePath string) ([]*templateTest, error) {
+	content, err := ioutil.ReadFile(testFileOf(templatePath))
+	if err != nil {
+		return nil, err
+	}
+	var file templateTestFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("%s: %s", testFileOf(templatePath), err)
+	}
+	for i, test := range file.Tests {
+		if test.Name == "" {
+			test.Name = fmt.Sprintf("test #%d", i+1)
+		}
+		test.dir = filepath.Dir(templatePath)
+	}
+	return file.Tests, nil
+}
+
+// run compiles and runs the template as `awless run` would, with AWS calls answered by the test
+func (t *templateTest) run(templatePath string) error {
+	content, err := ioutil.ReadFile(templatePath)
+	if err != nil {
+		return err
+	}
+	tpl, err := template.Parse(string(content))
+	if err != nil {
+		return err
+	}
+	if err := t.loadGraph(); err != nil {
+		return err
+	}
+
+	t.mock = &awsCallsMock{expected: t.Calls}
+	region := t.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	sess, err := t.mock.session(region)
+	if err != nil {
+		return err
+	}
+	factory := &awsspec.AWSFactory{Log: logger.DiscardLogger, Sess: sess, Graph: t.graph}
+	previousFactory := awsspec.CommandFactory
+	awsspec.CommandFactory = factory
+	defer func() { awsspec.CommandFactory = previousFactory }()
+
+	cenv := template.NewEnv().WithLookupCommandFunc(func(tokens ...string) interface{} {
+		newCommandFunc := factory.Build(strings.Join(tokens, ""))
+		if newCommandFunc == nil {
+			return nil
+		}
+		return newCommandFunc()
+	}).WithAliasFunc(func(paramPath, alias string) string {
+		return resolveAliasInGraph(t.graph, paramPath, alias)
+	}).WithMissingHolesFunc(func(key string, paramPaths []string, optional bool) string {
+		return t.Fillers[key]
+	}).WithIncludeFunc(includeTemplateFunc(templatePath)).WithLog(logger.DiscardLogger).WithParamsMode(env.REQUIRED_PARAMS_ONLY).Build()
+
+	compiled, cenv, err := template.Compile(tpl, cenv, template.NewRunnerCompileMode)
+	if err != nil {
+		return t.checkError(err)
+	}
+	ran, err := compiled.Run(template.NewRunEnv(cenv))
+	if err == nil && ran.HasErrors() {
+		for _, cmd := range ran.CommandNodesIterator() {
+			if cmd.Err() != nil {
+				err = fmt.Errorf("%s: %s", cmd, cmd.Err())
+				break
+			}
+		}
+	}
+	if err != nil {
+		return t.checkError(err)
+	}
+	if t.Error != "" {
+		return fmt.Errorf("expected error containing '%s', got none", t.Error)
+	}
+
+	if err := t.mock.verify(); err != nil {
+		return err
+	}
+	if len(t.Results) > 0 {
+		var results []string
+		for _, cmd := range ran.CommandNodesIterator() {
+			if res := cmd.Result(); res != nil {
+				results = append(results, fmt.Sprint(res))
+			} else {
+				results = append(results, "")
+			}
+		}
+		if !reflect.DeepEqual(results, t.Results) {
+			return fmt.Errorf("got results %q, want %q", results, t.Results)
+		}
+	}
+	if t.Revert != "" {
+		reverted, err := ran.Revert(cenv.LookupCommandFunc())
+		if err != nil {
+			return fmt.Errorf("cannot revert: %s", err)
+		}
+		if got, want := reverted.String(), strings.TrimSpace(t.Revert); got != want {
+			return fmt.Errorf("got revert\n%s\nwant\n%s", got, want)
+		}
+	}
+	return nil
+}
+
+func (t *templateTest) checkError(err error) error {
+	if t.Error == "" {
+		return err
+	}
+	if !strings.Contains(err.Error(), t.Error) {
+		return fmt.Errorf("got error '%s', want error containing '%s'", err, t.Error)
+	}
+	return nil
+}
+
+func (t *templateTest) loadGraph() error {
+	t.graph = graph.NewGraph()
+	if t.Graph == "" {
+		return nil
+	}
+	path := t.Graph
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(t.dir, path)
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("fixture graph: %s", err)
+	}
+	switch filepath.Ext(path) {
+	case ".json":
+		var resources []struct {
+			Type       string                 `json:"type"`
+			ID         string                 `json:"id"`
+			Properties map[string]interface{} `json:"properties"`
+		}
+		if err := json.Unmarshal(content, &resources); err != nil {
+			return fmt.Errorf("fixture graph %s: %s", path, err)
+		}
+		for _, r := range resources {
+			res := graph.InitResource(r.Type, r.ID)
+			res.SetProperty(properties.ID, r.ID)
+			for k, v := range r.Properties {
+				res.SetProperty(k, v)
+			}
+			if err := t.graph.AddResource(res); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := t.graph.Unmarshal(content); err != nil {
+			return fmt.Errorf("fixture graph %s: %s", path, err)
+		}
+	}
+	return nil
+}
+
+// awsCallsMock answers the calls made with an AWS session with the expected calls of a test,
+// recording the unexpected calls and the inputs that differ from the expected ones
+type awsCallsMock struct {
+	mu       gosync.Mutex
+	expected []*expectedAWSCall
+	errs     []string
+}
+
+func (m *awsCallsMock) session(region string) (*session.Session, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(region),
+		Credentials: credentials.NewStaticCredentials("AKIDTEST", "SECRETTEST", ""),
+		MaxRetries:  aws.Int(0),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sess.Handlers.Send.Clear()
+	sess.Handlers.Send.PushBack(m.send)
+	sess.Handlers.ValidateResponse.Clear()
+	sess.Handlers.Unmarshal.Clear()
+	sess.Handlers.UnmarshalMeta.Clear()
+	sess.Handlers.UnmarshalError.Clear()
+	return sess, nil
+}
+
+func (m *awsCallsMock) send(r *request.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r.HTTPResponse = &http.Response{StatusCode: 200, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewReader(nil))}
+	name := r.Operation.Name
+	var call *expectedAWSCall
+	for _, c := range m.expected {
+		if !c.done && c.Call == name {
+			call = c
+			break
+		}
+	}
+	if call == nil {
+		m.errs = append(m.errs, fmt.Sprintf("unexpected call %s", name))
+		r.Error = awserr.New("UnexpectedCall", fmt.Sprintf("unexpected call %s in test", name), nil)
+		return
+	}
+	call.done = true
+
+	if len(call.Input) > 0 {
+		if err := compareAWSInput(r.Params, call.Input); err != nil {
+			m.errs = append(m.errs, fmt.Sprintf("%s: %s", name, err))
+		}
+	}
+	if call.Error != "" {
+		r.Error = awserr.New(call.Error, fmt.Sprintf("%s returned by test", call.Error), nil)
+		return
+	}
+	if len(call.Output) > 0 && r.Data != nil {
+		if err := json.Unmarshal(call.Output, r.Data); err != nil {
+			r.Error = awserr.New("InvalidOutput", fmt.Sprintf("cannot unmarshal test output of %s: %s", name, err), nil)
+		}
+	}
+}
+
+func (m *awsCallsMock) verify() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	errs := append([]string{}, m.errs...)
+	for _, c := range m.expected {
+		if !c.done {
+			errs = append(errs, fmt.Sprintf("expected call %s not made", c.Call))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func compareAWSInput(input interface{}, expected json.RawMessage) error {
+	b, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	var got, want interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(expected, &want); err != nil {
+		return fmt.Errorf("invalid expected input: %s", err)
+	}
+	got = removeNullValues(got)
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.Marshal(got)
+		return fmt.Errorf("got input %s, want %s", gotJSON, expected)
+	}
+	return nil
+}
+
+// removeNullValues removes the nil fields of AWS inputs marshalled in JSON
+func removeNullValues(i interface{}) interface{} {
+	switch v := i.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{})
+		for k, e := range v {
+			if e != nil {
+				out[k] = removeNullValues(e)
+			}
+		}
+		return out
+	case []interface{}:
+		var out []interface{}
+		for _, e := range v {
+			out = append(out, removeNullValues(e))
+		}
+		return out
+	}
+	return i
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunTemplateTests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awless-template-tests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(name, content string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("infra.aws", "vpc = create vpc cidr={vpc.cidr} name=net\ncreate subnet cidr=10.0.1.0/24 vpc=$vpc\ndelete subnet id=@old-subnet")
+	write("graph.json", `[{"type": "subnet", "id": "subnet-old", "properties": {"Name": "old-subnet"}}]`)
+	write("infra.test.json", `{"tests": [
+{
+  "name": "create network",
+  "fillers": {"vpc.cidr": "10.0.0.0/16"},
+  "graph": "graph.json",
+  "calls": [
+    {"call": "CreateVpc", "input": {"CidrBlock": "10.0.0.0/16"}, "output": {"Vpc": {"VpcId": "vpc-1"}}},
+    {"call": "CreateTags", "input": {"Resources": ["vpc-1"], "Tags": [{"Key": "Name", "Value": "net"}]}},
+    {"call": "CreateSubnet", "input": {"CidrBlock": "10.0.1.0/24", "VpcId": "vpc-1"}, "output": {"Subnet": {"SubnetId": "subnet-1"}}},
+    {"call": "DeleteSubnet", "input": {"SubnetId": "subnet-old"}}
+  ],
+  "results": ["vpc-1", "subnet-1", ""],
+  "revert": "delete subnet id=subnet-1\ndelete vpc id=vpc-1"
+},
+{
+  "name": "wrong input",
+  "fillers": {"vpc.cidr": "10.0.0.0/16"},
+  "graph": "graph.json",
+  "calls": [
+    {"call": "CreateVpc", "input": {"CidrBlock": "10.1.0.0/16"}, "output": {"Vpc": {"VpcId": "vpc-1"}}},
+    {"call": "CreateTags"},
+    {"call": "CreateSubnet", "output": {"Subnet": {"SubnetId": "subnet-1"}}},
+    {"call": "DeleteSubnet"},
+    {"call": "DeleteVpc"}
+  ]
+},
+{
+  "name": "aws error",
+  "fillers": {"vpc.cidr": "10.0.0.0/16"},
+  "graph": "graph.json",
+  "calls": [
+    {"call": "CreateVpc", "error": "VpcLimitExceeded"}
+  ],
+  "error": "VpcLimitExceeded"
+}
+]}`)
+	write("untested.aws", "create vpc cidr=10.0.0.0/16")
+
+	templates, err := findTestedTemplates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(templates), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	tests, err := readTemplateTests(templates[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(tests), 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	if err := tests[0].run(templates[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	err = tests[1].run(templates[0])
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, exp := range []string{`CreateVpc: got input {"CidrBlock":"10.0.0.0/16"}, want {"CidrBlock": "10.1.0.0/16"}`, "expected call DeleteVpc not made"} {
+		if !strings.Contains(err.Error(), exp) {
+			t.Fatalf("got %s, want error containing %s", err, exp)
+		}
+	}
+
+	if err := tests[2].run(templates[0]); err != nil {
+		t.Fatal(err)
+	}
+}
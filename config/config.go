@@ -26,11 +26,21 @@ const (
 	autosyncConfigKey              = "autosync"
 	checkUpgradeFrequencyConfigKey = "upgrade.checkfrequency"
 	schedulerURL                   = "scheduler.url"
+	syncEventsConfigKey            = "sync.events"
+	syncIntervalConfigKey          = "sync.interval"
+	syncRemoteConfigKey            = "sync.remote"
+	syncRemoteEndpointConfigKey    = "sync.remote.endpoint"
+	syncSnapshotsConfigKey         = "sync.snapshots"
+	syncStaleAfterConfigKey        = "sync.staleafter"
 	RegionConfigKey                = "aws.region"
 	ProfileConfigKey               = "aws.profile"
+	templateRequireSignedConfigKey = "template.requiresigned"
 
 	//Config prefix
-	awsCloudPrefix = "aws."
+	awsCloudPrefix                 = "aws."
+	templateConfigPrefix           = "template."
+	TemplateRepoConfigPrefix       = "template.repo."
+	TemplateTrustedKeyConfigPrefix = "template.trustedkey."
 )
 
 var configDefinitions = map[string]*Definition{
@@ -51,6 +61,13 @@ var configDefinitions = map[string]*Definition{
 	"aws.cloudformation.sync":      {help: "Enable/disable sync of CloudFormation service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
 	checkUpgradeFrequencyConfigKey: {help: "Upgrade check frequency (hours); a negative value disables check", defaultValue: "8", parseParamFn: parseInt},
 	schedulerURL:                   {help: "URL used by awless CLI to interact with pre-installed https://github.com/wallix/awless-scheduler", defaultValue: "http://localhost:8082"},
+	syncEventsConfigKey:            {help: "Publish the resource changes of each sync as JSON lines to 'stdout' ('stderr' for the syncs of commands other than `awless sync`), a file path or an http(s) webhook URL (when empty: disabled)"},
+	syncIntervalConfigKey:          {help: "Interval (minutes) between syncs of a service with `awless sync --watch`; per service with aws.<service>.sync.interval", defaultValue: "5", parseParamFn: parseInt},
+	syncRemoteConfigKey:            {help: "Remote store shared with `awless sync push/pull`: a git repository URL or path, or a S3 bucket as s3://<bucket>/<prefix>"},
+	syncRemoteEndpointConfigKey:    {help: "Endpoint of the S3 compatible server (i.e. MinIO) of a s3:// sync remote (when empty: AWS S3)"},
+	syncSnapshotsConfigKey:         {help: "Also store synced data as indexed binary snapshots, faster to load for list and show on large accounts", defaultValue: "false", parseParamFn: parseBool},
+	syncStaleAfterConfigKey:        {help: "Warn when listing or showing locally synced data older than this (minutes); a negative value disables the warning", defaultValue: "60", parseParamFn: parseInt},
+	templateRequireSignedConfigKey: {help: "Refuse to run remote templates not signed by a key of template.trustedkey.<name>", defaultValue: "false", parseParamFn: parseBool},
 }
 
 var defaultsDefinitions = map[string]*Definition{
@@ -256,7 +273,7 @@ func setVolatile(key, value string) (interface{}, *Definition, bool, error) {
 	case defOk:
 		def = defDef
 	default:
-		if strings.Contains(key, awsCloudPrefix) {
+		if strings.Contains(key, awsCloudPrefix) || strings.HasPrefix(key, templateConfigPrefix) {
 			isConf = true
 		}
 	}
@@ -45,6 +45,82 @@ func GetSchedulerURL() string {
 	return ""
 }
 
+// GetSyncEvents returns where the change events of syncs are published, empty when disabled
+func GetSyncEvents() string {
+	if target, ok := Config[syncEventsConfigKey].(string); ok {
+		return target
+	}
+	return ""
+}
+
+// GetSyncInterval returns the interval between syncs of the given service with `awless sync --watch`,
+// set with `awless config set aws.<service>.sync.interval <minutes>` or globally with sync.interval
+func GetSyncInterval(service string) time.Duration {
+	if minutes, ok := Config[fmt.Sprintf("%s%s.sync.interval", awsCloudPrefix, service)].(int); ok && minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	if minutes, ok := Config[syncIntervalConfigKey].(int); ok && minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return 5 * time.Minute
+}
+
+// GetSyncRemote returns the remote store of `awless sync push/pull` and the endpoint
+// of its S3 compatible server, empty when not set
+func GetSyncRemote() (remote, endpoint string) {
+	remote, _ = Config[syncRemoteConfigKey].(string)
+	endpoint, _ = Config[syncRemoteEndpointConfigKey].(string)
+	return
+}
+
+// GetSyncSnapshots returns whether synced data is also stored and loaded as indexed binary snapshots
+func GetSyncSnapshots() bool {
+	snapshots, _ := Config[syncSnapshotsConfigKey].(bool)
+	return snapshots
+}
+
+// GetSyncStaleAfter returns the age after which locally synced data is reported as stale, 0 when disabled
+func GetSyncStaleAfter() time.Duration {
+	if minutes, ok := Config[syncStaleAfterConfigKey].(int); ok {
+		if minutes < 0 {
+			return 0
+		}
+		return time.Duration(minutes) * time.Minute
+	}
+	return time.Hour
+}
+
+// GetTemplateRepositories returns the locations of the template catalogs per name,
+// set with `awless config set template.repo.<name> <location>`
+func GetTemplateRepositories() map[string]string {
+	repos := make(map[string]string)
+	for k, v := range GetConfigWithPrefix(TemplateRepoConfigPrefix) {
+		if name := strings.TrimPrefix(k, TemplateRepoConfigPrefix); name != "" {
+			repos[name] = fmt.Sprint(v)
+		}
+	}
+	return repos
+}
+
+// GetTemplateTrustedKeys returns the public keys trusted to sign templates per name,
+// set with `awless config set template.trustedkey.<name> <key>`
+func GetTemplateTrustedKeys() map[string]string {
+	keys := make(map[string]string)
+	for k, v := range GetConfigWithPrefix(TemplateTrustedKeyConfigPrefix) {
+		if name := strings.TrimPrefix(k, TemplateTrustedKeyConfigPrefix); name != "" {
+			keys[name] = fmt.Sprint(v)
+		}
+	}
+	return keys
+}
+
+func GetTemplateRequireSigned() bool {
+	if required, ok := Config[templateRequireSignedConfigKey].(bool); ok {
+		return required
+	}
+	return false
+}
+
 func GetConfigWithPrefix(prefix string) map[string]interface{} {
 	conf := make(map[string]interface{})
 	for k, v := range Config {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestGetSyncEnabled(t *testing.T) {
@@ -46,3 +47,39 @@ func TestGetSyncEnabled(t *testing.T) {
 		}
 	})
 }
+
+func TestGetTemplateRepositories(t *testing.T) {
+	Config = map[string]interface{}{
+		"aws.region":             "eu-west-1",
+		"template.repo.mine":     "~/templates",
+		"template.repo.company":  "git@github.com:company/templates.git",
+		TemplateRepoConfigPrefix: "invalid",
+	}
+	defer func() { Config = map[string]interface{}{} }()
+
+	expect := map[string]string{"mine": "~/templates", "company": "git@github.com:company/templates.git"}
+	if got, want := GetTemplateRepositories(), expect; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestGetSyncInterval(t *testing.T) {
+	defer func(c map[string]interface{}) { Config = c }(Config)
+
+	Config = map[string]interface{}{}
+	if got, want := GetSyncInterval("infra"), 5*time.Minute; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	Config = map[string]interface{}{syncIntervalConfigKey: 10, "aws.access.sync.interval": 60}
+	if got, want := GetSyncInterval("infra"), 10*time.Minute; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := GetSyncInterval("access"), time.Hour; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	Config = map[string]interface{}{syncStaleAfterConfigKey: -1}
+	if got, want := GetSyncStaleAfter(), time.Duration(0); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/cloud/match"
+	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/graph"
 )
 
@@ -302,6 +303,43 @@ func WithIDsOnly(only bool) optsFn {
 	}
 }
 
+// WithRegionColumn appends a Region column to the columns displayed (i.e. when listing resources of several regions)
+func WithRegionColumn(add bool) optsFn {
+	return func(b *Builder) *Builder {
+		if add {
+			b.appendColumns(StringColumnDefinition{Prop: properties.Region})
+		}
+		return b
+	}
+}
+
+// WithProfileColumns appends Account and Profile columns to the columns displayed (i.e. when listing resources of several profiles)
+func WithProfileColumns(add bool) optsFn {
+	return func(b *Builder) *Builder {
+		if add {
+			b.appendColumns(StringColumnDefinition{Prop: properties.Account}, StringColumnDefinition{Prop: properties.AWSProfile, Friendly: "Profile"})
+		}
+		return b
+	}
+}
+
+func (b *Builder) appendColumns(definitions ...ColumnDefinition) {
+	if len(b.columnDefinitions) == 0 {
+		b.columnDefinitions = DefaultsColumnDefinitions[b.rdfType]
+	}
+	columns := append([]ColumnDefinition{}, b.columnDefinitions...)
+	for _, def := range definitions {
+		var found bool
+		for _, col := range columns {
+			found = found || col.propKey() == def.propKey()
+		}
+		if !found {
+			columns = append(columns, def)
+		}
+	}
+	b.columnDefinitions = columns
+}
+
 func WithSortBy(sortingBy ...string) optsFn {
 	return func(b *Builder) *Builder {
 		indexes, err := resolveSortIndexes(b.columnDefinitions, sortingBy...)
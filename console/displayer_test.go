@@ -657,6 +657,36 @@ func TestFilter(t *testing.T) {
 	})
 }
 
+func TestRegionAndProfileColumns(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Instance("inst_1").Prop(p.Name, "redis").Prop(p.Region, "eu-west-1").Prop(p.Account, "123").Prop(p.AWSProfile, "prod").Build(),
+		resourcetest.Instance("inst_2").Prop(p.Name, "django").Prop(p.Region, "us-east-1").Prop(p.Account, "456").Prop(p.AWSProfile, "dev").Build(),
+	)
+	var w bytes.Buffer
+	displayer, err := BuildOptions(
+		WithRdfType("instance"),
+		WithColumns([]string{"ID", "Name"}),
+		WithFormat("csv"),
+		WithRegionColumn(true),
+		WithProfileColumns(true),
+		WithSortBy("Region"),
+		WithReverseSort(true),
+	).SetSource(g).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	expected := "ID,Name,Region,Account,Profile\n" +
+		"inst_2,django,us-east-1,456,dev\n" +
+		"inst_1,redis,eu-west-1,123,prod\n"
+	if got, want := w.String(), expected; got != want {
+		t.Fatalf("got \n%s\n\nwant\n\n%s\n", got, want)
+	}
+}
+
 func TestCompareInterface(t *testing.T) {
 	if got, want := valueLowerOrEqual(interface{}(1), interface{}(4)), true; got != want {
 		t.Fatalf("got %t want %t", got, want)
@@ -2,12 +2,16 @@ package fetch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
 	"github.com/wallix/awless/graph"
 )
 
+// ErrDisabled is returned by the fetch funcs of resource types whose sync is disabled
+var ErrDisabled = errors.New("sync disabled")
+
 type Fetcher interface {
 	Cache
 	Fetch(context.Context) (*graph.Graph, error)
@@ -70,8 +74,14 @@ func (f *fetcher) Fetch(ctx context.Context) (*graph.Graph, error) {
 
 	ferr := new(Error)
 	for res := range results {
-		if err := res.Err; err != nil {
-			ferr.Add(err)
+		switch res.Err {
+		case nil:
+			gph.SetFetchStatus(res.ResourceType, &graph.FetchStatus{})
+		case ErrDisabled:
+			gph.SetFetchStatus(res.ResourceType, &graph.FetchStatus{Disabled: true})
+		default:
+			gph.SetFetchStatus(res.ResourceType, &graph.FetchStatus{Err: res.Err})
+			ferr.Add(res.Err)
 		}
 		gph.AddResource(res.Resources...)
 	}
@@ -92,8 +102,14 @@ func (f *fetcher) FetchByType(ctx context.Context, resourceType string) (*graph.
 	gph := graph.NewGraph()
 	select {
 	case res := <-results:
-		if err := res.Err; err != nil {
-			return gph, err
+		switch res.Err {
+		case nil:
+			gph.SetFetchStatus(resourceType, &graph.FetchStatus{})
+		case ErrDisabled:
+			gph.SetFetchStatus(resourceType, &graph.FetchStatus{Disabled: true})
+		default:
+			gph.SetFetchStatus(resourceType, &graph.FetchStatus{Err: res.Err})
+			return gph, res.Err
 		}
 		for _, r := range res.Resources {
 			gph.AddResource(r)
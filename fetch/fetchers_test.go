@@ -59,6 +59,9 @@ func TestFetcher(t *testing.T) {
 		if res, _ := gph.GetResource("instance", "inst_2"); res == nil {
 			t.Fatalf("got unexpected resource: %v", res)
 		}
+		if st, ok := gph.FetchStatuses()["instance"]; !ok || st.Err != nil || st.Disabled {
+			t.Fatalf("got unexpected fetch status: %#v", st)
+		}
 	})
 
 	t.Run("fetch unexisting type", func(t *testing.T) {
@@ -69,6 +72,9 @@ func TestFetcher(t *testing.T) {
 		if gph == nil {
 			t.Fatal("expected non nil empty graph")
 		}
+		if st, ok := gph.FetchStatuses()["unexisting"]; !ok || st.Err == nil {
+			t.Fatalf("got unexpected fetch status: %#v", st)
+		}
 	})
 
 	t.Run("fetch when fetchfunc returns nils", func(t *testing.T) {
@@ -103,4 +109,36 @@ func TestFetcher(t *testing.T) {
 			t.Fatal("expected non nil empty graph")
 		}
 	})
+	t.Run("fetch statuses per type", func(t *testing.T) {
+		f := fetch.NewFetcher(
+			fetch.Funcs{
+				"instance": func(context.Context, fetch.Cache) ([]*graph.Resource, interface{}, error) { return instances, nil, nil },
+				"subnet": func(context.Context, fetch.Cache) ([]*graph.Resource, interface{}, error) {
+					return nil, nil, errors.New("fetch func error")
+				},
+				"s3object": func(context.Context, fetch.Cache) ([]*graph.Resource, interface{}, error) {
+					return nil, nil, fetch.ErrDisabled
+				},
+			},
+		)
+
+		gph, err := f.Fetch(context.Background())
+		if err == nil || err.Error() != "fetch func error" {
+			t.Fatalf("expected only subnet fetch error, got %v", err)
+		}
+		statuses := gph.FetchStatuses()
+		if st := statuses["instance"]; st == nil || st.Err != nil || st.Disabled {
+			t.Fatalf("unexpected instance status %#v", st)
+		}
+		if st := statuses["subnet"]; st == nil || st.Err == nil {
+			t.Fatalf("unexpected subnet status %#v", st)
+		}
+		if st := statuses["s3object"]; st == nil || !st.Disabled {
+			t.Fatalf("unexpected s3object status %#v", st)
+		}
+
+		if _, err = f.FetchByType(context.Background(), "s3object"); err != nil {
+			t.Fatalf("expected no error fetching disabled type, got %s", err)
+		}
+	})
 }
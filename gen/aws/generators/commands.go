@@ -46,11 +46,30 @@ func loadCommandStructs() map[string]cmdData {
 			ast.Walk(finder, f)
 		}
 	}
+	for name, cmd := range finder.result {
+		cmd.HasRevert = finder.reverters[name]
+		finder.result[name] = cmd
+	}
 	return finder.result
 }
 
+func reportCommandsWithoutRevert(cmds map[string]cmdData) {
+	var missing []string
+	for _, cmd := range cmds {
+		if !cmd.HasRevert && cmd.Action != "check" {
+			missing = append(missing, fmt.Sprintf("%s %s", cmd.Action, cmd.Entity))
+		}
+	}
+	sort.Strings(missing)
+	fmt.Printf("%d commands have no revert defined:\n", len(missing))
+	for _, m := range missing {
+		fmt.Printf("\t%s\n", m)
+	}
+}
+
 func generateCommands() {
 	cmdsData := loadCommandStructs()
+	reportCommandsWithoutRevert(cmdsData)
 	templ, err := template.New("cmdRuns").Funcs(
 		template.FuncMap{
 			"ApiToInterface": aws.ApiToInterface,
@@ -112,6 +131,7 @@ type cmdData struct {
 	HasRequiredParams                        bool
 	HasDryRun                                bool
 	GenDryRun                                bool
+	HasRevert                                bool
 }
 
 type templateParam struct {
@@ -121,13 +141,24 @@ type templateParam struct {
 }
 
 type findStructs struct {
-	result map[string]cmdData
+	result    map[string]cmdData
+	reverters map[string]bool
 }
 
 func (v *findStructs) Visit(node ast.Node) (w ast.Visitor) {
 	if v.result == nil {
 		v.result = make(map[string]cmdData)
 	}
+	if v.reverters == nil {
+		v.reverters = make(map[string]bool)
+	}
+	if fn, ok := node.(*ast.FuncDecl); ok && (fn.Name.Name == "Revert" || fn.Name.Name == "RevertSnapshot") && fn.Recv != nil && len(fn.Recv.List) == 1 {
+		if star, isStar := fn.Recv.List[0].Type.(*ast.StarExpr); isStar {
+			if ident, isIdent := star.X.(*ast.Ident); isIdent {
+				v.reverters[ident.Name] = true
+			}
+		}
+	}
 	if typ, ok := node.(*ast.TypeSpec); ok {
 		if s, isStruct := typ.Type.(*ast.StructType); isStruct {
 			var cmd *cmdData
@@ -355,7 +386,7 @@ func (cmd *{{ $cmdName }}) run(renv env.Running, params map[string]interface{})
 			case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
 				renv.Log().ExtraVerbosef("dry run: {{ $tag.API }}.{{ $tag.Call }} call took %s", time.Since(start))
 				renv.Log().Verbose("dry run: {{ $tag.Action }} {{ $tag.Entity }} ok")
-				return fakeDryRunId("{{ $tag.Entity }}"), nil
+				return fakeDryRunId(renv, "{{ $tag.Entity }}"), nil
 			}
 		}
 
@@ -364,13 +395,18 @@ func (cmd *{{ $cmdName }}) run(renv env.Running, params map[string]interface{})
 	{{- end }}
 {{- else }}
 func (cmd *{{ $cmdName }}) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("{{ $tag.Entity }}"), nil
+	simulate(renv, cmd, params)
+	return fakeDryRunId(renv, "{{ $tag.Entity }}"), nil
 }
 {{- end }}
 
 func (cmd *{{ $cmdName }}) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
+
+func (cmd *{{ $cmdName }}) ParamType(key string) (string, bool) {
+	return structParamType(cmd, key)
+}
 {{ end }}
 `
 
@@ -417,6 +453,10 @@ type AWSFactory struct {
 }
 
 func (f *AWSFactory) Build(key string) func() interface{} {
+	return f.buildGeneric(key, f.build(key))
+}
+
+func (f *AWSFactory) build(key string) func() interface{} {
 	switch key {
 	{{- range $cmdName, $tag := . }}
 	case "{{ $tag.Action }}{{ $tag.Entity }}":
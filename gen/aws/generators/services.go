@@ -219,6 +219,13 @@ func (s *{{ Title $service.Name }}) Fetch(ctx context.Context) (cloud.GraphAPI,
 			allErrors.Add(ee)
 		}
 	}
+	for _, status := range gph.FetchStatuses() {
+		for _, e := range *fetch.WrapError(status.Err) {
+			if ee, ok := e.(awserr.RequestFailure); ok && ee.Message() == accessDenied {
+				status.Err = cloud.ErrFetchAccessDenied
+			}
+		}
+	}
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -274,7 +281,15 @@ func (s *{{ Title $service.Name }}) Fetch(ctx context.Context) (cloud.GraphAPI,
 
 func (s *{{ Title $service.Name }}) FetchByType(ctx context.Context, t string) (cloud.GraphAPI, error) {
 	defer s.fetcher.Reset()
-  return s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	gph, err := s.fetcher.FetchByType(context.WithValue(ctx, "region", s.region), t)
+	if err != nil {
+		return gph, err
+	}
+	objects, err := s.fetcher.Get(t + "_objects")
+	if err != nil {
+		return gph, err
+	}
+	return gph, addParentsOfType(gph, objects, s.region, t)
 }
 
 func (s *{{ Title $service.Name }}) IsSyncDisabled() bool {
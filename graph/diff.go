@@ -17,13 +17,24 @@ limitations under the License.
 package graph
 
 import (
+	"reflect"
+	"sort"
+
 	"github.com/wallix/awless/cloud/rdf"
 	tstore "github.com/wallix/triplestore"
 )
 
 var (
 	DefaultDiffer = &hierarchicDiffer{rdf.ParentOf}
-	MetaPredicate = "meta"
+	// ResourceDiffer compares all the resources of two graphs by type and id, whatever their hierarchy (the root is ignored)
+	ResourceDiffer = &resourceDiffer{}
+	MetaPredicate  = "meta"
+)
+
+const (
+	ResourceCreated         = "created"
+	ResourceDeleted         = "deleted"
+	ResourcePropertyChanged = "property-changed"
 )
 
 const (
@@ -40,6 +51,15 @@ type Diff struct {
 	toGraph     *Graph
 	mergedGraph *Graph
 	hasDiffs    bool
+	changes     []*ResourceChange
+}
+
+// ResourceChange is a created, deleted or modified resource of a diff computed with the ResourceDiffer
+type ResourceChange struct {
+	Kind string
+	// Resource is the new resource, or the deleted one
+	Resource    *Resource
+	ChangedKeys []string
 }
 
 func NewDiff(fromG, toG *Graph) *Diff {
@@ -75,6 +95,81 @@ func (d *Diff) HasDiff() bool {
 	return d.hasDiffs
 }
 
+// ResourceChanges returns the changes of the resources, sorted by type and id (only computed by the ResourceDiffer)
+func (d *Diff) ResourceChanges() []*ResourceChange {
+	return d.changes
+}
+
+type resourceDiffer struct{}
+
+func (d *resourceDiffer) Run(root string, from *Graph, to *Graph) (*Diff, error) {
+	diff := &Diff{fromGraph: from, toGraph: to}
+
+	fromResources, err := resourcesByTypeAndID(from)
+	if err != nil {
+		return diff, err
+	}
+	toResources, err := resourcesByTypeAndID(to)
+	if err != nil {
+		return diff, err
+	}
+
+	for key, res := range toResources {
+		old, ok := fromResources[key]
+		if !ok {
+			diff.changes = append(diff.changes, &ResourceChange{Kind: ResourceCreated, Resource: res})
+		} else if keys := changedKeys(old.properties, res.properties); len(keys) > 0 {
+			diff.changes = append(diff.changes, &ResourceChange{Kind: ResourcePropertyChanged, Resource: res, ChangedKeys: keys})
+		}
+	}
+	for key, res := range fromResources {
+		if _, ok := toResources[key]; !ok {
+			diff.changes = append(diff.changes, &ResourceChange{Kind: ResourceDeleted, Resource: res})
+		}
+	}
+	sort.Slice(diff.changes, func(i, j int) bool {
+		if ti, tj := diff.changes[i].Resource.Type(), diff.changes[j].Resource.Type(); ti != tj {
+			return ti < tj
+		}
+		return diff.changes[i].Resource.Id() < diff.changes[j].Resource.Id()
+	})
+	diff.hasDiffs = len(diff.changes) > 0
+
+	return diff, nil
+}
+
+func resourcesByTypeAndID(g *Graph) (map[string]*Resource, error) {
+	snap := g.store.Snapshot()
+	all := make(map[string]*Resource)
+	for _, t := range snap.WithPredicate(rdf.RdfType) {
+		typ, err := unmarshalResourceType(t.Object())
+		if err != nil {
+			return all, err
+		}
+		res := InitResource(typ, t.Subject())
+		if err := res.unmarshalFullRdf(snap); err != nil {
+			return all, err
+		}
+		all[typ+"/"+res.Id()] = res
+	}
+	return all, nil
+}
+
+func changedKeys(from, to map[string]interface{}) (keys []string) {
+	for k, v := range to {
+		if !reflect.DeepEqual(from[k], v) {
+			keys = append(keys, k)
+		}
+	}
+	for k := range from {
+		if _, ok := to[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return
+}
+
 type hierarchicDiffer struct {
 	predicate string
 }
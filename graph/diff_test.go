@@ -0,0 +1,55 @@
+package graph_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestResourceDiffer(t *testing.T) {
+	from := graph.NewGraph()
+	from.AddResource(
+		resourcetest.Instance("inst_1").Prop("State", "running").Prop("Type", "t2.micro").Build(),
+		resourcetest.Instance("inst_2").Prop("State", "running").Build(),
+		resourcetest.Subnet("sub_1").Prop("Name", "private").Build(),
+		resourcetest.User("usr_1").Build(),
+	)
+	to := graph.NewGraph()
+	to.AddResource(
+		resourcetest.Instance("inst_1").Prop("State", "stopped").Prop("Name", "redis").Build(),
+		resourcetest.Instance("inst_3").Build(),
+		resourcetest.Subnet("sub_1").Prop("Name", "private").Build(),
+		resourcetest.User("usr_1").Build(),
+	)
+
+	diff, err := graph.ResourceDiffer.Run("", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.HasDiff() {
+		t.Fatal("expected diff")
+	}
+	var got []string
+	for _, c := range diff.ResourceChanges() {
+		got = append(got, fmt.Sprintf("%s %s %s %v", c.Kind, c.Resource.Type(), c.Resource.Id(), c.ChangedKeys))
+	}
+	expected := []string{
+		"property-changed instance inst_1 [Name State Type]",
+		"deleted instance inst_2 []",
+		"created instance inst_3 []",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("got %q, want %q", got, expected)
+	}
+
+	diff, err = graph.ResourceDiffer.Run("", to, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.HasDiff() || len(diff.ResourceChanges()) != 0 {
+		t.Fatalf("expected no diff, got %d changes", len(diff.ResourceChanges()))
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/cloud/rdf"
@@ -29,10 +30,19 @@ import (
 
 type Graph struct {
 	store tstore.Source
+
+	fetchMu sync.Mutex
+	fetched map[string]*FetchStatus
+}
+
+// FetchStatus is the outcome of the fetch of a resource type into a graph
+type FetchStatus struct {
+	Err      error
+	Disabled bool
 }
 
 func NewGraph() *Graph {
-	return &Graph{tstore.NewSource()}
+	return &Graph{store: tstore.NewSource(), fetched: make(map[string]*FetchStatus)}
 }
 
 func NewGraphFromFile(filepath string) (*Graph, error) {
@@ -45,6 +55,24 @@ func NewGraphFromFile(filepath string) (*Graph, error) {
 	return g, err
 }
 
+// SetFetchStatus records the outcome of the fetch of a resource type into this graph
+func (g *Graph) SetFetchStatus(resourceType string, status *FetchStatus) {
+	g.fetchMu.Lock()
+	defer g.fetchMu.Unlock()
+	g.fetched[resourceType] = status
+}
+
+// FetchStatuses returns the fetch outcome per resource type, empty when the graph was not fetched
+func (g *Graph) FetchStatuses() map[string]*FetchStatus {
+	g.fetchMu.Lock()
+	defer g.fetchMu.Unlock()
+	statuses := make(map[string]*FetchStatus)
+	for t, st := range g.fetched {
+		statuses[t] = st
+	}
+	return statuses
+}
+
 func (g *Graph) AsRDFGraphSnaphot() tstore.RDFGraph {
 	return g.store.Snapshot()
 }
@@ -82,6 +110,65 @@ func (g *Graph) AddGraph(other *Graph) {
 	g.store.Add(other.store.CopyTriples()...)
 }
 
+// ReplaceResources replaces the resources of the given types, with their relations, by those of the other graph
+// (i.e. resources of these types fetched alone). Other relations of the replaced resources still existing are kept,
+// unless the other graph has relations of the same kind (i.e. predicate and types), as they are then built by this graph
+func (g *Graph) ReplaceResources(other *Graph, types ...string) {
+	snap, otherSnap := g.store.Snapshot(), other.store.Snapshot()
+	typeOf := func(id string) string {
+		for _, s := range []tstore.RDFGraph{otherSnap, snap} {
+			if typ, err := resolveResourceType(s, id); err == nil {
+				return typ
+			}
+		}
+		return ""
+	}
+	relationKind := func(t tstore.Triple) (string, bool) {
+		if t.Predicate() != rdf.ParentOf && t.Predicate() != rdf.ApplyOn {
+			return "", false
+		}
+		obj, ok := t.Object().Resource()
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("%s %s %s", typeOf(t.Subject()), t.Predicate(), typeOf(obj)), true
+	}
+
+	replaced := make(map[string]bool)
+	for _, typ := range types {
+		for _, t := range snap.WithPredObj(rdf.RdfType, tstore.Resource(namespacedResourceType(typ))) {
+			replaced[t.Subject()] = true
+		}
+	}
+	existing := func(id string) bool {
+		return len(otherSnap.WithSubjPred(id, rdf.RdfType)) > 0
+	}
+	builtKinds := make(map[string]bool)
+	for _, t := range otherSnap.Triples() {
+		if kind, ok := relationKind(t); ok {
+			builtKinds[kind] = true
+		}
+	}
+
+	var removed []tstore.Triple
+	for id := range replaced {
+		for _, t := range snap.WithSubject(id) {
+			kind, isRelation := relationKind(t)
+			if obj, _ := t.Object().Resource(); !isRelation || replaced[obj] || !existing(id) || builtKinds[kind] {
+				removed = append(removed, t)
+			}
+		}
+		for _, t := range snap.WithObject(tstore.Resource(id)) {
+			kind, isRelation := relationKind(t)
+			if isRelation && (replaced[t.Subject()] || !existing(id) || builtKinds[kind]) {
+				removed = append(removed, t)
+			}
+		}
+	}
+	g.store.Remove(removed...)
+	g.AddGraph(other)
+}
+
 func (g *Graph) AddParentRelation(parent, child *Resource) error {
 	return g.addRelation(parent, child, rdf.ParentOf)
 }
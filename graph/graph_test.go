@@ -371,3 +371,60 @@ func TestResourceChildrenAndSiblings(t *testing.T) {
 		}
 	})
 }
+
+func TestReplaceResources(t *testing.T) {
+	g := NewGraph()
+	vpc, sub1, sub2 := InitResource("vpc", "vpc_1"), InitResource("subnet", "sub_1"), InitResource("subnet", "sub_2")
+	sg := InitResource("securitygroup", "sg_1")
+	inst1, inst2 := InitResource("instance", "inst_1"), InitResource("instance", "inst_2")
+	inst1.SetProperty("Name", "old")
+	g.AddResource(vpc, sub1, sub2, sg, inst1, inst2)
+	g.AddParentRelation(vpc, sub1)
+	g.AddParentRelation(vpc, sub2)
+	g.AddParentRelation(sub1, inst1)
+	g.AddParentRelation(sub1, inst2)
+	g.AddAppliesOnRelation(sg, inst1)
+	g.AddAppliesOnRelation(sg, inst2)
+
+	fetched := NewGraph()
+	newInst1, inst3 := InitResource("instance", "inst_1"), InitResource("instance", "inst_3")
+	newInst1.SetProperty("Name", "new")
+	fetched.AddResource(newInst1, inst3)
+	fetched.AddParentRelation(InitResource("subnet", "sub_2"), newInst1)
+	fetched.AddParentRelation(InitResource("subnet", "sub_1"), inst3)
+
+	g.ReplaceResources(fetched, "instance")
+
+	instances, err := g.GetAllResources("instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := Resources(instances).Map(func(r *Resource) string { return r.Id() }), []string{"inst_1", "inst_3"}; !reflect.DeepEqual(sortStrings(got), want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	snap := g.store.Snapshot()
+	expected := map[tstore.Triple]bool{
+		tstore.SubjPred("sub_2", rdf.ParentOf).Resource("inst_1"):    true,
+		tstore.SubjPred("sub_1", rdf.ParentOf).Resource("inst_3"):    true,
+		tstore.SubjPred("sg_1", rdf.ApplyOn).Resource("inst_1"):      true,
+		tstore.SubjPred("vpc_1", rdf.ParentOf).Resource("sub_1"):     true,
+		tstore.SubjPred("sub_1", rdf.ParentOf).Resource("inst_1"):    false,
+		tstore.SubjPred("sub_1", rdf.ParentOf).Resource("inst_2"):    false,
+		tstore.SubjPred("sg_1", rdf.ApplyOn).Resource("inst_2"):      false,
+		tstore.SubjPred("inst_1", "cloud:name").StringLiteral("old"): false,
+		tstore.SubjPred("inst_1", "cloud:name").StringLiteral("new"): true,
+	}
+	for triple, want := range expected {
+		if got := snap.Contains(triple); got != want {
+			t.Fatalf("%s: got %t, want %t", triple, got, want)
+		}
+	}
+	if got := len(snap.WithSubject("inst_2")); got != 0 {
+		t.Fatalf("got %d triples of deleted instance, want 0", got)
+	}
+}
+
+func sortStrings(s []string) []string {
+	sort.Strings(s)
+	return s
+}
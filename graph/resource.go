@@ -141,6 +141,28 @@ func (res *Resource) AddRelation(typ string, rel *Resource) {
 	res.relations[typ] = append(res.relations[typ], rel)
 }
 
+// CopyWithId returns a copy of the resource identified by id in graphs, keeping its properties (ID included),
+// i.e. to merge in one graph resources of different accounts or regions sharing the same id
+func (res *Resource) CopyWithId(id string) *Resource {
+	cpy := &Resource{
+		id:         id,
+		kind:       res.kind,
+		properties: make(map[string]interface{}),
+		meta:       make(map[string]interface{}),
+		relations:  make(map[string][]*Resource),
+	}
+	for k, v := range res.properties {
+		cpy.properties[k] = v
+	}
+	for k, v := range res.meta {
+		cpy.meta[k] = v
+	}
+	for k, v := range res.relations {
+		cpy.relations[k] = v
+	}
+	return cpy
+}
+
 // Compare only the id and type of the resources (no properties nor meta)
 func (res *Resource) Same(other cloud.Resource) bool {
 	if res == nil && other == nil {
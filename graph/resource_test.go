@@ -439,3 +439,19 @@ func BenchmarkRdfUnmarshaling(b *testing.B) {
 		}
 	})
 }
+
+func TestCopyWithId(t *testing.T) {
+	res := InitResource("instance", "inst_1")
+	res.SetProperty("Name", "web")
+	cpy := res.CopyWithId("prod/eu-west-1/inst_1")
+	if got, want := cpy.Id(), "prod/eu-west-1/inst_1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := cpy.Properties(), map[string]interface{}{"ID": "inst_1", "Name": "web"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	cpy.SetProperty("Name", "db")
+	if name, _ := res.Property("Name"); name != "web" {
+		t.Fatalf("expected original resource unchanged, got %s", name)
+	}
+}
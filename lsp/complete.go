@@ -0,0 +1,316 @@
+package lsp
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/aws/doc"
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/params"
+)
+
+var (
+	declarationPrefixRegex = regexp.MustCompile(`^\s*[a-zA-Z0-9-_.]+\s*=\s*`)
+	declarationRegex       = regexp.MustCompile(`(?m)^\s*([a-zA-Z0-9-_.]+)\s*=`)
+)
+
+// statementLine holds the tokens of a template line, ignoring a variable declaration
+type statementLine struct {
+	tokens []string
+	// starts of the tokens in the line
+	offsets []int
+}
+
+func parseStatementLine(line string) (st statementLine) {
+	runes := []rune(line)
+	start := 0
+	if loc := declarationPrefixRegex.FindStringIndex(line); loc != nil {
+		start = len([]rune(line[:loc[1]]))
+	}
+	if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") {
+		return
+	}
+	for i := start; i < len(runes); {
+		for i < len(runes) && isSpace(runes[i]) {
+			i++
+		}
+		if i >= len(runes) {
+			break
+		}
+		begin := i
+		for i < len(runes) && !isSpace(runes[i]) {
+			i++
+		}
+		st.tokens = append(st.tokens, string(runes[begin:i]))
+		st.offsets = append(st.offsets, begin)
+	}
+	return
+}
+
+func (st statementLine) definition() (awsspec.Definition, bool) {
+	if len(st.tokens) < 2 {
+		return awsspec.Definition{}, false
+	}
+	return awsspec.AWSLookupDefinitions(st.tokens[0] + st.tokens[1])
+}
+
+// tokenAt returns the index of the token at the char, or -1
+func (st statementLine) tokenAt(char int) int {
+	for i, off := range st.offsets {
+		if char >= off && char <= off+len([]rune(st.tokens[i])) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) complete(text string, pos Position) []CompletionItem {
+	items := []CompletionItem{}
+	line := []rune(documentLine(text, pos.Line))
+	if pos.Character > len(line) {
+		pos.Character = len(line)
+	}
+	before := string(line[:pos.Character])
+	if trimmed := strings.TrimSpace(before); strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") {
+		return items
+	}
+
+	st := parseStatementLine(before)
+	index, word := len(st.tokens), ""
+	if l := len(st.tokens); l > 0 && strings.HasSuffix(before, st.tokens[l-1]) {
+		index, word = l-1, st.tokens[l-1]
+	}
+
+	switch index {
+	case 0:
+		for _, action := range definedActions() {
+			if strings.HasPrefix(action, word) {
+				items = append(items, CompletionItem{Label: action, Kind: keywordCompletion})
+			}
+		}
+		return items
+	case 1:
+		for _, def := range definitionsOfAction(st.tokens[0]) {
+			if strings.HasPrefix(def.Entity, word) {
+				items = append(items, CompletionItem{
+					Label:         def.Entity,
+					Kind:          classCompletion,
+					Detail:        fmt.Sprintf("%s %s", def.Action, def.Entity),
+					Documentation: awsdoc.AwlessCommandDefinitionsDoc(def.Action, def.Entity, ""),
+				})
+			}
+		}
+		return items
+	}
+
+	def, ok := st.definition()
+	if !ok {
+		return items
+	}
+
+	if eq := strings.Index(word, "="); eq > -1 {
+		return s.completeValue(text, def, word[:eq], word[eq+1:])
+	}
+
+	set := make(map[string]bool)
+	for _, tok := range st.tokens[2:] {
+		if eq := strings.Index(tok, "="); eq > 0 {
+			set[tok[:eq]] = true
+		}
+	}
+	required, optionals, suggested := params.List(def.Params)
+	add := func(keys []string, detail string) {
+		for _, key := range keys {
+			if set[key] || !strings.HasPrefix(key, word) {
+				continue
+			}
+			set[key] = true
+			doc, _ := awsdoc.TemplateParamsDocWithEnums(def.Action, def.Entity, key)
+			items = append(items, CompletionItem{Label: key, Kind: propertyCompletion, Detail: detail, Documentation: doc, InsertText: key + "="})
+		}
+	}
+	add(required, "required")
+	add(suggested, "suggested")
+	add(optionals, "optional")
+	return items
+}
+
+func (s *Server) completeValue(text string, def awsspec.Definition, key, value string) []CompletionItem {
+	items := []CompletionItem{}
+	if i := strings.LastIndex(value, ","); i > -1 {
+		value = value[i+1:]
+	}
+	value = strings.TrimLeft(value, "[")
+
+	switch {
+	case strings.HasPrefix(value, "$"):
+		for _, match := range declarationRegex.FindAllStringSubmatch(text, -1) {
+			if strings.HasPrefix(match[1], value[1:]) {
+				items = append(items, CompletionItem{Label: "$" + match[1], Kind: variableCompletion})
+			}
+		}
+		return uniqueItems(items)
+	case strings.HasPrefix(value, "@"):
+		g := s.loadGraph()
+		resType, _ := paramResourceType(def, key)
+		if g == nil || resType == "" {
+			return items
+		}
+		resources, _ := g.Find(cloud.NewQuery(resType))
+		for _, res := range resources {
+			if name, ok := res.Properties()[properties.Name].(string); ok && name != "" && strings.HasPrefix(name, value[1:]) {
+				items = append(items, CompletionItem{Label: "@" + quoteValue(name), Kind: referenceCompletion, Detail: res.Id()})
+			}
+		}
+		return uniqueItems(items)
+	}
+
+	paramPath := fmt.Sprintf("%s.%s.%s", def.Action, def.Entity, key)
+	for _, enum := range awsdoc.EnumDoc[paramPath] {
+		if enum = strings.TrimSpace(enum); enum != "" && strings.HasPrefix(enum, value) {
+			items = append(items, CompletionItem{Label: quoteValue(enum), Kind: valueCompletion})
+		}
+	}
+
+	g := s.loadGraph()
+	resType, prop := paramResourceType(def, key)
+	if g == nil || resType == "" {
+		return uniqueItems(items)
+	}
+	resources, _ := g.Find(cloud.NewQuery(resType))
+	for _, res := range resources {
+		val := res.Id()
+		if prop != properties.ID {
+			v, ok := res.Properties()[prop]
+			if !ok {
+				continue
+			}
+			val = fmt.Sprint(v)
+		}
+		if !strings.HasPrefix(val, value) {
+			continue
+		}
+		name, _ := res.Properties()[properties.Name].(string)
+		items = append(items, CompletionItem{Label: quoteValue(val), Kind: valueCompletion, Detail: strings.TrimSpace(resType + " " + name)})
+	}
+	return uniqueItems(items)
+}
+
+func (s *Server) hover(text string, pos Position) *Hover {
+	line := documentLine(text, pos.Line)
+	st := parseStatementLine(line)
+	index := st.tokenAt(pos.Character)
+	if index < 0 {
+		return nil
+	}
+	tokenRange := &Range{
+		Start: Position{Line: pos.Line, Character: st.offsets[index]},
+		End:   Position{Line: pos.Line, Character: st.offsets[index] + len([]rune(st.tokens[index]))},
+	}
+
+	def, ok := st.definition()
+	if !ok {
+		return nil
+	}
+
+	if index < 2 {
+		var buff bytes.Buffer
+		fmt.Fprintf(&buff, "**%s %s**", def.Action, def.Entity)
+		if doc := awsdoc.AwlessCommandDefinitionsDoc(def.Action, def.Entity, ""); doc != "" {
+			fmt.Fprintf(&buff, "\n\n%s", doc)
+		}
+		required, optionals, suggested := params.List(def.Params)
+		if len(required) > 0 {
+			fmt.Fprintf(&buff, "\n\nRequired params: %s", strings.Join(required, ", "))
+		}
+		if extra := append(suggested, optionals...); len(extra) > 0 {
+			fmt.Fprintf(&buff, "\n\nExtra params: %s", strings.Join(extra, ", "))
+		}
+		if examples := awsdoc.AwlessExamplesDoc(def.Action, def.Entity); examples != "" {
+			fmt.Fprintf(&buff, "\n\n```\n%s\n```", examples)
+		}
+		return &Hover{Contents: MarkupContent{Kind: "markdown", Value: buff.String()}, Range: tokenRange}
+	}
+
+	key := st.tokens[index]
+	if eq := strings.Index(key, "="); eq > -1 {
+		if pos.Character > st.offsets[index]+len([]rune(key[:eq])) {
+			return nil
+		}
+		key = key[:eq]
+	}
+	doc, ok := awsdoc.TemplateParamsDocWithEnums(def.Action, def.Entity, key)
+	if !ok {
+		return nil
+	}
+	return &Hover{Contents: MarkupContent{Kind: "markdown", Value: fmt.Sprintf("**%s** (%s %s)\n\n%s", key, def.Action, def.Entity, doc)}, Range: tokenRange}
+}
+
+// paramResourceType guesses the type of the cloud resources, and their property, a param refers to
+func paramResourceType(def awsspec.Definition, key string) (string, string) {
+	if typed, ok := awsdoc.ParamTypeDoc[fmt.Sprintf("%s.%s.%s", def.Action, def.Entity, key)]; ok {
+		return typed.ResourceType, typed.PropertyName
+	}
+	if key == "id" || key == "ids" {
+		return def.Entity, properties.ID
+	}
+	singular := cloud.SingularizeResource(key)
+	for _, typ := range awsservices.ResourceTypes {
+		if typ == singular {
+			return typ, properties.ID
+		}
+	}
+	return "", ""
+}
+
+func definedActions() (actions []string) {
+	unique := make(map[string]bool)
+	for _, def := range awsspec.AWSTemplatesDefinitions {
+		if !unique[def.Action] {
+			unique[def.Action] = true
+			actions = append(actions, def.Action)
+		}
+	}
+	sort.Strings(actions)
+	return
+}
+
+func definitionsOfAction(action string) (defs []awsspec.Definition) {
+	for _, def := range awsspec.AWSTemplatesDefinitions {
+		if def.Action == action {
+			defs = append(defs, def)
+		}
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Entity < defs[j].Entity })
+	return
+}
+
+func quoteValue(s string) string {
+	if template.MatchStringParamValue(s) {
+		return s
+	}
+	return "'" + s + "'"
+}
+
+func uniqueItems(items []CompletionItem) []CompletionItem {
+	unique := make(map[string]bool)
+	var out []CompletionItem
+	for _, item := range items {
+		if !unique[item.Label] {
+			unique[item.Label] = true
+			out = append(out, item)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Label < out[j].Label })
+	if out == nil {
+		return []CompletionItem{}
+	}
+	return out
+}
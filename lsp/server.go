@@ -0,0 +1,252 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/env"
+)
+
+// Server is a language server for awless templates speaking over a single stream (i.e. stdio).
+// It completes actions, entities, params, resource ids and aliases, gives the docs of
+// commands and params on hover and publishes the diagnostics of the template linter.
+type Server struct {
+	Version string
+	Log     *logger.Logger
+	// LoadGraph returns the local graph to complete resource ids and aliases
+	LoadGraph func() (cloud.GraphAPI, error)
+	// NewEnv returns the compile env used to lint the template at path
+	NewEnv func(path string) env.Compiling
+
+	out          io.Writer
+	docs         map[string]string
+	graph        cloud.GraphAPI
+	graphLoaded  bool
+	shutdownDone bool
+}
+
+// Serve handles requests read on r until the client sends an exit notification
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	if s.Log == nil {
+		s.Log = logger.DiscardLogger
+	}
+	s.out = w
+	s.docs = make(map[string]string)
+
+	in := bufio.NewReader(r)
+	for {
+		msg, err := readMessage(in)
+		if err == io.EOF {
+			return nil
+		}
+		if rerr, ok := err.(*responseError); ok {
+			s.reply(nil, nil, rerr)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdownDone {
+				return errors.New("exit before shutdown")
+			}
+			return nil
+		}
+
+		result, err := s.handle(msg)
+		if msg.ID == nil { // notification
+			if err != nil {
+				s.Log.Warningf("lsp: %s: %s", msg.Method, err)
+			}
+			continue
+		}
+		if rerr, ok := err.(*responseError); ok {
+			s.reply(msg.ID, nil, rerr)
+		} else if err != nil {
+			s.reply(msg.ID, nil, &responseError{Code: invalidParamsCode, Message: err.Error()})
+		} else {
+			s.reply(msg.ID, result, nil)
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) (interface{}, error) {
+	s.Log.ExtraVerbosef("lsp: received %s", msg.Method)
+	switch msg.Method {
+	case "initialize":
+		res := InitializeResult{Capabilities: ServerCapabilities{
+			TextDocumentSync:   fullTextDocumentSync,
+			CompletionProvider: &CompletionOptions{TriggerCharacters: []string{" ", "=", "@", "$", ","}},
+			HoverProvider:      true,
+		}}
+		res.ServerInfo.Name, res.ServerInfo.Version = "awless", s.Version
+		return res, nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdownDone = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		s.docs[params.TextDocument.URI] = params.TextDocument.Text
+		return nil, s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		if l := len(params.ContentChanges); l > 0 {
+			s.docs[params.TextDocument.URI] = params.ContentChanges[l-1].Text
+		}
+		return nil, s.publishDiagnostics(params.TextDocument.URI)
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []Diagnostic{}})
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.complete(s.docs[params.TextDocument.URI], params.Position), nil
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.hover(s.docs[params.TextDocument.URI], params.Position), nil
+	}
+	if strings.HasPrefix(msg.Method, "$/") || msg.ID == nil {
+		return nil, nil
+	}
+	return nil, &responseError{Code: methodNotFoundCode, Message: fmt.Sprintf("method not found: %s", msg.Method)}
+}
+
+func (s *Server) reply(id *json.RawMessage, result interface{}, rerr *responseError) {
+	msg := &message{ID: id, Error: rerr}
+	if id == nil {
+		null := json.RawMessage("null")
+		msg.ID = &null
+	}
+	if rerr == nil {
+		b, err := json.Marshal(result)
+		if err != nil {
+			msg.Error = &responseError{Code: invalidParamsCode, Message: err.Error()}
+		}
+		msg.Result = b
+	}
+	if err := writeMessage(s.out, msg); err != nil {
+		s.Log.Errorf("lsp: cannot write response: %s", err)
+	}
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return writeMessage(s.out, &message{Method: method, Params: b})
+}
+
+func (s *Server) publishDiagnostics(uri string) error {
+	return s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{URI: uri, Diagnostics: s.diagnose(uri, s.docs[uri])})
+}
+
+// diagnose reports parsing errors, or else the issues of the template linter
+func (s *Server) diagnose(uri, text string) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	if strings.TrimSpace(text) == "" {
+		return diagnostics
+	}
+
+	tpl, err := template.Parse(text)
+	if err != nil {
+		var pos Position
+		if perr, ok := err.(interface {
+			Position() (int, int)
+		}); ok {
+			line, char := perr.Position()
+			pos = Position{Line: line - 1, Character: char}
+		}
+		msg := strings.SplitN(err.Error(), "\n", 2)[0]
+		return append(diagnostics, Diagnostic{Range: lineRange(text, pos), Severity: errorSeverity, Source: "awless", Message: msg})
+	}
+
+	if s.NewEnv == nil {
+		return diagnostics
+	}
+	for _, issue := range template.Lint(tpl, s.NewEnv(uriToPath(uri))) {
+		severity := errorSeverity
+		if issue.Warning {
+			severity = warningSeverity
+		}
+		pos := Position{Line: issue.Line - 1, Character: issue.Col - 1}
+		diagnostics = append(diagnostics, Diagnostic{Range: wordRange(text, pos), Severity: severity, Source: "awless", Message: issue.Message})
+	}
+	return diagnostics
+}
+
+func (s *Server) loadGraph() cloud.GraphAPI {
+	if !s.graphLoaded && s.LoadGraph != nil {
+		g, err := s.LoadGraph()
+		if err != nil {
+			s.Log.Warningf("lsp: cannot load local graph: %s", err)
+		}
+		s.graph, s.graphLoaded = g, true
+	}
+	return s.graph
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return u.Path
+}
+
+func documentLine(text string, line int) string {
+	lines := strings.Split(text, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	return strings.TrimSuffix(lines[line], "\r")
+}
+
+// lineRange spans from the position to the end of its line
+func lineRange(text string, pos Position) Range {
+	end := pos
+	end.Character = len([]rune(documentLine(text, pos.Line)))
+	if end.Character < pos.Character {
+		end.Character = pos.Character
+	}
+	return Range{Start: pos, End: end}
+}
+
+// wordRange spans from the position to the next whitespace
+func wordRange(text string, pos Position) Range {
+	line := []rune(documentLine(text, pos.Line))
+	end := pos
+	for end.Character < len(line) && !isSpace(line[end.Character]) {
+		end.Character++
+	}
+	return Range{Start: pos, End: end}
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t'
+}
@@ -0,0 +1,185 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/env"
+)
+
+func TestServe(t *testing.T) {
+	var in bytes.Buffer
+	send := func(id int, method string, params interface{}) {
+		msg := &message{Method: method}
+		if id > 0 {
+			raw := json.RawMessage(strings.TrimSpace(string(mustMarshal(t, id))))
+			msg.ID = &raw
+		}
+		if params != nil {
+			msg.Params = mustMarshal(t, params)
+		}
+		if err := writeMessage(&in, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	uri := "file:///tmp/infra.aws"
+	doc := TextDocumentPositionParams{TextDocument: TextDocumentIdentifier{URI: uri}}
+
+	send(1, "initialize", map[string]interface{}{})
+	send(0, "initialized", map[string]interface{}{})
+	send(0, "textDocument/didOpen", DidOpenTextDocumentParams{TextDocument: TextDocumentItem{URI: uri, Text: "create vpc cidr=10.0.0.0/16 size=2"}})
+	doc.Position = Position{Line: 0, Character: 8}
+	send(2, "textDocument/hover", doc)
+	send(3, "unknown/method", nil)
+	send(4, "shutdown", nil)
+	send(0, "exit", nil)
+
+	var out bytes.Buffer
+	s := &Server{NewEnv: func(string) env.Compiling {
+		return template.NewEnv().WithLookupCommandFunc(lookupMockCommand).Build()
+	}}
+	if err := s.Serve(&in, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	var msgs []*message
+	r := bufio.NewReader(&out)
+	for {
+		msg, err := readMessage(r)
+		if err != nil {
+			break
+		}
+		msgs = append(msgs, msg)
+	}
+	if got, want := len(msgs), 5; got != want {
+		t.Fatalf("got %d messages, want %d", got, want)
+	}
+
+	var initRes InitializeResult
+	if err := json.Unmarshal(msgs[0].Result, &initRes); err != nil {
+		t.Fatal(err)
+	}
+	if !initRes.Capabilities.HoverProvider || initRes.Capabilities.CompletionProvider == nil {
+		t.Fatalf("unexpected capabilities %#v", initRes.Capabilities)
+	}
+
+	if got, want := msgs[1].Method, "textDocument/publishDiagnostics"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	var diags PublishDiagnosticsParams
+	if err := json.Unmarshal(msgs[1].Params, &diags); err != nil {
+		t.Fatal(err)
+	}
+	expDiags := []Diagnostic{
+		{Range: Range{Start: Position{Line: 0, Character: 28}, End: Position{Line: 0, Character: 34}}, Severity: errorSeverity, Source: "awless", Message: "create vpc: unknown param 'size'"},
+	}
+	if got, want := diags.Diagnostics, expDiags; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	var hover Hover
+	if err := json.Unmarshal(msgs[2].Result, &hover); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(hover.Contents.Value, "**create vpc**") || !strings.Contains(hover.Contents.Value, "Required params: cidr") {
+		t.Fatalf("unexpected hover %q", hover.Contents.Value)
+	}
+
+	if msgs[3].Error == nil || msgs[3].Error.Code != methodNotFoundCode {
+		t.Fatalf("expected method not found, got %#v", msgs[3])
+	}
+	if got, want := string(msgs[4].Result), "null"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Subnet("sub-1234").Prop(properties.Name, "my-subnet").Build(),
+		resourcetest.Subnet("sub-5678").Build(),
+		resourcetest.Instance("i-1234").Prop(properties.Name, "my instance").Build(),
+	)
+	s := &Server{LoadGraph: func() (cloud.GraphAPI, error) { return g, nil }}
+
+	labels := func(items []CompletionItem) (out []string) {
+		for _, i := range items {
+			out = append(out, i.Label)
+		}
+		return
+	}
+	tcases := []struct {
+		text string
+		pos  Position
+		exp  []string
+	}{
+		{text: "de", pos: Position{0, 2}, exp: []string{"delete", "detach"}},
+		{text: "create su", pos: Position{0, 9}, exp: []string{"subnet", "subscription"}},
+		{text: "vpc = create vpc ", pos: Position{0, 17}, exp: []string{"cidr", "name"}},
+		{text: "create vpc name=any ", pos: Position{0, 20}, exp: []string{"cidr"}},
+		{text: "create subnet vpc=vpc-1 ci", pos: Position{0, 26}, exp: []string{"cidr"}},
+		{text: "delete subnet id=", pos: Position{0, 17}, exp: []string{"sub-1234", "sub-5678"}},
+		{text: "delete subnet id=sub-5", pos: Position{0, 22}, exp: []string{"sub-5678"}},
+		{text: "create instance subnet=@", pos: Position{0, 24}, exp: []string{"@my-subnet"}},
+		{text: "stop instance ids=[i-1234,@", pos: Position{0, 27}, exp: []string{"@'my instance'"}},
+		{text: "net = create vpc cidr=10.0.0.0/16\nvpc = create vpc cidr=10.0.0.0/16\ncreate subnet vpc=$", pos: Position{2, 19}, exp: []string{"$net", "$vpc"}},
+		{text: "check instance state=run", pos: Position{0, 24}, exp: []string{"running"}},
+		{text: "# create", pos: Position{0, 8}},
+	}
+
+	for i, tcase := range tcases {
+		if got, want := labels(s.complete(tcase.text, tcase.pos)), tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestHover(t *testing.T) {
+	s := &Server{}
+	if h := s.hover("create subnet cidr=10.0.0.0/24", Position{0, 16}); h == nil || !strings.Contains(h.Contents.Value, "**cidr** (create subnet)") {
+		t.Fatalf("unexpected hover %#v", h)
+	}
+	if h := s.hover("create subnet cidr=10.0.0.0/24", Position{0, 22}); h != nil {
+		t.Fatalf("expected no hover on param value, got %#v", h)
+	}
+	if h := s.hover("sub = create subnet cidr=10.0.0.0/24", Position{0, 16}); h == nil || h.Range.Start.Character != 13 {
+		t.Fatalf("unexpected hover %#v", h)
+	}
+}
+
+func TestDiagnoseParsingError(t *testing.T) {
+	s := &Server{}
+	diags := s.diagnose("file:///tmp/t.aws", "create vpc cidr=10.0.0.0/16\ncreate subnet cidr==")
+	if got, want := len(diags), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := diags[0].Range.Start.Line, 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+func lookupMockCommand(tokens ...string) interface{} {
+	newCommandFunc := awsspec.MockAWSSessionFactory.Build(strings.Join(tokens, ""))
+	if newCommandFunc == nil {
+		return nil
+	}
+	return newCommandFunc()
+}
+
+func mustMarshal(t *testing.T, v interface{}) json.RawMessage {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
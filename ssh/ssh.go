@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"syscall"
@@ -32,6 +33,7 @@ type Client struct {
 	HostKeyCallback         gossh.HostKeyCallback
 	StrictHostKeyChecking   bool
 	InteractiveTerminalFunc func(*gossh.Client) error
+	HostKeyFingerprintsFunc func(hostname string) ([]string, error)
 	logger                  *logger.Logger
 }
 
@@ -83,9 +85,7 @@ func (c *Client) DialWithUsers(usernames ...string) error {
 	for _, user := range usernames {
 		newConfig := *c.Config
 		newConfig.User = user
-		if !c.StrictHostKeyChecking {
-			newConfig.HostKeyCallback = gossh.InsecureIgnoreHostKey()
-		}
+		newConfig.HostKeyCallback = c.hostKeyCallback()
 		client, err = gossh.Dial("tcp", hostport, &newConfig)
 		if err != nil {
 			continue
@@ -110,9 +110,7 @@ func (c *Client) NewClientWithProxy(destinationHost string, destinationPort int,
 		c.logger.ExtraVerbosef("successful tcp connection from %s:%d to %s:%d", c.IP, c.Port, destinationHost, destinationPort)
 		newConfig := *c.Config
 		newConfig.User = user
-		if !c.StrictHostKeyChecking {
-			newConfig.HostKeyCallback = gossh.InsecureIgnoreHostKey()
-		}
+		newConfig.HostKeyCallback = c.hostKeyCallback()
 		conn, chans, reqs, err := gossh.NewClientConn(netConn, hostport, &newConfig)
 		if err != nil {
 			netConn.Close()
@@ -122,14 +120,15 @@ func (c *Client) NewClientWithProxy(destinationHost string, destinationPort int,
 		c.logger.ExtraVerbosef("proxied successfully with user %s", user)
 
 		return &Client{
-			Client:  gossh.NewClient(conn, chans, reqs),
-			Proxy:   c,
-			IP:      destinationHost,
-			User:    user,
-			Keypath: c.Keypath,
-			Port:    destinationPort,
+			Client:                  gossh.NewClient(conn, chans, reqs),
+			Proxy:                   c,
+			IP:                      destinationHost,
+			User:                    user,
+			Keypath:                 c.Keypath,
+			Port:                    destinationPort,
 			InteractiveTerminalFunc: func(*gossh.Client) error { return nil },
 			StrictHostKeyChecking:   c.StrictHostKeyChecking,
+			HostKeyFingerprintsFunc: c.HostKeyFingerprintsFunc,
 			logger:                  logger.DiscardLogger,
 		}, nil
 	}
@@ -137,6 +136,18 @@ func (c *Client) NewClientWithProxy(destinationHost string, destinationPort int,
 	return nil, fmt.Errorf("cannot proxy from %s:%d to %s:%d with users %q", c.IP, c.Port, destinationHost, destinationPort, usernames)
 }
 
+func (c *Client) hostKeyCallback() gossh.HostKeyCallback {
+	if !c.StrictHostKeyChecking {
+		return gossh.InsecureIgnoreHostKey()
+	}
+	if c.HostKeyFingerprintsFunc == nil {
+		return c.Config.HostKeyCallback
+	}
+	return func(hostname string, remote net.Addr, key gossh.PublicKey) error {
+		return checkHostKeyWithFingerprints(hostname, remote, key, c.HostKeyFingerprintsFunc)
+	}
+}
+
 func (c *Client) CloseAll() error {
 	if c != nil {
 		if c.Client != nil {
@@ -298,6 +309,12 @@ func findPrivateKeyFromName(keyname string, keyFolders ...string) (privateKey, b
 }
 
 func checkHostKey(hostname string, remote net.Addr, key gossh.PublicKey) error {
+	return checkHostKeyWithFingerprints(hostname, remote, key, nil)
+}
+
+// When the host is unknown and fingerprints are published out of band (i.e. EC2 console output),
+// the remote key is verified against them and persisted without prompting the user.
+func checkHostKeyWithFingerprints(hostname string, remote net.Addr, key gossh.PublicKey, fingerprintsFunc func(string) ([]string, error)) error {
 	var knownHostsFiles []string
 	var fileToAddKnownKey string
 
@@ -325,14 +342,29 @@ func checkHostKey(hostname string, remote net.Addr, key gossh.PublicKey) error {
 		return knownhostsErr
 	}
 	if len(keyError.Want) == 0 {
-		if trustKeyFunc(hostname, remote, key, fileToAddKnownKey) {
-			f, err := os.OpenFile(fileToAddKnownKey, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if fingerprintsFunc != nil {
+			fingerprints, err := fingerprintsFunc(hostname)
 			if err != nil {
-				return err
+				logger.Warningf("cannot verify host key of '%s' from published fingerprints: %s", hostname, err)
+			} else if len(fingerprints) > 0 {
+				if !matchFingerprints(key, fingerprints) {
+					return fmt.Errorf(`
+@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@
+AWLESS DETECTED THAT THE REMOTE HOST PUBLIC KEY IS NOT TRUSTED
+@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@@
+
+The fingerprint for the %s key sent by '%s' is %s.
+It does not match any of the fingerprints published by the host:
+-> %s
+
+Someone may be trying to intercept your connection (man-in-the-middle attack).`, key.Type(), hostname, gossh.FingerprintSHA256(key), strings.Join(fingerprints, "\n-> "))
+				}
+				logger.Infof("%s public key fingerprint %s of '%s' verified from published fingerprints. Persisting it to '%s'", key.Type(), gossh.FingerprintSHA256(key), hostname, awlessFile)
+				return addKnownHost(awlessFile, hostname, key)
 			}
-			defer f.Close()
-			_, err = f.WriteString(knownhosts.Line([]string{hostname}, key) + "\n")
-			return err
+		}
+		if trustKeyFunc(hostname, remote, key, fileToAddKnownKey) {
+			return addKnownHost(fileToAddKnownKey, hostname, key)
 		} else {
 			return errors.New("Host public key verification failed.")
 		}
@@ -359,6 +391,59 @@ You persisted:%s
 To get rid of this message, update %s`, hostname, key.Type(), gossh.FingerprintSHA256(key), knownKeyInfos, strings.Join(knownKeyFiles, ","))
 }
 
+func addKnownHost(filename, hostname string, key gossh.PublicKey) error {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(knownhosts.Line([]string{hostname}, key) + "\n")
+	return err
+}
+
+const (
+	beginFingerprintsMarker = "-----BEGIN SSH HOST KEY FINGERPRINTS-----"
+	endFingerprintsMarker   = "-----END SSH HOST KEY FINGERPRINTS-----"
+)
+
+// ParseHostKeyFingerprints extracts the host key fingerprints printed by cloud-init
+// in an instance console output. Lines are formatted as given by `ssh-keygen -l`
+// (i.e. "256 SHA256:Xy1... root@host (ECDSA)") and may be prefixed (i.e. "ec2: "),
+// only the fingerprints are returned
+func ParseHostKeyFingerprints(consoleOutput string) (fingerprints []string) {
+	start := strings.LastIndex(consoleOutput, beginFingerprintsMarker)
+	if start < 0 {
+		return
+	}
+	block := consoleOutput[start+len(beginFingerprintsMarker):]
+	end := strings.Index(block, endFingerprintsMarker)
+	if end < 0 {
+		return
+	}
+	for _, line := range strings.Split(block[:end], "\n") {
+		for _, field := range strings.Fields(line) {
+			if strings.HasPrefix(field, "SHA256:") || strings.HasPrefix(field, "MD5:") || legacyMD5FingerprintRegex.MatchString(field) {
+				fingerprints = append(fingerprints, field)
+				break
+			}
+		}
+	}
+	return
+}
+
+var legacyMD5FingerprintRegex = regexp.MustCompile(`^([0-9a-f]{2}:){15}[0-9a-f]{2}$`)
+
+func matchFingerprints(key gossh.PublicKey, fingerprints []string) bool {
+	sha256 := gossh.FingerprintSHA256(key)
+	md5 := gossh.FingerprintLegacyMD5(key)
+	for _, f := range fingerprints {
+		if f == sha256 || strings.TrimPrefix(f, "MD5:") == md5 {
+			return true
+		}
+	}
+	return false
+}
+
 var trustKeyFunc func(hostname string, remote net.Addr, key gossh.PublicKey, keyFileName string) bool = func(hostname string, remote net.Addr, key gossh.PublicKey, keyFileName string) bool {
 	fmt.Printf("awless could not validate the authenticity of '%s' (unknown host)\n", hostname)
 	fmt.Printf("%s public key fingerprint is %s.\n", key.Type(), gossh.FingerprintSHA256(key))
@@ -377,8 +462,9 @@ const tmpProxyCommandScriptFilename = "awless-ssh-proxycommand"
 // Bug: when executing syscall.Exec(args[0], args, os.Environ()) and args contains
 // the proxy command (typically args := []string{"/usr/bin/ssh", "ec2-user@172.31.78.138", "-o", "StrictHostKeychecking=no", "-o", "ProxyCommand='ssh ec2-user@52.26.181.76 -W [%h]:%p'"}
 // we get an error like (in Go, Python):
-//     /bin/bash: 1: exec: ssh ec2-user@52.26.181.76 -W [172.31.78.138]:22: not found
-//     ssh_exchange_identification: Connection closed by remote host
+//
+//	/bin/bash: 1: exec: ssh ec2-user@52.26.181.76 -W [172.31.78.138]:22: not found
+//	ssh_exchange_identification: Connection closed by remote host
 //
 // Since execve(2) can take as the first argument a filename, the workaround is to use
 // a temporary script to execute this command.
@@ -120,6 +120,7 @@ func TestCheckHostKey(t *testing.T) {
 	}
 	knownKeys := make(map[string]gossh.PublicKey)
 	numberKeysAdded := 0
+	defer func(restore func(string, net.Addr, gossh.PublicKey, string) bool) { trustKeyFunc = restore }(trustKeyFunc)
 	trustKeyFunc = func(hostname string, remote net.Addr, key gossh.PublicKey, _ string) bool {
 		knownKeys[hostname] = key
 		numberKeysAdded++
@@ -223,3 +224,104 @@ func TestCLIAndConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckHostKeyWithFingerprints(t *testing.T) {
+	f, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(f)
+	if err = os.Setenv("HOME", f); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Setenv("__AWLESS_HOME", f); err != nil {
+		t.Fatal(err)
+	}
+	awlessKnownHosts := filepath.Join(f, "known_hosts")
+
+	ecdsa1, _, _, _, err := gossh.ParseAuthorizedKey([]byte("1.2.3.4 ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBBFJz/HFJUq6SaXD5FdLe6ddIpmNPFim7E3NkNCSNurDun/h3BOIzNGfuseyMn32n24oQayhjkX8eGqevJIA38E="))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsa2, _, _, _, err := gossh.ParseAuthorizedKey([]byte("3.4.5.6 ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBBKl6fXNb/yA0w7brzqNuOCwLJ/aPEMerl7/lsF0Y/1oafD2bxzj+QsEZo4XK/kvwCjqQArFO5nET+Tz015C6Kk="))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var prompted int
+	defer func(restore func(string, net.Addr, gossh.PublicKey, string) bool) { trustKeyFunc = restore }(trustKeyFunc)
+	trustKeyFunc = func(string, net.Addr, gossh.PublicKey, string) bool {
+		prompted++
+		return false
+	}
+
+	fingerprints := map[string][]string{
+		"1.2.3.4:22": {gossh.FingerprintSHA256(ecdsa1)},
+		"2.3.4.5:22": {"MD5:" + gossh.FingerprintLegacyMD5(ecdsa1)},
+		"3.4.5.6:22": {gossh.FingerprintSHA256(ecdsa1)},
+	}
+	fingerprintsFunc := func(hostname string) ([]string, error) {
+		return fingerprints[hostname], nil
+	}
+
+	tcases := []struct {
+		ip          string
+		key         gossh.PublicKey
+		expErr      bool
+		expPrompted int
+	}{
+		{"1.2.3.4", ecdsa1, false, 0},
+		{"1.2.3.4", ecdsa1, false, 0},
+		{"2.3.4.5", ecdsa1, false, 0},
+		{"3.4.5.6", ecdsa2, true, 0},
+		{"4.5.6.7", ecdsa2, true, 1},
+	}
+
+	for i, tcase := range tcases {
+		addr, er := net.ResolveTCPAddr("", tcase.ip+":22")
+		if er != nil {
+			t.Fatal(er)
+		}
+		err := checkHostKeyWithFingerprints(tcase.ip+":22", addr, tcase.key, fingerprintsFunc)
+		if got, want := err != nil, tcase.expErr; got != want {
+			t.Fatalf("case %d: got err %v, want err %t", i+1, err, want)
+		}
+		if got, want := prompted, tcase.expPrompted; got != want {
+			t.Fatalf("case %d: got %d prompts, want %d", i+1, got, want)
+		}
+	}
+
+	content, err := ioutil.ReadFile(awlessKnownHosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "1.2.3.4 ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBBFJz/HFJUq6SaXD5FdLe6ddIpmNPFim7E3NkNCSNurDun/h3BOIzNGfuseyMn32n24oQayhjkX8eGqevJIA38E=\n" +
+		"2.3.4.5 ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBBFJz/HFJUq6SaXD5FdLe6ddIpmNPFim7E3NkNCSNurDun/h3BOIzNGfuseyMn32n24oQayhjkX8eGqevJIA38E=\n"
+	if got, want := string(content), exp; got != want {
+		t.Fatalf("got \n%s\nwant\n%s\n", got, want)
+	}
+}
+
+func TestParseHostKeyFingerprints(t *testing.T) {
+	output := `[   12.345678] cloud-init[2650]: Cloud-init v. 0.7.6 running 'modules:final'
+ec2: 
+ec2: #############################################################
+ec2: -----BEGIN SSH HOST KEY FINGERPRINTS-----
+ec2: 256 SHA256:t88p7xhU/1D3USAczv1d88hTZJbOeWN/ktcNmeWh6qI no comment (ECDSA)
+ec2: 2048 MD5:9b:6f:8a:c3:1b:f2:d4:46:a1:20:75:4e:e3:23:0e:b0 /etc/ssh/ssh_host_rsa_key.pub (RSA)
+ec2: -----END SSH HOST KEY FINGERPRINTS-----
+ec2: #############################################################
+-----BEGIN SSH HOST KEY KEYS-----
+ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBBKl6fXNb/yA0w7brzqNuOCwLJ/aPEMerl7/lsF0Y/1oafD2bxzj+QsEZo4XK/kvwCjqQArFO5nET+Tz015C6Kk=
+-----END SSH HOST KEY KEYS-----`
+
+	got := ParseHostKeyFingerprints(output)
+	want := []string{"SHA256:t88p7xhU/1D3USAczv1d88hTZJbOeWN/ktcNmeWh6qI", "MD5:9b:6f:8a:c3:1b:f2:d4:46:a1:20:75:4e:e3:23:0e:b0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if got := ParseHostKeyFingerprints("no fingerprints here"); len(got) != 0 {
+		t.Fatalf("got %q, want none", got)
+	}
+}
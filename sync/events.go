@@ -0,0 +1,139 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+)
+
+// ChangeEvent is a resource change found by a sync, published as a JSON line
+type ChangeEvent struct {
+	Time        time.Time `json:"time"`
+	Event       string    `json:"event"`
+	Profile     string    `json:"profile"`
+	Region      string    `json:"region"`
+	Service     string    `json:"service"`
+	Type        string    `json:"type"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name,omitempty"`
+	ChangedKeys []string  `json:"changedKeys,omitempty"`
+}
+
+// EventPublisher publishes the change events of a sync
+type EventPublisher interface {
+	Publish(events []*ChangeEvent) error
+}
+
+// NewEventPublisher returns the publisher of change events to the given target: 'stdout', 'stderr',
+// an http(s) webhook URL (events being posted as JSON lines) or otherwise a file the events are appended to
+func NewEventPublisher(target string) EventPublisher {
+	switch {
+	case target == "stdout":
+		return &writerPublisher{w: os.Stdout}
+	case target == "stderr":
+		return &writerPublisher{w: os.Stderr}
+	case strings.HasPrefix(target, "http://"), strings.HasPrefix(target, "https://"):
+		return &webhookPublisher{url: target, client: &http.Client{Timeout: 10 * time.Second}}
+	default:
+		return &filePublisher{path: target}
+	}
+}
+
+func changeEvents(diff *graph.Diff, profile, region, service string, now time.Time) (events []*ChangeEvent) {
+	for _, change := range diff.ResourceChanges() {
+		event := &ChangeEvent{
+			Time:        now,
+			Event:       change.Kind,
+			Profile:     profile,
+			Region:      region,
+			Service:     service,
+			Type:        change.Resource.Type(),
+			ID:          change.Resource.Id(),
+			ChangedKeys: change.ChangedKeys,
+		}
+		if name, ok := change.Resource.Properties()[properties.Name].(string); ok {
+			event.Name = name
+		}
+		events = append(events, event)
+	}
+	return
+}
+
+func writeJSONLines(w io.Writer, events []*ChangeEvent) error {
+	enc := json.NewEncoder(w)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type writerPublisher struct {
+	w io.Writer
+}
+
+func (p *writerPublisher) Publish(events []*ChangeEvent) error {
+	return writeJSONLines(p.w, events)
+}
+
+type filePublisher struct {
+	path string
+}
+
+func (p *filePublisher) Publish(events []*ChangeEvent) error {
+	f, err := os.OpenFile(p.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if err = writeJSONLines(f, events); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type webhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func (p *webhookPublisher) Publish(events []*ChangeEvent) error {
+	var body bytes.Buffer
+	if err := writeJSONLines(&body, events); err != nil {
+		return err
+	}
+	resp, err := p.client.Post(p.url, "application/x-ndjson", &body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s: %s", p.url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package sync
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestSyncChangeEvents(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	os.Setenv("__AWLESS_HOME", tmpDir)
+
+	eventsFile := filepath.Join(tmpDir, "events.jsonl")
+	syncer := NewSyncerWithEvents(NewEventPublisher(eventsFile))
+	syncInfra := func(resources ...*graph.Resource) {
+		g := graph.NewGraph()
+		if err := g.AddResource(resources...); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := syncer.Sync(&mockService{g: g, name: "infra", region: "eu-west-1", profile: "default"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	syncInfra(resourcetest.Instance("inst_1").Prop("State", "pending").Build(), resourcetest.Instance("inst_2").Build())
+	if _, err := os.Stat(eventsFile); !os.IsNotExist(err) {
+		t.Fatalf("expected no events on first sync of a service, got %v", err)
+	}
+	syncInfra(resourcetest.Instance("inst_1").Prop("State", "running").Prop("Name", "web").Build(), resourcetest.Subnet("sub_1").Build())
+
+	f, err := os.Open(eventsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var got []*ChangeEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e ChangeEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("invalid JSON line %q: %s", scanner.Text(), err)
+		}
+		if e.Time.IsZero() || e.Profile != "default" || e.Region != "eu-west-1" || e.Service != "infra" {
+			t.Fatalf("unexpected event context: %#v", e)
+		}
+		got = append(got, &e)
+	}
+
+	var summary []string
+	for _, e := range got {
+		line := e.Event + " " + e.Type + " " + e.ID + " " + e.Name
+		for _, k := range e.ChangedKeys {
+			line += " " + k
+		}
+		summary = append(summary, line)
+	}
+	expected := []string{
+		"property-changed instance inst_1 web Name State",
+		"deleted instance inst_2 ",
+		"created subnet sub_1 ",
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Fatalf("got\n%q\nwant\n%q", summary, expected)
+	}
+}
+
+func TestSyncChangeEventsSkipUnsyncedTypes(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	os.Setenv("__AWLESS_HOME", tmpDir)
+
+	eventsFile := filepath.Join(tmpDir, "events.jsonl")
+	syncer := NewSyncerWithEvents(NewEventPublisher(eventsFile))
+	syncInfra := func(statuses map[string]*graph.FetchStatus, resources ...*graph.Resource) {
+		g := graph.NewGraph()
+		if err := g.AddResource(resources...); err != nil {
+			t.Fatal(err)
+		}
+		for typ, st := range statuses {
+			g.SetFetchStatus(typ, st)
+		}
+		if _, err := syncer.Sync(&mockService{g: g, name: "infra", region: "eu-west-1", profile: "default"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	syncInfra(nil, resourcetest.Instance("inst_1").Build(), resourcetest.Subnet("sub_1").Build(), resourcetest.VPC("vpc_1").Build())
+	syncInfra(map[string]*graph.FetchStatus{
+		"instance": {Err: errors.New("throttled")},
+		"subnet":   {Disabled: true},
+		"vpc":      {},
+	})
+
+	b, err := ioutil.ReadFile(eventsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		var e ChangeEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("invalid JSON line %q: %s", line, err)
+		}
+		got = append(got, e.Event+" "+e.Type+" "+e.ID)
+	}
+	if want := []string{"deleted vpc vpc_1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestWebhookEventPublisher(t *testing.T) {
+	var contentType string
+	var received []*ChangeEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		dec := json.NewDecoder(r.Body)
+		for dec.More() {
+			var e ChangeEvent
+			if err := dec.Decode(&e); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			received = append(received, &e)
+		}
+	}))
+	defer server.Close()
+
+	events := []*ChangeEvent{{Event: "created", Type: "instance", ID: "inst_1"}, {Event: "deleted", Type: "subnet", ID: "sub_1"}}
+	if err := NewEventPublisher(server.URL).Publish(events); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := contentType, "application/x-ndjson"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := len(received), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := received[1].ID, "sub_1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	if err := NewEventPublisher(failing.URL).Publish(events); err == nil {
+		t.Fatal("expected error on non 2xx webhook response")
+	}
+}
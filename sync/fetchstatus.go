@@ -0,0 +1,147 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync/repo"
+)
+
+const fetchStatusExt = ".fetch.json"
+
+const (
+	TypeFetched       = "fetched"
+	TypeFetchFailed   = "failed"
+	TypeFetchDisabled = "disabled"
+)
+
+// TypeFetchStatus is the outcome of the fetch of a resource type, recorded by a sync
+// next to the synced service file, to tell a type without resources from a type not synced
+type TypeFetchStatus struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+}
+
+func (s *TypeFetchStatus) IsSynced() bool {
+	return s.Status == TypeFetched
+}
+
+// Reason returns why the resource type is not synced
+func (s *TypeFetchStatus) Reason() string {
+	switch {
+	case s.Status == TypeFetchDisabled:
+		return "sync disabled"
+	case s.Error == cloud.ErrFetchAccessDenied.Error():
+		return "access denied"
+	default:
+		return s.Error
+	}
+}
+
+func fetchStatusesOf(g cloud.GraphAPI, at time.Time) map[string]*TypeFetchStatus {
+	statuses := make(map[string]*TypeFetchStatus)
+	gph, ok := g.(*graph.Graph)
+	if !ok {
+		return statuses
+	}
+	for t, st := range gph.FetchStatuses() {
+		status := &TypeFetchStatus{FetchedAt: at, Status: TypeFetched}
+		switch {
+		case st.Disabled:
+			status.Status = TypeFetchDisabled
+		case st.Err != nil:
+			status.Status, status.Error = TypeFetchFailed, st.Err.Error()
+		}
+		statuses[t] = status
+	}
+	return statuses
+}
+
+func disabledFetchStatuses(resourceTypes []string, at time.Time) map[string]*TypeFetchStatus {
+	statuses := make(map[string]*TypeFetchStatus)
+	for _, t := range resourceTypes {
+		statuses[t] = &TypeFetchStatus{FetchedAt: at, Status: TypeFetchDisabled}
+	}
+	return statuses
+}
+
+// writeFetchStatuses writes the fetch statuses of a service and returns the path of the file relative to baseDir
+func writeFetchStatuses(baseDir, profile, region, service string, statuses map[string]*TypeFetchStatus) (string, error) {
+	b, err := json.MarshalIndent(statuses, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	relPath := filepath.Join(profile, region, service+fetchStatusExt)
+	if err = os.MkdirAll(filepath.Join(baseDir, profile, region), 0700); err != nil {
+		return "", err
+	}
+	return relPath, ioutil.WriteFile(filepath.Join(baseDir, relPath), b, 0600)
+}
+
+// mergeFetchStatuses returns the fetch statuses last written for a service, updated with the given ones
+func mergeFetchStatuses(baseDir, profile, region, service string, statuses map[string]*TypeFetchStatus) map[string]*TypeFetchStatus {
+	merged := make(map[string]*TypeFetchStatus)
+	if b, err := ioutil.ReadFile(filepath.Join(baseDir, profile, region, service+fetchStatusExt)); err == nil {
+		json.Unmarshal(b, &merged)
+	}
+	for t, st := range statuses {
+		merged[t] = st
+	}
+	return merged
+}
+
+// LocalFetchStatuses returns the fetch status per resource type recorded by the last syncs
+// of all the services of a profile and region. Types of services never synced are absent
+func LocalFetchStatuses(profile, region string) map[string]*TypeFetchStatus {
+	statuses := make(map[string]*TypeFetchStatus)
+	for _, dir := range []string{GlobalRegion, region} {
+		files, _ := filepath.Glob(filepath.Join(repo.BaseDir(), profile, dir, "*"+fetchStatusExt))
+		for _, f := range files {
+			service := strings.TrimSuffix(filepath.Base(f), fetchStatusExt)
+			if IsGlobalService(service) != (dir == GlobalRegion) {
+				continue
+			}
+			b, err := ioutil.ReadFile(f)
+			if err != nil {
+				continue
+			}
+			var serviceStatuses map[string]*TypeFetchStatus
+			if err := json.Unmarshal(b, &serviceStatuses); err != nil {
+				continue
+			}
+			for t, st := range serviceStatuses {
+				statuses[t] = st
+			}
+		}
+	}
+	return statuses
+}
+
+// LocalFetchStatus returns the fetch status of a resource type recorded by the last sync, false when unknown
+func LocalFetchStatus(profile, region, resourceType string) (*TypeFetchStatus, bool) {
+	st, ok := LocalFetchStatuses(profile, region)[resourceType]
+	return st, ok
+}
@@ -0,0 +1,73 @@
+package sync
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestLocalFetchStatuses(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	os.Setenv("__AWLESS_HOME", tmpDir)
+
+	if _, ok := LocalFetchStatus("default", "eu-west-1", "instance"); ok {
+		t.Fatal("expected unknown status before any sync")
+	}
+
+	infra := graph.NewGraph()
+	infra.AddResource(resourcetest.Instance("inst_1").Build())
+	infra.SetFetchStatus("instance", &graph.FetchStatus{})
+	infra.SetFetchStatus("database", &graph.FetchStatus{Err: cloud.ErrFetchAccessDenied})
+	storage := graph.NewGraph()
+	storage.SetFetchStatus("bucket", &graph.FetchStatus{Err: errors.New("timeout")})
+	storage.SetFetchStatus("s3object", &graph.FetchStatus{Disabled: true})
+
+	if _, err = NewSyncer().Sync(
+		&mockService{g: infra, name: "infra", region: "eu-west-1", profile: "default"},
+		&mockService{g: storage, name: "storage", region: "eu-west-1", profile: "default"},
+		&mockService{name: "access", region: "global", profile: "default", disabled: true, types: []string{"user", "group"}},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	tcases := []struct {
+		resourceType, status, reason string
+	}{
+		{"instance", TypeFetched, ""},
+		{"database", TypeFetchFailed, "access denied"},
+		{"bucket", TypeFetchFailed, "timeout"},
+		{"s3object", TypeFetchDisabled, "sync disabled"},
+		{"user", TypeFetchDisabled, "sync disabled"},
+	}
+	for _, tcase := range tcases {
+		st, ok := LocalFetchStatus("default", "eu-west-1", tcase.resourceType)
+		if !ok {
+			t.Fatalf("%s: expected fetch status", tcase.resourceType)
+		}
+		if got, want := st.Status, tcase.status; got != want {
+			t.Fatalf("%s: got %s, want %s", tcase.resourceType, got, want)
+		}
+		if st.IsSynced() {
+			continue
+		}
+		if got, want := st.Reason(), tcase.reason; got != want {
+			t.Fatalf("%s: got %s, want %s", tcase.resourceType, got, want)
+		}
+	}
+
+	if _, ok := LocalFetchStatus("default", "us-east-1", "instance"); ok {
+		t.Fatal("expected unknown status in other region")
+	}
+	if _, ok := LocalFetchStatus("default", "us-east-1", "user"); !ok {
+		t.Fatal("expected status of global service in other region")
+	}
+}
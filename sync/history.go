@@ -0,0 +1,221 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/rdf"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync/repo"
+)
+
+const (
+	ResourceAppeared    = "appeared"
+	ResourceChanged     = "changed"
+	ResourceDisappeared = "disappeared"
+)
+
+// ResourceChange is a change of a resource between two sync revisions
+type ResourceChange struct {
+	Rev  *repo.Rev
+	Kind string
+	// Resource is the resource at this revision, or its last known state when it disappeared
+	Resource   cloud.Resource
+	Region     string
+	Properties []*PropertyChange
+	Relations  []*RelationChange
+}
+
+type PropertyChange struct {
+	Name     string
+	Old, New interface{}
+}
+
+type RelationChange struct {
+	Relation, Resource string
+	Added              bool
+}
+
+// historyRelations are the direct relations of a resource followed through its history, by label
+var historyRelations = map[string]string{
+	"parent":     rdf.ParentOf,
+	"applies on": rdf.ApplyOn,
+	"depends on": rdf.DependingOnRel,
+}
+
+// ResourceHistory walks all the sync revisions of a profile, oldest first, and returns the changes
+// of the resource with the given ID: when it appeared, its property and relation changes, and when it disappeared
+func ResourceHistory(r repo.Repo, profile, id string) ([]*ResourceChange, error) {
+	revs, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &historyWalker{repo: r, profile: profile, id: id, files: make(map[string]*historyFile)}
+	var changes []*ResourceChange
+	var prev *resourceSnapshot
+	for _, rev := range revs {
+		snap, err := w.snapshotAt(rev.Id)
+		if err != nil {
+			return changes, err
+		}
+		switch {
+		case prev == nil && snap != nil:
+			changes = append(changes, &ResourceChange{Rev: rev, Kind: ResourceAppeared, Resource: snap.resource, Region: snap.region})
+		case prev != nil && snap == nil:
+			changes = append(changes, &ResourceChange{Rev: rev, Kind: ResourceDisappeared, Resource: prev.resource, Region: prev.region})
+		case prev != nil && snap != nil:
+			change := &ResourceChange{Rev: rev, Kind: ResourceChanged, Resource: snap.resource, Region: snap.region}
+			change.Properties = diffProperties(prev.resource.Properties(), snap.resource.Properties())
+			change.Relations = diffRelations(prev.relations, snap.relations)
+			if len(change.Properties) > 0 || len(change.Relations) > 0 {
+				changes = append(changes, change)
+			}
+		}
+		prev = snap
+	}
+	return changes, nil
+}
+
+type resourceSnapshot struct {
+	resource  cloud.Resource
+	region    string
+	relations map[string][]string
+}
+
+// historyFile is a synced file parsed once per content, as most files do not change between revisions
+type historyFile struct {
+	hash string
+	snap *resourceSnapshot
+}
+
+type historyWalker struct {
+	repo        repo.Repo
+	profile, id string
+	files       map[string]*historyFile
+}
+
+func (w *historyWalker) snapshotAt(version string) (*resourceSnapshot, error) {
+	files, err := w.repo.ListFiles(version)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for p := range files {
+		if strings.HasPrefix(p, w.profile+"/") && path.Ext(p) == fileExt {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		cached, ok := w.files[p]
+		if !ok || cached.hash != files[p] {
+			snap, err := w.loadSnapshot(version, p)
+			if err != nil {
+				return nil, err
+			}
+			cached = &historyFile{hash: files[p], snap: snap}
+			w.files[p] = cached
+		}
+		if cached.snap != nil {
+			return cached.snap, nil
+		}
+	}
+	return nil, nil
+}
+
+func (w *historyWalker) loadSnapshot(version, file string) (*resourceSnapshot, error) {
+	content, err := w.repo.ReadFile(version, file)
+	if err != nil {
+		return nil, err
+	}
+	g := graph.NewGraph()
+	if err = g.Unmarshal(content); err != nil {
+		return nil, fmt.Errorf("loading %s at revision %s: %s", file, version, err)
+	}
+	res, err := g.FindResource(w.id)
+	if err != nil || res == nil {
+		return nil, err
+	}
+
+	snap := &resourceSnapshot{resource: res, region: path.Base(path.Dir(file)), relations: make(map[string][]string)}
+	for label, rel := range historyRelations {
+		related, err := g.ResourceRelations(res, rel, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range related {
+			snap.relations[label] = append(snap.relations[label], r.Format("%i[%t]"))
+		}
+	}
+	return snap, nil
+}
+
+func diffProperties(old, new map[string]interface{}) (changes []*PropertyChange) {
+	names := make(map[string]bool)
+	for k := range old {
+		names[k] = true
+	}
+	for k := range new {
+		names[k] = true
+	}
+	for name := range names {
+		if !reflect.DeepEqual(old[name], new[name]) {
+			changes = append(changes, &PropertyChange{Name: name, Old: old[name], New: new[name]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return
+}
+
+func diffRelations(old, new map[string][]string) (changes []*RelationChange) {
+	for label := range historyRelations {
+		for _, r := range new[label] {
+			if !contains(old[label], r) {
+				changes = append(changes, &RelationChange{Relation: label, Resource: r, Added: true})
+			}
+		}
+		for _, r := range old[label] {
+			if !contains(new[label], r) {
+				changes = append(changes, &RelationChange{Relation: label, Resource: r})
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Relation != changes[j].Relation {
+			return changes[i].Relation < changes[j].Relation
+		}
+		return changes[i].Resource < changes[j].Resource
+	})
+	return
+}
+
+func contains(arr []string, s string) bool {
+	for _, a := range arr {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package sync
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestResourceHistory(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	os.Setenv("__AWLESS_HOME", tmpDir)
+
+	syncer := NewSyncer()
+	syncInfra := func(resources ...*graph.Resource) {
+		g := graph.NewGraph()
+		if err := g.AddResource(resources...); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := syncer.Sync(&mockService{g: g, name: "infra", region: "eu-west-1", profile: "default"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	syncAccess := func(resources ...*graph.Resource) {
+		g := graph.NewGraph()
+		if err := g.AddResource(resources...); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := syncer.Sync(&mockService{g: g, name: "access", region: "global", profile: "default"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sg := resourcetest.SecurityGroup("sg_1").Build()
+	syncInfra(sg)
+	syncAccess(resourcetest.User("usr_1").Prop("Name", "jsmith").Build())
+	syncInfra(sg, resourcetest.Instance("inst_1").Prop("State", "pending").Build())
+	syncInfra(sg, resourcetest.Instance("inst_1").Prop("State", "running").Build())
+	syncAccess(resourcetest.User("usr_1").Prop("Name", "jsmith").Build(), resourcetest.User("usr_2").Build())
+
+	inst := resourcetest.Instance("inst_1").Prop("State", "running").Build()
+	g := graph.NewGraph()
+	g.AddResource(sg, inst)
+	g.AddAppliesOnRelation(sg, inst)
+	if _, err := syncer.Sync(&mockService{g: g, name: "infra", region: "eu-west-1", profile: "default"}); err != nil {
+		t.Fatal(err)
+	}
+	syncInfra(sg)
+
+	changes, err := ResourceHistory(syncer, "default", "inst_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, c := range changes {
+		line := fmt.Sprintf("%s %s %s", c.Kind, c.Resource.Id(), c.Region)
+		for _, p := range c.Properties {
+			line += fmt.Sprintf(" %s:%v->%v", p.Name, p.Old, p.New)
+		}
+		for _, r := range c.Relations {
+			line += fmt.Sprintf(" %s:%s:%t", r.Relation, r.Resource, r.Added)
+		}
+		got = append(got, line)
+	}
+	expected := []string{
+		"appeared inst_1 eu-west-1",
+		"changed inst_1 eu-west-1 State:pending->running",
+		"changed inst_1 eu-west-1 depends on:sg_1[securitygroup]:true",
+		"disappeared inst_1 eu-west-1",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("got\n%q\nwant\n%q", got, expected)
+	}
+
+	changes, err = ResourceHistory(syncer, "default", "usr_2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Kind != ResourceAppeared || changes[0].Region != "global" {
+		t.Fatalf("unexpected history of global resource: %#v", changes)
+	}
+
+	if changes, _ = ResourceHistory(syncer, "other", "inst_1"); len(changes) != 0 {
+		t.Fatalf("expected no history for other profile, got %d changes", len(changes))
+	}
+}
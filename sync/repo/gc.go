@@ -0,0 +1,296 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/filemode"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+)
+
+// Retention is the number of revisions to keep: the last revision of each of the
+// latest hours, days and months having revisions. The last revision is always kept
+type Retention struct {
+	Hourly, Daily, Monthly int
+}
+
+// Keep returns the revisions to keep among the given revisions, both ordered from the first one
+// (as commit dates have a one second precision, the order cannot be derived from the dates)
+func (r Retention) Keep(revs []*Rev) []*Rev {
+	if len(revs) == 0 {
+		return nil
+	}
+	periods := []struct {
+		layout string
+		count  int
+		last   string
+	}{
+		{layout: "2006-01-02 15", count: r.Hourly},
+		{layout: "2006-01-02", count: r.Daily},
+		{layout: "2006-01", count: r.Monthly},
+	}
+
+	keep := map[*Rev]bool{revs[len(revs)-1]: true}
+	for i := len(revs) - 1; i >= 0; i-- {
+		rev := revs[i]
+		for j := range periods {
+			p := &periods[j]
+			if period := rev.Date.Format(p.layout); p.count > 0 && period != p.last {
+				keep[rev] = true
+				p.last = period
+				p.count--
+			}
+		}
+	}
+
+	var kept []*Rev
+	for _, rev := range revs {
+		if keep[rev] {
+			kept = append(kept, rev)
+		}
+	}
+	return kept
+}
+
+// GC rewrites the history down to the revisions kept by the retention, and returns the number of removed revisions.
+// As go-git cannot prune unreachable objects, the kept revisions are copied into a new repository replacing the current one.
+// The revisions up to the last one pushed to or pulled from a remote store are kept unchanged, so that the next push
+// still fast-forwards. With force they are rewritten too: the remote store then diverges from the local one
+func (r *gitRepo) GC(retention Retention, force bool) (int, error) {
+	unlock, err := r.lock()
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	revs, err := r.List()
+	if err != nil || len(revs) == 0 {
+		return 0, err
+	}
+	shared, err := r.sharedRevision()
+	if err != nil {
+		return 0, err
+	}
+	var unchanged int
+	if !force && !shared.IsZero() {
+		for i, rev := range revs {
+			if rev.Id == shared.String() {
+				unchanged = i + 1
+			}
+		}
+	}
+	rewritten := revs[unchanged:]
+	kept := retention.Keep(rewritten)
+	if len(kept) == len(rewritten) {
+		return 0, nil
+	}
+
+	head, err := r.repo.Head()
+	if err != nil {
+		return 0, err
+	}
+	gcDir := filepath.Join(r.basedir, ".gc")
+	if err = os.RemoveAll(gcDir); err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(gcDir)
+	compacted, err := git.PlainInit(gcDir, false)
+	if err != nil {
+		return 0, err
+	}
+
+	copied := make(map[plumbing.Hash]bool)
+	parent := plumbing.ZeroHash
+	if unchanged > 0 {
+		if err = copyHistory(r.repo, compacted.Storer, shared, copied); err != nil {
+			return 0, fmt.Errorf("copying shared revisions: %s", err)
+		}
+		if err = compacted.Storer.SetReference(plumbing.NewHashReference(sharedRef, shared)); err != nil {
+			return 0, err
+		}
+		parent = shared
+	}
+	for _, rev := range kept {
+		commit, err := r.repo.CommitObject(plumbing.NewHash(rev.Id))
+		if err != nil {
+			return 0, err
+		}
+		if err = copyTree(r.repo.Storer, compacted.Storer, commit.TreeHash, copied); err != nil {
+			return 0, fmt.Errorf("copying revision %s: %s", rev.Id, err)
+		}
+		rewritten := &object.Commit{Author: commit.Author, Committer: commit.Committer, Message: commit.Message, TreeHash: commit.TreeHash}
+		if !parent.IsZero() {
+			rewritten.ParentHashes = []plumbing.Hash{parent}
+		}
+		obj := compacted.Storer.NewEncodedObject()
+		if err = rewritten.Encode(obj); err != nil {
+			return 0, err
+		}
+		if parent, err = compacted.Storer.SetEncodedObject(obj); err != nil {
+			return 0, err
+		}
+	}
+	if err = compacted.Storer.SetReference(plumbing.NewHashReference(head.Name(), parent)); err != nil {
+		return 0, err
+	}
+	// the last revision is kept, so the index still matches the files in the working directory
+	idx, err := r.repo.Storer.Index()
+	if err != nil {
+		return 0, err
+	}
+	if err = compacted.Storer.SetIndex(idx); err != nil {
+		return 0, err
+	}
+
+	gitDir, oldGitDir := filepath.Join(r.basedir, ".git"), filepath.Join(r.basedir, ".git.old")
+	if err = os.Rename(gitDir, oldGitDir); err != nil {
+		return 0, err
+	}
+	if err = os.Rename(filepath.Join(gcDir, ".git"), gitDir); err != nil {
+		if rerr := os.Rename(oldGitDir, gitDir); rerr != nil {
+			return 0, fmt.Errorf("%s; cannot restore repository from %s: %s", err, oldGitDir, rerr)
+		}
+		return 0, err
+	}
+	if r.repo, err = git.PlainOpen(r.basedir); err != nil {
+		return 0, err
+	}
+	return len(rewritten) - len(kept), os.RemoveAll(oldGitDir)
+}
+
+// copyHistory copies a revision and its ancestors unchanged
+func copyHistory(from *git.Repository, to storer.EncodedObjectStorer, h plumbing.Hash, copied map[plumbing.Hash]bool) error {
+	commit, err := from.CommitObject(h)
+	if err != nil {
+		return err
+	}
+	return object.NewCommitPreorderIter(commit, nil).ForEach(func(c *object.Commit) error {
+		if err := copyTree(from.Storer, to, c.TreeHash, copied); err != nil {
+			return err
+		}
+		return copyObject(from.Storer, to, plumbing.CommitObject, c.Hash, copied)
+	})
+}
+
+func copyTree(from, to storer.EncodedObjectStorer, h plumbing.Hash, copied map[plumbing.Hash]bool) error {
+	if copied[h] {
+		return nil
+	}
+	tree, err := object.GetTree(from, h)
+	if err != nil {
+		return err
+	}
+	for _, e := range tree.Entries {
+		if e.Mode == filemode.Dir {
+			err = copyTree(from, to, e.Hash, copied)
+		} else {
+			err = copyObject(from, to, plumbing.BlobObject, e.Hash, copied)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return copyObject(from, to, plumbing.TreeObject, h, copied)
+}
+
+func copyObject(from, to storer.EncodedObjectStorer, t plumbing.ObjectType, h plumbing.Hash, copied map[plumbing.Hash]bool) error {
+	if copied[h] {
+		return nil
+	}
+	obj, err := from.EncodedObject(t, h)
+	if err != nil {
+		return err
+	}
+	if _, err = to.SetEncodedObject(obj); err != nil {
+		return err
+	}
+	copied[h] = true
+	return nil
+}
+
+// Stats are the disk usage and content of the repository
+type Stats struct {
+	Size      int64
+	Revisions int
+	Files     []*FileStats
+}
+
+// FileStats are the size of a synced file at the last revision and its number of distinct versions in history
+type FileStats struct {
+	Path     string
+	Size     int64
+	Versions int
+}
+
+func (r *gitRepo) Stats() (*Stats, error) {
+	stats := &Stats{}
+	err := filepath.Walk(filepath.Join(r.basedir, ".git"), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			stats.Size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return stats, err
+	}
+
+	revs, err := r.List()
+	if err != nil || len(revs) == 0 {
+		return stats, err
+	}
+	stats.Revisions = len(revs)
+
+	versions := make(map[string]map[string]bool)
+	for _, rev := range revs {
+		files, err := r.ListFiles(rev.Id)
+		if err != nil {
+			return stats, err
+		}
+		for path, hash := range files {
+			if versions[path] == nil {
+				versions[path] = make(map[string]bool)
+			}
+			versions[path][hash] = true
+		}
+	}
+
+	last, err := r.repo.CommitObject(plumbing.NewHash(revs[len(revs)-1].Id))
+	if err != nil {
+		return stats, err
+	}
+	iter, err := last.Files()
+	if err != nil {
+		return stats, err
+	}
+	defer iter.Close()
+	err = iter.ForEach(func(f *object.File) error {
+		stats.Files = append(stats.Files, &FileStats{Path: f.Name, Size: f.Size, Versions: len(versions[f.Name])})
+		return nil
+	})
+	sort.Slice(stats.Files, func(i, j int) bool { return stats.Files[i].Path < stats.Files[j].Path })
+	return stats, err
+}
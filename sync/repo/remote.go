@@ -0,0 +1,691 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/format/objfile"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/revlist"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+)
+
+const remoteName = "awless-remote"
+
+// ErrDiverged is returned when the revisions of the local and remote stores cannot be fast-forwarded
+var ErrDiverged = errors.New("local and remote revisions have diverged")
+
+// ErrConcurrentPush is returned when the remote store was updated by another push meanwhile.
+// The revisions pushed are then not published
+var ErrConcurrentPush = errors.New("remote store updated by a concurrent push: pull and push again")
+
+// Remote is a store where the revisions of the local store are shared, for a team to work
+// with the same synced inventory. Pushing and pulling revisions only fast-forward
+type Remote interface {
+	// push sends the local revisions missing in the remote store
+	push(r *gitRepo) (bool, error)
+	// fetch gets the remote revisions missing in the local store, and returns the last remote revision (zero when none)
+	fetch(r *gitRepo) (plumbing.Hash, error)
+}
+
+// sharedRef is the last revision known to be in the remote store, that gc does not rewrite
+const sharedRef = plumbing.ReferenceName("refs/awless/shared")
+
+func (r *gitRepo) Push(remote Remote) (bool, error) {
+	unlock, err := r.lock()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	pushed, err := remote.push(r)
+	if err != nil {
+		return pushed, err
+	}
+	head, err := r.repo.Head()
+	if err != nil {
+		return pushed, err
+	}
+	return pushed, r.repo.Storer.SetReference(plumbing.NewHashReference(sharedRef, head.Hash()))
+}
+
+// sharedRevision returns the last revision known to be in the remote store, zero when none
+func (r *gitRepo) sharedRevision() (plumbing.Hash, error) {
+	ref, err := r.repo.Storer.Reference(sharedRef)
+	if err == plumbing.ErrReferenceNotFound {
+		return plumbing.ZeroHash, nil
+	} else if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return ref.Hash(), nil
+}
+
+// Pull fast-forwards the local store to the last remote revision.
+// With force, the local revisions are replaced by the remote ones when they diverged
+func (r *gitRepo) Pull(remote Remote, force bool) (bool, error) {
+	unlock, err := r.lock()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	last, err := remote.fetch(r)
+	if err != nil {
+		return false, err
+	}
+	if last.IsZero() {
+		return false, errors.New("no revision in remote store")
+	}
+	head, err := r.repo.Head()
+	switch {
+	case err == plumbing.ErrReferenceNotFound:
+	case err != nil:
+		return false, err
+	case head.Hash() == last:
+		return false, r.repo.Storer.SetReference(plumbing.NewHashReference(sharedRef, last))
+	default:
+		ff, err := r.isAncestor(head.Hash(), last)
+		if err != nil {
+			return false, err
+		}
+		if !ff && !force {
+			return false, ErrDiverged
+		}
+	}
+
+	branch, err := r.branch()
+	if err != nil {
+		return false, err
+	}
+	if err = r.repo.Storer.SetReference(plumbing.NewHashReference(branch, last)); err != nil {
+		return false, err
+	}
+	if err = r.repo.Storer.SetReference(plumbing.NewHashReference(sharedRef, last)); err != nil {
+		return false, err
+	}
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return false, err
+	}
+	return true, wt.Reset(&git.ResetOptions{Commit: last, Mode: git.HardReset})
+}
+
+// branch returns the branch of HEAD, existing or not
+func (r *gitRepo) branch() (plumbing.ReferenceName, error) {
+	head, err := r.repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return "", err
+	}
+	if head.Type() != plumbing.SymbolicReference {
+		return "", errors.New("local store HEAD is not a branch")
+	}
+	return head.Target(), nil
+}
+
+// isAncestor returns true when the revision old is in the history of the revision new
+func (r *gitRepo) isAncestor(old, new plumbing.Hash) (bool, error) {
+	if _, err := r.repo.Storer.EncodedObject(plumbing.CommitObject, old); err == plumbing.ErrObjectNotFound {
+		return false, nil
+	}
+	commit, err := r.repo.CommitObject(new)
+	if err != nil {
+		return false, err
+	}
+	var found bool
+	err = object.NewCommitPreorderIter(commit, nil).ForEach(func(c *object.Commit) error {
+		if c.Hash == old {
+			found = true
+			return errStopIter
+		}
+		return nil
+	})
+	if err == errStopIter {
+		err = nil
+	}
+	return found, err
+}
+
+var errStopIter = errors.New("stop iteration")
+
+type gitRemote struct {
+	url string
+}
+
+// NewGitRemote returns a remote store being a git repository (i.e. a bare repository) at the given URL or path
+func NewGitRemote(url string) Remote {
+	if path, ok := localPath(url); ok {
+		return &localGitRemote{path: path}
+	}
+	return &gitRemote{url: url}
+}
+
+// localPath returns the path of a git URL on the local filesystem
+func localPath(url string) (string, bool) {
+	if strings.HasPrefix(url, "file://") {
+		return strings.TrimPrefix(url, "file://"), true
+	}
+	if strings.Contains(url, "://") {
+		return "", false
+	}
+	// scp-like syntax, i.e. git@github.com:team/inventory.git
+	if i := strings.Index(url, ":"); i > 0 && !strings.Contains(url[:i], "/") {
+		return "", false
+	}
+	return url, true
+}
+
+func (g *gitRemote) configure(r *gitRepo) error {
+	if remote, err := r.repo.Remote(remoteName); err == nil {
+		if remote.Config().URL == g.url {
+			return nil
+		}
+		if err = r.repo.DeleteRemote(remoteName); err != nil {
+			return err
+		}
+	}
+	_, err := r.repo.CreateRemote(&config.RemoteConfig{
+		Name:  remoteName,
+		URL:   g.url,
+		Fetch: []config.RefSpec{config.RefSpec(fmt.Sprintf("+refs/heads/*:refs/remotes/%s/*", remoteName))},
+	})
+	return err
+}
+
+func (g *gitRemote) push(r *gitRepo) (bool, error) {
+	head, err := r.repo.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		return false, errors.New("no revision in local store")
+	} else if err != nil {
+		return false, err
+	}
+	last, err := g.fetch(r)
+	if err != nil {
+		return false, err
+	}
+	if last == head.Hash() {
+		return false, nil
+	}
+	if !last.IsZero() {
+		if ff, err := r.isAncestor(last, head.Hash()); err != nil {
+			return false, err
+		} else if !ff {
+			return false, ErrDiverged
+		}
+	}
+	spec := config.RefSpec(fmt.Sprintf("%s:%s", head.Name(), head.Name()))
+	if err = r.repo.Push(&git.PushOptions{RemoteName: remoteName, RefSpecs: []config.RefSpec{spec}}); err == git.NoErrAlreadyUpToDate {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (g *gitRemote) fetch(r *gitRepo) (plumbing.Hash, error) {
+	if err := g.configure(r); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	err := r.repo.Fetch(&git.FetchOptions{RemoteName: remoteName})
+	switch err {
+	case nil, git.NoErrAlreadyUpToDate:
+	case transport.ErrEmptyRemoteRepository:
+		return plumbing.ZeroHash, nil
+	default:
+		return plumbing.ZeroHash, err
+	}
+	branch, err := r.branch()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	remoteBranch := plumbing.ReferenceName(fmt.Sprintf("refs/remotes/%s/%s", remoteName, branch.Short()))
+	ref, err := r.repo.Reference(remoteBranch, true)
+	if err == plumbing.ErrReferenceNotFound {
+		return plumbing.ZeroHash, nil
+	} else if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return ref.Hash(), nil
+}
+
+const localPushLock = "awless-push.lock"
+
+// localGitRemote is a git repository on the local filesystem, whose objects are copied directly
+// rather than through the file transport, as recent git binaries advertise capabilities unknown to go-git
+type localGitRemote struct {
+	path string
+}
+
+func (l *localGitRemote) open() (*git.Repository, error) {
+	remote, err := git.PlainOpen(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("opening remote store %s: %s", l.path, err)
+	}
+	return remote, nil
+}
+
+func (l *localGitRemote) lastRevision(remote *git.Repository, branch plumbing.ReferenceName) (plumbing.Hash, error) {
+	ref, err := remote.Storer.Reference(branch)
+	if err == plumbing.ErrReferenceNotFound {
+		return plumbing.ZeroHash, nil
+	} else if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return ref.Hash(), nil
+}
+
+func (l *localGitRemote) push(r *gitRepo) (bool, error) {
+	head, err := r.repo.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		return false, errors.New("no revision in local store")
+	} else if err != nil {
+		return false, err
+	}
+	remote, err := l.open()
+	if err != nil {
+		return false, err
+	}
+	// the remote branch is checked and updated by one push at a time.
+	// A lock older than staleLockAge is left by a killed push and is taken over
+	lockPath := filepath.Join(l.path, localPushLock)
+	lock, err := os.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if info, serr := os.Stat(lockPath); os.IsExist(err) && serr == nil && time.Since(info.ModTime()) > staleLockAge {
+		os.Remove(lockPath)
+		lock, err = os.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	}
+	if os.IsExist(err) {
+		return false, ErrConcurrentPush
+	} else if err != nil {
+		return false, err
+	}
+	defer os.Remove(lock.Name())
+	lock.Close()
+
+	last, err := l.lastRevision(remote, head.Name())
+	if err != nil {
+		return false, err
+	}
+	if last == head.Hash() {
+		return false, nil
+	}
+	var known []plumbing.Hash
+	if !last.IsZero() {
+		if ff, err := r.isAncestor(last, head.Hash()); err != nil {
+			return false, err
+		} else if !ff {
+			return false, ErrDiverged
+		}
+		known = append(known, last)
+	}
+	hashes, err := revlist.Objects(r.repo.Storer, []plumbing.Hash{head.Hash()}, known)
+	if err != nil {
+		return false, err
+	}
+	copied := make(map[plumbing.Hash]bool)
+	for _, h := range hashes {
+		if err = copyObject(r.repo.Storer, remote.Storer, plumbing.AnyObject, h, copied); err != nil {
+			return false, fmt.Errorf("pushing object %s: %s", h, err)
+		}
+	}
+	return true, remote.Storer.SetReference(plumbing.NewHashReference(head.Name(), head.Hash()))
+}
+
+func (l *localGitRemote) fetch(r *gitRepo) (plumbing.Hash, error) {
+	remote, err := l.open()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	branch, err := r.branch()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	last, err := l.lastRevision(remote, branch)
+	if err != nil || last.IsZero() {
+		return last, err
+	}
+	return last, r.copyMissing(last, func(h plumbing.Hash) (plumbing.EncodedObject, error) {
+		return remote.Storer.EncodedObject(plumbing.AnyObject, h)
+	})
+}
+
+// ErrNoSuchKey is returned by object stores getting a missing key
+var ErrNoSuchKey = errors.New("no such key")
+
+// ErrConflict is returned by object stores on a conditional write of a key changed since read
+var ErrConflict = errors.New("key changed since read")
+
+// ObjectStore is a key/value store, such as an S3 bucket, where revisions can be shared
+type ObjectStore interface {
+	Get(key string) ([]byte, error)
+	Put(key string, content []byte) error
+	// GetVersion returns the content of a key with its version (i.e. S3 ETag)
+	GetVersion(key string) ([]byte, string, error)
+	// PutIfVersion writes a key only if still at the given version, or still missing when the version is empty.
+	// It returns ErrConflict otherwise
+	PutIfVersion(key string, content []byte, version string) error
+}
+
+const remoteHeadKey = "HEAD"
+
+type objectStoreRemote struct {
+	store ObjectStore
+}
+
+// NewObjectStoreRemote returns a remote store sharing the git objects of the revisions
+// in the given object store, with the key HEAD holding the last revision
+func NewObjectStoreRemote(store ObjectStore) Remote {
+	return &objectStoreRemote{store: store}
+}
+
+// lastRevision returns the last remote revision (zero when none) with the version of the HEAD key
+func (o *objectStoreRemote) lastRevision() (plumbing.Hash, string, error) {
+	b, version, err := o.store.GetVersion(remoteHeadKey)
+	if err == ErrNoSuchKey {
+		return plumbing.ZeroHash, "", nil
+	} else if err != nil {
+		return plumbing.ZeroHash, "", err
+	}
+	return plumbing.NewHash(strings.TrimSpace(string(b))), version, nil
+}
+
+func (o *objectStoreRemote) push(r *gitRepo) (bool, error) {
+	head, err := r.repo.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		return false, errors.New("no revision in local store")
+	} else if err != nil {
+		return false, err
+	}
+	last, version, err := o.lastRevision()
+	if err != nil {
+		return false, err
+	}
+	if last == head.Hash() {
+		return false, nil
+	}
+	var known []plumbing.Hash
+	if !last.IsZero() {
+		if ff, err := r.isAncestor(last, head.Hash()); err != nil {
+			return false, err
+		} else if !ff {
+			return false, ErrDiverged
+		}
+		known = append(known, last)
+	}
+
+	hashes, err := revlist.Objects(r.repo.Storer, []plumbing.Hash{head.Hash()}, known)
+	if err != nil {
+		return false, err
+	}
+	for _, h := range hashes {
+		if err = o.upload(r, h); err != nil {
+			return false, fmt.Errorf("pushing object %s: %s", h, err)
+		}
+	}
+	// the last revision is published once all its objects are available,
+	// only if no other push published a revision meanwhile
+	err = o.store.PutIfVersion(remoteHeadKey, []byte(head.Hash().String()), version)
+	if err == ErrConflict {
+		return false, ErrConcurrentPush
+	} else if err != nil {
+		return false, err
+	}
+	// read back in case the store ignores conditional writes (i.e. some S3 compatible servers)
+	published, _, err := o.lastRevision()
+	if err != nil {
+		return false, err
+	}
+	if published != head.Hash() {
+		return false, ErrConcurrentPush
+	}
+	return true, nil
+}
+
+func (o *objectStoreRemote) upload(r *gitRepo, h plumbing.Hash) error {
+	obj, err := r.repo.Storer.EncodedObject(plumbing.AnyObject, h)
+	if err != nil {
+		return err
+	}
+	reader, err := obj.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	var buf bytes.Buffer
+	w := objfile.NewWriter(&buf)
+	if err = w.WriteHeader(obj.Type(), obj.Size()); err != nil {
+		return err
+	}
+	if _, err = io.Copy(w, reader); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return o.store.Put("objects/"+h.String(), buf.Bytes())
+}
+
+func (o *objectStoreRemote) fetch(r *gitRepo) (plumbing.Hash, error) {
+	last, _, err := o.lastRevision()
+	if err != nil || last.IsZero() {
+		return last, err
+	}
+	return last, r.copyMissing(last, func(h plumbing.Hash) (plumbing.EncodedObject, error) {
+		return o.get(r, h)
+	})
+}
+
+// copyMissing gets the objects of a revision and its ancestors missing in the local store.
+// Objects are stored once all the objects they reference are: blobs and subtrees before their tree,
+// trees and parents before their commit. A pull interrupted midway thus never leaves in the local store
+// a commit whose tree or history is incomplete, which later pulls would skip as present
+func (r *gitRepo) copyMissing(last plumbing.Hash, get func(plumbing.Hash) (plumbing.EncodedObject, error)) error {
+	var missing []*object.Commit
+	objects := make(map[plumbing.Hash]plumbing.EncodedObject)
+	commits := []plumbing.Hash{last}
+	for len(commits) > 0 {
+		h := commits[0]
+		commits = commits[1:]
+		if _, seen := objects[h]; seen || r.hasObject(h) {
+			continue
+		}
+		obj, err := get(h)
+		if err != nil {
+			return err
+		}
+		commit, err := object.DecodeCommit(r.repo.Storer, obj)
+		if err != nil {
+			return err
+		}
+		if err = r.copyMissingTree(commit.TreeHash, get); err != nil {
+			return err
+		}
+		objects[h] = obj
+		missing = append(missing, commit)
+		commits = append(commits, commit.ParentHashes...)
+	}
+	// ancestors are found last: store from the oldest, each commit once its parents are stored
+	for len(missing) > 0 {
+		var pending []*object.Commit
+		for i := len(missing) - 1; i >= 0; i-- {
+			commit := missing[i]
+			if !r.hasObjects(commit.ParentHashes) {
+				pending = append([]*object.Commit{commit}, pending...)
+				continue
+			}
+			if _, err := r.repo.Storer.SetEncodedObject(objects[commit.Hash]); err != nil {
+				return err
+			}
+		}
+		if len(pending) == len(missing) {
+			return fmt.Errorf("pulling revision %s: missing parents of commit %s", last, pending[0].Hash)
+		}
+		missing = pending
+	}
+	return nil
+}
+
+// copyMissingTree gets a tree missing in the local store with its missing subtrees and blobs, storing it last
+func (r *gitRepo) copyMissingTree(h plumbing.Hash, get func(plumbing.Hash) (plumbing.EncodedObject, error)) error {
+	if r.hasObject(h) {
+		return nil
+	}
+	obj, err := get(h)
+	if err != nil {
+		return err
+	}
+	tree, err := object.DecodeTree(r.repo.Storer, obj)
+	if err != nil {
+		return err
+	}
+	for _, e := range tree.Entries {
+		if !e.Mode.IsFile() {
+			err = r.copyMissingTree(e.Hash, get)
+		} else if !r.hasObject(e.Hash) {
+			err = r.storeObject(get, e.Hash)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = r.repo.Storer.SetEncodedObject(obj)
+	return err
+}
+
+func (r *gitRepo) storeObject(get func(plumbing.Hash) (plumbing.EncodedObject, error), h plumbing.Hash) error {
+	obj, err := get(h)
+	if err != nil {
+		return err
+	}
+	_, err = r.repo.Storer.SetEncodedObject(obj)
+	return err
+}
+
+// get reads an object of the remote store, without storing it
+func (o *objectStoreRemote) get(r *gitRepo, h plumbing.Hash) (plumbing.EncodedObject, error) {
+	b, err := o.store.Get("objects/" + h.String())
+	if err != nil {
+		return nil, fmt.Errorf("pulling object %s: %s", h, err)
+	}
+	reader, err := objfile.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	t, size, err := reader.Header()
+	if err != nil {
+		return nil, err
+	}
+
+	obj := r.repo.Storer.NewEncodedObject()
+	obj.SetType(t)
+	obj.SetSize(size)
+	w, err := obj.Writer()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(w, reader); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	if obj.Hash() != h {
+		return nil, fmt.Errorf("corrupted object %s in remote store", h)
+	}
+	return obj, nil
+}
+
+func (r *gitRepo) hasObjects(hashes []plumbing.Hash) bool {
+	for _, h := range hashes {
+		if !r.hasObject(h) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *gitRepo) hasObject(h plumbing.Hash) bool {
+	_, err := r.repo.Storer.EncodedObject(plumbing.AnyObject, h)
+	return err == nil
+}
+
+type s3ObjectStore struct {
+	api            s3iface.S3API
+	bucket, prefix string
+}
+
+// NewS3ObjectStore returns an object store in a S3 (or S3 compatible) bucket, with keys under the given prefix
+func NewS3ObjectStore(api s3iface.S3API, bucket, prefix string) ObjectStore {
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		prefix = prefix + "/"
+	}
+	return &s3ObjectStore{api: api, bucket: bucket, prefix: prefix}
+}
+
+func (s *s3ObjectStore) Get(key string) ([]byte, error) {
+	b, _, err := s.GetVersion(key)
+	return b, err
+}
+
+func (s *s3ObjectStore) GetVersion(key string) ([]byte, string, error) {
+	out, err := s.api.GetObject(&s3.GetObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(s.prefix + key)})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+		return nil, "", ErrNoSuchKey
+	} else if err != nil {
+		return nil, "", err
+	}
+	defer out.Body.Close()
+	b, err := ioutil.ReadAll(out.Body)
+	return b, aws.StringValue(out.ETag), err
+}
+
+func (s *s3ObjectStore) Put(key string, content []byte) error {
+	_, err := s.api.PutObject(&s3.PutObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(s.prefix + key), Body: bytes.NewReader(content)})
+	return err
+}
+
+// PutIfVersion writes with the If-Match (or If-None-Match when missing) header of S3 conditional writes
+func (s *s3ObjectStore) PutIfVersion(key string, content []byte, version string) error {
+	condition := func(r *request.Request) {
+		if version == "" {
+			r.HTTPRequest.Header.Set("If-None-Match", "*")
+		} else {
+			r.HTTPRequest.Header.Set("If-Match", version)
+		}
+	}
+	input := &s3.PutObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(s.prefix + key), Body: bytes.NewReader(content)}
+	_, err := s.api.PutObjectWithContext(aws.BackgroundContext(), input, condition)
+	if aerr, ok := err.(awserr.Error); ok && (aerr.Code() == "PreconditionFailed" || aerr.Code() == "ConditionalRequestConflict") {
+		return ErrConflict
+	}
+	return err
+}
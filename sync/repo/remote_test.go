@@ -0,0 +1,425 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+)
+
+func TestRemotes(t *testing.T) {
+	t.Run("git remote", func(t *testing.T) {
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		if _, err := git.PlainInit(dir, true); err != nil {
+			t.Fatal(err)
+		}
+		testRemote(t, NewGitRemote(dir))
+	})
+	t.Run("object store remote", func(t *testing.T) {
+		testRemote(t, NewObjectStoreRemote(newMemObjectStore()))
+	})
+	t.Run("s3 remote", func(t *testing.T) {
+		api := &mockS3{store: newMemObjectStore()}
+		testRemote(t, NewObjectStoreRemote(NewS3ObjectStore(api, "team-bucket", "/awless/")))
+		if _, ok := api.store.objects["team-bucket/awless/HEAD"]; !ok {
+			t.Fatalf("expected HEAD under prefix, got %d keys", len(api.store.objects))
+		}
+	})
+}
+
+func TestConcurrentPush(t *testing.T) {
+	for _, ignoreConditions := range []bool{false, true} {
+		dir := tempDir(t)
+		defer os.RemoveAll(dir)
+		r := newTestRepo(t, dir)
+		commitFile(t, r, "infra.nt", "instances 1")
+
+		store := &racingStore{memObjectStore: newMemObjectStore(), ignoreConditions: ignoreConditions}
+		if _, err := r.Push(NewObjectStoreRemote(store)); err != ErrConcurrentPush {
+			t.Fatalf("ignore conditions %t: got %v, want %v", ignoreConditions, err, ErrConcurrentPush)
+		}
+		if got, want := string(store.objects[remoteHeadKey]), "6ecf0ef2c2dffb796033e5a02219af86ec6584e5"; got != want {
+			t.Fatalf("ignore conditions %t: got %s, want %s", ignoreConditions, got, want)
+		}
+	}
+
+	t.Run("local git remote", func(t *testing.T) {
+		remoteDir, dir := tempDir(t), tempDir(t)
+		defer os.RemoveAll(remoteDir)
+		defer os.RemoveAll(dir)
+		if _, err := git.PlainInit(remoteDir, true); err != nil {
+			t.Fatal(err)
+		}
+		r := newTestRepo(t, dir)
+		commitFile(t, r, "infra.nt", "instances 1")
+		if err := ioutil.WriteFile(filepath.Join(remoteDir, localPushLock), nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.Push(NewGitRemote(remoteDir)); err != ErrConcurrentPush {
+			t.Fatalf("got %v, want %v", err, ErrConcurrentPush)
+		}
+
+		stale := time.Now().Add(-2 * staleLockAge)
+		if err := os.Chtimes(filepath.Join(remoteDir, localPushLock), stale, stale); err != nil {
+			t.Fatal(err)
+		}
+		if pushed, err := r.Push(NewGitRemote(remoteDir)); err != nil || !pushed {
+			t.Fatalf("expected stale lock taken over, got %t, %v", pushed, err)
+		}
+		if _, err := os.Stat(filepath.Join(remoteDir, localPushLock)); !os.IsNotExist(err) {
+			t.Fatalf("expected lock removed after push, got %v", err)
+		}
+	})
+}
+
+func TestInterruptedPull(t *testing.T) {
+	aliceDir, bobDir := tempDir(t), tempDir(t)
+	defer os.RemoveAll(aliceDir)
+	defer os.RemoveAll(bobDir)
+	alice, bob := newTestRepo(t, aliceDir), newTestRepo(t, bobDir)
+
+	store := newMemObjectStore()
+	commitFile(t, alice, "infra.nt", "instances 1")
+	commitFile(t, alice, "access.nt", "users")
+	if _, err := alice.Push(NewObjectStoreRemote(store)); err != nil {
+		t.Fatal(err)
+	}
+	head := plumbing.NewHash(string(store.objects[remoteHeadKey]))
+
+	// each interrupted pull stores some objects, resumed by the next one
+	for failAfter := 1; ; failAfter++ {
+		interrupted := &failingStore{memObjectStore: store, failAfter: failAfter}
+		if _, err := bob.Pull(NewObjectStoreRemote(interrupted), false); err == nil {
+			break
+		}
+		if bob.(*gitRepo).hasObject(head) {
+			t.Fatalf("fail after %d: commit stored before its tree and parents", failAfter)
+		}
+		if failAfter > 20 {
+			t.Fatal("pull never completed")
+		}
+	}
+	checkFile(t, bobDir, "infra.nt", "instances 1")
+	checkFile(t, bobDir, "access.nt", "users")
+}
+
+func TestGCKeepsSharedRevisions(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	r := newTestRepo(t, dir)
+	for i := 0; i < 3; i++ {
+		commitFile(t, r, "infra.nt", fmt.Sprintf("instances %d", i))
+	}
+	remote := NewObjectStoreRemote(newMemObjectStore())
+	if _, err := r.Push(remote); err != nil {
+		t.Fatal(err)
+	}
+	shared, err := r.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 3; i < 6; i++ {
+		commitFile(t, r, "infra.nt", fmt.Sprintf("instances %d", i))
+	}
+
+	removed, err := r.GC(Retention{Hourly: 1}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := removed, 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	revs, err := r.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(revs), 4; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	for i, rev := range shared {
+		if got, want := revs[i].Id, rev.Id; got != want {
+			t.Fatalf("revision %d: got %s, want %s", i, got, want)
+		}
+	}
+	if _, err = r.Push(remote); err != nil {
+		t.Fatalf("expected fast-forward push after gc, got %s", err)
+	}
+
+	commitFile(t, r, "infra.nt", "instances 6")
+	if _, err = r.GC(Retention{Hourly: 1}, true); err != nil {
+		t.Fatal(err)
+	}
+	if revs, _ = r.List(); len(revs) != 1 {
+		t.Fatalf("expected all revisions rewritten with force, got %d", len(revs))
+	}
+	if _, err = r.Push(remote); err != ErrDiverged {
+		t.Fatalf("got %v, want %v", err, ErrDiverged)
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	tcases := []struct {
+		url   string
+		path  string
+		local bool
+	}{
+		{url: "/srv/git/inventory.git", path: "/srv/git/inventory.git", local: true},
+		{url: "file:///srv/git/inventory.git", path: "/srv/git/inventory.git", local: true},
+		{url: "../inventory", path: "../inventory", local: true},
+		{url: "https://github.com/team/inventory.git"},
+		{url: "ssh://git@github.com/team/inventory.git"},
+		{url: "git@github.com:team/inventory.git"},
+	}
+	for _, tcase := range tcases {
+		path, local := localPath(tcase.url)
+		if path != tcase.path || local != tcase.local {
+			t.Fatalf("%s: got %s, %t, want %s, %t", tcase.url, path, local, tcase.path, tcase.local)
+		}
+	}
+}
+
+func testRemote(t *testing.T, remote Remote) {
+	aliceDir, bobDir := tempDir(t), tempDir(t)
+	defer os.RemoveAll(aliceDir)
+	defer os.RemoveAll(bobDir)
+	alice, bob := newTestRepo(t, aliceDir), newTestRepo(t, bobDir)
+
+	if _, err := bob.Pull(remote, false); err == nil {
+		t.Fatal("expected error when pulling empty remote")
+	}
+
+	commitFile(t, alice, "infra.nt", "instances 1")
+	pushed, err := alice.Push(remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pushed {
+		t.Fatal("expected revisions pushed")
+	}
+	if pushed, err = alice.Push(remote); err != nil || pushed {
+		t.Fatalf("expected nothing to push, got %t, %v", pushed, err)
+	}
+
+	pulled, err := bob.Pull(remote, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pulled {
+		t.Fatal("expected revisions pulled")
+	}
+	checkFile(t, bobDir, "infra.nt", "instances 1")
+
+	commitFile(t, alice, "infra.nt", "instances 2")
+	commitFile(t, alice, "access.nt", "users")
+	if _, err = alice.Push(remote); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = bob.Pull(remote, false); err != nil {
+		t.Fatal(err)
+	}
+	checkFile(t, bobDir, "infra.nt", "instances 2")
+	checkFile(t, bobDir, "access.nt", "users")
+	revs, err := bob.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(revs), 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	commitFile(t, alice, "infra.nt", "instances 3")
+	commitFile(t, bob, "infra.nt", "instances 4")
+	if _, err = alice.Push(remote); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = bob.Push(remote); err != ErrDiverged {
+		t.Fatalf("got %v, want %v", err, ErrDiverged)
+	}
+	if _, err = bob.Pull(remote, false); err != ErrDiverged {
+		t.Fatalf("got %v, want %v", err, ErrDiverged)
+	}
+	if _, err = bob.Pull(remote, true); err != nil {
+		t.Fatal(err)
+	}
+	checkFile(t, bobDir, "infra.nt", "instances 3")
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func newTestRepo(t *testing.T, dir string) Repo {
+	r, err := newGitRepo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func commitFile(t *testing.T, r Repo, file, content string) {
+	if err := ioutil.WriteFile(filepath.Join(r.BaseDir(), file), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Commit(file); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkFile(t *testing.T, dir, file, content string) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), content; got != want {
+		t.Fatalf("%s: got %s, want %s", file, got, want)
+	}
+}
+
+type memObjectStore struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	versions map[string]int
+}
+
+func newMemObjectStore() *memObjectStore {
+	return &memObjectStore{objects: make(map[string][]byte), versions: make(map[string]int)}
+}
+
+func (s *memObjectStore) Get(key string) ([]byte, error) {
+	b, _, err := s.GetVersion(key)
+	return b, err
+}
+
+func (s *memObjectStore) GetVersion(key string) ([]byte, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.objects[key]
+	if !ok {
+		return nil, "", ErrNoSuchKey
+	}
+	return b, strconv.Itoa(s.versions[key]), nil
+}
+
+func (s *memObjectStore) Put(key string, content []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = content
+	s.versions[key]++
+	return nil
+}
+
+func (s *memObjectStore) PutIfVersion(key string, content []byte, version string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.objects[key]; ok != (version != "") || (ok && strconv.Itoa(s.versions[key]) != version) {
+		return ErrConflict
+	}
+	s.objects[key] = content
+	s.versions[key]++
+	return nil
+}
+
+// racingStore publishes a teammate's revision right before (or, ignoring conditions, right after) a push publishes its own
+type racingStore struct {
+	*memObjectStore
+	ignoreConditions bool
+}
+
+func (s *racingStore) PutIfVersion(key string, content []byte, version string) error {
+	if key != remoteHeadKey {
+		return s.memObjectStore.PutIfVersion(key, content, version)
+	}
+	teammate := []byte(plumbing.NewHash("6ecf0ef2c2dffb796033e5a02219af86ec6584e5").String())
+	if s.ignoreConditions {
+		s.memObjectStore.Put(key, content)
+		return s.memObjectStore.Put(key, teammate)
+	}
+	s.memObjectStore.Put(key, teammate)
+	return s.memObjectStore.PutIfVersion(key, content, version)
+}
+
+type mockS3 struct {
+	s3iface.S3API
+	store *memObjectStore
+}
+
+func (m *mockS3) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	b, version, err := m.store.GetVersion(aws.StringValue(input.Bucket) + "/" + aws.StringValue(input.Key))
+	if err == ErrNoSuchKey {
+		return nil, awserr.New(s3.ErrCodeNoSuchKey, "The specified key does not exist.", nil)
+	}
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(b)), ETag: aws.String(version)}, err
+}
+
+func (m *mockS3) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	b, err := ioutil.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &s3.PutObjectOutput{}, m.store.Put(aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key), b)
+}
+
+func (m *mockS3) PutObjectWithContext(ctx aws.Context, input *s3.PutObjectInput, opts ...request.Option) (*s3.PutObjectOutput, error) {
+	req := &request.Request{HTTPRequest: &http.Request{Header: make(http.Header)}}
+	req.ApplyOptions(opts...)
+	version := req.HTTPRequest.Header.Get("If-Match")
+	if version == "" && req.HTTPRequest.Header.Get("If-None-Match") != "*" {
+		return m.PutObject(input)
+	}
+	b, err := ioutil.ReadAll(input.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err = m.store.PutIfVersion(aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key), b, version); err == ErrConflict {
+		return nil, awserr.New("PreconditionFailed", "At least one of the pre-conditions you specified did not hold", nil)
+	}
+	return &s3.PutObjectOutput{}, err
+}
+
+// failingStore fails getting objects after a number of them, as a pull interrupted midway
+type failingStore struct {
+	*memObjectStore
+	failAfter, gets int
+}
+
+func (s *failingStore) Get(key string) ([]byte, error) {
+	if s.gets++; s.gets > s.failAfter {
+		return nil, fmt.Errorf("connection reset getting %s", key)
+	}
+	return s.memObjectStore.Get(key)
+}
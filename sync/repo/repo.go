@@ -18,7 +18,6 @@ package repo
 
 import (
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -48,15 +47,27 @@ type Repo interface {
 	Commit(files ...string) error
 	List() ([]*Rev, error)
 	LoadRev(version string) (*Rev, error)
+	ListFiles(version string) (map[string]string, error)
+	ReadFile(version, path string) ([]byte, error)
+	GC(retention Retention, force bool) (int, error)
+	Stats() (*Stats, error)
+	Push(Remote) (bool, error)
+	Pull(remote Remote, force bool) (bool, error)
 	BaseDir() string
 }
 
 type NullRepo struct{}
 
-func (NullRepo) Commit(files ...string) error         { return nil }
-func (NullRepo) List() ([]*Rev, error)                { return nil, nil }
-func (NullRepo) LoadRev(version string) (*Rev, error) { return nil, nil }
-func (NullRepo) BaseDir() string                      { return "" }
+func (NullRepo) Commit(files ...string) error                        { return nil }
+func (NullRepo) List() ([]*Rev, error)                               { return nil, nil }
+func (NullRepo) LoadRev(version string) (*Rev, error)                { return nil, nil }
+func (NullRepo) ListFiles(version string) (map[string]string, error) { return nil, nil }
+func (NullRepo) ReadFile(version, path string) ([]byte, error)       { return nil, nil }
+func (NullRepo) GC(Retention, bool) (int, error)                     { return 0, nil }
+func (NullRepo) Stats() (*Stats, error)                              { return &Stats{}, nil }
+func (NullRepo) Push(Remote) (bool, error)                           { return false, nil }
+func (NullRepo) Pull(Remote, bool) (bool, error)                     { return false, nil }
+func (NullRepo) BaseDir() string                                     { return "" }
 
 type gitRepo struct {
 	repo    *git.Repository
@@ -88,30 +99,29 @@ func (r *gitRepo) BaseDir() string {
 	return r.basedir
 }
 
+// List returns the revisions from the first one, following the history of HEAD
+// (commit dates have a one second precision, so cannot order revisions of consecutive syncs)
 func (r *gitRepo) List() ([]*Rev, error) {
 	var all []*Rev
 
-	iter, err := r.repo.CommitObjects()
+	if _, err := r.repo.Head(); err == plumbing.ErrReferenceNotFound {
+		return all, nil
+	}
+	iter, err := r.repo.Log(&git.LogOptions{})
 	if err != nil {
 		return all, err
 	}
 	defer iter.Close()
 
-	for {
-		commit, err := iter.Next()
-		if err != nil {
-			if err == io.EOF {
-				break
-			}
-			panic(fmt.Sprintf("error listing repo revisions: %s", err))
-		}
-
+	err = iter.ForEach(func(commit *object.Commit) error {
 		all = append(all, &Rev{Id: commit.Hash.String(), Date: commit.Committer.When})
+		return nil
+	})
+	for i, j := 0, len(all)-1; i < j; i, j = i+1, j-1 {
+		all[i], all[j] = all[j], all[i]
 	}
 
-	sort.Slice(all, func(i, j int) bool { return all[i].Date.Before(all[j].Date) })
-
-	return all, nil
+	return all, err
 }
 
 func reduceToLastRevOfEachDay(revs []*Rev) []*Rev {
@@ -154,6 +164,39 @@ func (r *gitRepo) LoadRev(version string) (*Rev, error) {
 	return rev, nil
 }
 
+// ListFiles returns the hashes of the files tracked at the given revision, per relative path
+func (r *gitRepo) ListFiles(version string) (map[string]string, error) {
+	commit, err := r.repo.CommitObject(plumbing.NewHash(version))
+	if err != nil {
+		return nil, err
+	}
+	iter, err := commit.Files()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	files := make(map[string]string)
+	err = iter.ForEach(func(f *object.File) error {
+		files[f.Name] = f.Hash.String()
+		return nil
+	})
+	return files, err
+}
+
+func (r *gitRepo) ReadFile(version, path string) ([]byte, error) {
+	commit, err := r.repo.CommitObject(plumbing.NewHash(version))
+	if err != nil {
+		return nil, err
+	}
+	f, err := commit.File(path)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := f.Contents()
+	return []byte(contents), err
+}
+
 func unmarshalIntoGraph(g *graph.Graph, commit *object.Commit, filename string) error {
 	f, err := commit.File(filename)
 	if err != nil && err != object.ErrFileNotFound {
@@ -169,6 +212,12 @@ func unmarshalIntoGraph(g *graph.Graph, commit *object.Commit, filename string)
 }
 
 func (r *gitRepo) Commit(relativePaths ...string) error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	wt, err := r.repo.Worktree()
 	if err != nil {
 		return err
@@ -186,3 +235,35 @@ func (r *gitRepo) Commit(relativePaths ...string) error {
 	_, err = wt.Commit(msg, &git.CommitOptions{Author: committer})
 	return err
 }
+
+const (
+	lockFile     = ".awless.lock"
+	lockTimeout  = 30 * time.Second
+	staleLockAge = 10 * time.Minute
+)
+
+// lock takes the lock of the local store, shared by the processes committing to or rewriting it
+// (i.e. `awless sync --watch` and `awless sync gc`), waiting for it when taken.
+// A lock older than staleLockAge is left by a killed process and is taken over
+func (r *gitRepo) lock() (func(), error) {
+	path := filepath.Join(r.basedir, lockFile)
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+		if info, serr := os.Stat(path); serr == nil && time.Since(info.ModTime()) > staleLockAge {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("local store locked by another awless process (remove %s if none is running)", path)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
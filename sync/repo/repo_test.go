@@ -17,6 +17,11 @@ limitations under the License.
 package repo
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
 	"sort"
 	"testing"
 	"time"
@@ -58,3 +63,118 @@ func mustParse(s string) time.Time {
 	}
 	return t
 }
+
+func TestRetentionKeep(t *testing.T) {
+	revs := []*Rev{
+		{Id: "1", Date: mustParse("2016-11-03 10:00")},
+		{Id: "2", Date: mustParse("2016-12-24 10:00")},
+		{Id: "3", Date: mustParse("2016-12-25 10:00")},
+		{Id: "4", Date: mustParse("2017-01-17 21:05")},
+		{Id: "5", Date: mustParse("2017-01-18 15:05")},
+		{Id: "6", Date: mustParse("2017-01-18 15:09")},
+		{Id: "7", Date: mustParse("2017-01-19 08:05")},
+		{Id: "8", Date: mustParse("2017-01-19 09:05")},
+		{Id: "9", Date: mustParse("2017-01-19 09:15")},
+	}
+	tcases := []struct {
+		retention Retention
+		expect    []string
+	}{
+		{Retention{}, []string{"9"}},
+		{Retention{Hourly: 2}, []string{"7", "9"}},
+		{Retention{Daily: 2}, []string{"6", "9"}},
+		{Retention{Hourly: 2, Daily: 3}, []string{"4", "6", "7", "9"}},
+		{Retention{Monthly: 2}, []string{"3", "9"}},
+		{Retention{Monthly: 12}, []string{"1", "3", "9"}},
+		{Retention{Hourly: 24, Daily: 30, Monthly: 12}, []string{"1", "2", "3", "4", "6", "7", "9"}},
+	}
+	for i, tcase := range tcases {
+		var got []string
+		for _, rev := range tcase.retention.Keep(revs) {
+			got = append(got, rev.Id)
+		}
+		if !reflect.DeepEqual(got, tcase.expect) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, tcase.expect)
+		}
+	}
+}
+
+func TestGC(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	r, err := newGitRepo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := func(file, content string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, file), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Commit(file); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit("access.nt", "users")
+	for i := 0; i < 5; i++ {
+		commit("infra.nt", fmt.Sprintf("instances %d", i))
+	}
+
+	stats, err := r.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := stats.Revisions, 6; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := len(stats.Files), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := *stats.Files[1], (FileStats{Path: "infra.nt", Size: 11, Versions: 5}); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	removed, err := r.GC(Retention{Hourly: 1}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := removed, 5; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	afterGC, err := r.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := afterGC.Revisions, 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if afterGC.Size >= stats.Size {
+		t.Fatalf("expected smaller repository after gc, got %d >= %d", afterGC.Size, stats.Size)
+	}
+
+	commit("infra.nt", "instances 5")
+	revs, err := r.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(revs), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	files, err := r.ListFiles(revs[1].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := files["access.nt"]; !ok {
+		t.Fatalf("expected unchanged file kept in new revision, got %v", files)
+	}
+	content, err := r.ReadFile(revs[0].Id, "infra.nt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(content), "instances 4"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wallix/awless/cloud/rdf"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync/repo"
+	tstore "github.com/wallix/triplestore"
+)
+
+const (
+	snapshotExt = ".snap"
+	// otherSection holds the triples not belonging to a resource type (i.e. relations with resources of other services)
+	otherSection = ""
+)
+
+// BinarySnapshots enables the writing of binary snapshots next to the synced N-Triples files,
+// and their use to load local data (set with `awless config set sync.snapshots true`)
+var BinarySnapshots bool
+
+// snapshotMagic starts the binary snapshots, with the version of their format
+var snapshotMagic = []byte("AWLSNAP1")
+
+var errStaleSnapshot = errors.New("stale snapshot")
+
+// snapshotIndex is the header of a binary snapshot. It identifies the N-Triples file the snapshot is built from,
+// locates the encoded triples of each resource type, and indexes the types of the resources per reference (id, arn and name)
+type snapshotIndex struct {
+	SourceSize    int64                       `json:"sourceSize"`
+	SourceModTime int64                       `json:"sourceModTime"`
+	Sections      map[string]*snapshotSection `json:"sections"`
+	References    map[string][]string         `json:"references"`
+}
+
+type snapshotSection struct {
+	Offset int64 `json:"offset"`
+	Length int64 `json:"length"`
+}
+
+func snapshotPath(ntPath string) string {
+	return strings.TrimSuffix(ntPath, fileExt) + snapshotExt
+}
+
+// writeSnapshot writes the binary snapshot of the graph just written to the given N-Triples file
+func writeSnapshot(ntPath string, g *graph.Graph) error {
+	info, err := os.Stat(ntPath)
+	if err != nil {
+		return err
+	}
+	snap := g.AsRDFGraphSnaphot()
+
+	types := make(map[string]string)
+	for _, t := range snap.WithPredicate(rdf.RdfType) {
+		if obj, ok := t.Object().Resource(); ok && strings.HasPrefix(obj, rdf.CloudOwlNS+":") {
+			types[t.Subject()] = strings.ToLower(strings.TrimPrefix(obj, rdf.CloudOwlNS+":"))
+		}
+	}
+
+	index := &snapshotIndex{
+		SourceSize:    info.Size(),
+		SourceModTime: info.ModTime().UnixNano(),
+		Sections:      make(map[string]*snapshotSection),
+		References:    make(map[string][]string),
+	}
+	sections := make(map[string][]tstore.Triple)
+	assigned := make(map[tstore.Triple]bool)
+	var addSubject func(typ, subject string, visited map[string]bool)
+	addSubject = func(typ, subject string, visited map[string]bool) {
+		visited[subject] = true
+		for _, t := range snap.WithSubject(subject) {
+			sections[typ] = append(sections[typ], t)
+			assigned[t] = true
+			// nested objects (i.e. firewall rules, routes) have their own triples
+			obj, ok := t.Object().Resource()
+			if ok && t.Predicate() != rdf.RdfType && !isRelation(t.Predicate()) && types[obj] == "" && !visited[obj] {
+				addSubject(typ, obj, visited)
+			}
+		}
+	}
+	for id, typ := range types {
+		addSubject(typ, id, make(map[string]bool))
+		for _, t := range snap.WithObject(tstore.Resource(id)) {
+			if isRelation(t.Predicate()) {
+				sections[typ] = append(sections[typ], t)
+				assigned[t] = true
+			}
+		}
+		index.addReference(id, typ)
+		for _, prop := range []string{rdf.Arn, rdf.Name} {
+			for _, t := range snap.WithSubjPred(id, prop) {
+				if lit, err := tstore.ParseString(t.Object()); err == nil && lit != "" {
+					index.addReference(lit, typ)
+				}
+			}
+		}
+	}
+	for _, t := range snap.Triples() {
+		if !assigned[t] {
+			sections[otherSection] = append(sections[otherSection], t)
+		}
+	}
+
+	var body bytes.Buffer
+	for typ, triples := range sections {
+		offset := int64(body.Len())
+		if err := tstore.NewBinaryEncoder(&body).Encode(triples...); err != nil {
+			return fmt.Errorf("encoding %s: %s", typ, err)
+		}
+		index.Sections[typ] = &snapshotSection{Offset: offset, Length: int64(body.Len()) - offset}
+	}
+	header, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	// written to a unique file of the same directory then renamed, so that concurrent writers
+	// (i.e. `awless sync --watch` and a load rebuilding a stale snapshot) never mix their content
+	f, err := ioutil.TempFile(filepath.Dir(ntPath), filepath.Base(snapshotPath(ntPath))+".tmp")
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	w.Write(snapshotMagic)
+	binary.Write(w, binary.BigEndian, uint32(len(header)))
+	w.Write(header)
+	w.Write(body.Bytes())
+	if err = w.Flush(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err = f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err = os.Rename(f.Name(), snapshotPath(ntPath)); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+func (idx *snapshotIndex) addReference(ref, typ string) {
+	if !contains(idx.References[ref], typ) {
+		idx.References[ref] = append(idx.References[ref], typ)
+	}
+}
+
+func isRelation(predicate string) bool {
+	return predicate == rdf.ParentOf || predicate == rdf.ApplyOn
+}
+
+// snapshot is an opened binary snapshot, whose sections are read on demand
+type snapshot struct {
+	f     *os.File
+	base  int64
+	index *snapshotIndex
+}
+
+// openSnapshot opens the binary snapshot of a N-Triples file, returning errStaleSnapshot
+// when missing or not built from the current N-Triples file
+func openSnapshot(ntPath string) (*snapshot, error) {
+	info, err := os.Stat(ntPath)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(snapshotPath(ntPath))
+	if os.IsNotExist(err) {
+		return nil, errStaleSnapshot
+	} else if err != nil {
+		return nil, err
+	}
+	s, err := readSnapshotIndex(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if s.index.SourceSize != info.Size() || s.index.SourceModTime != info.ModTime().UnixNano() {
+		f.Close()
+		return nil, errStaleSnapshot
+	}
+	return s, nil
+}
+
+func readSnapshotIndex(f *os.File) (*snapshot, error) {
+	r := bufio.NewReader(f)
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || !bytes.Equal(magic, snapshotMagic) {
+		return nil, errStaleSnapshot
+	}
+	var length uint32
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+	header := make([]byte, length)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	index := &snapshotIndex{}
+	if err := json.Unmarshal(header, index); err != nil {
+		return nil, err
+	}
+	return &snapshot{f: f, base: int64(len(snapshotMagic)) + 4 + int64(length), index: index}, nil
+}
+
+// readers returns the readers of the sections of the given resource types, of all sections when none given
+func (s *snapshot) readers(resourceTypes ...string) []io.Reader {
+	var readers []io.Reader
+	for typ, section := range s.index.Sections {
+		if len(resourceTypes) == 0 || contains(resourceTypes, typ) {
+			readers = append(readers, bufio.NewReader(io.NewSectionReader(s.f, s.base+section.Offset, section.Length)))
+		}
+	}
+	return readers
+}
+
+func (s *snapshot) Close() error {
+	return s.f.Close()
+}
+
+// loadGraphFiles loads the given N-Triples files, only the resources of the given types (and their relations) when
+// any and read from fresh binary snapshots. Stale snapshots are rebuilt, the N-Triples files being read meanwhile
+func loadGraphFiles(files []string, resourceTypes ...string) (*graph.Graph, error) {
+	g := graph.NewGraph()
+	var readers []io.Reader
+	var closers []io.Closer
+	defer func() {
+		for _, c := range closers {
+			c.Close()
+		}
+	}()
+	for _, f := range files {
+		if BinarySnapshots {
+			s, err := openSnapshot(f)
+			if err == errStaleSnapshot {
+				s, err = rebuildSnapshot(f)
+			}
+			if err == nil {
+				closers = append(closers, s)
+				readers = append(readers, s.readers(resourceTypes...)...)
+				continue
+			}
+		}
+		reader, err := os.Open(f)
+		if err != nil {
+			return g, fmt.Errorf("loading '%s': %s", f, err)
+		}
+		closers = append(closers, reader)
+		readers = append(readers, reader)
+	}
+	return g, g.UnmarshalFromReaders(readers...)
+}
+
+func rebuildSnapshot(ntPath string) (*snapshot, error) {
+	g, err := graph.NewGraphFromFile(ntPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeSnapshot(ntPath, g); err != nil {
+		return nil, err
+	}
+	return openSnapshot(ntPath)
+}
+
+// LocalReferenceTypes returns the types of the resources referenced (by id, arn or name) in the data synced for
+// a profile in all regions, according to the binary snapshots. Indexed is false when a synced file has no fresh snapshot
+func LocalReferenceTypes(profile, ref string) (types []string, indexed bool) {
+	if !BinarySnapshots {
+		return nil, false
+	}
+	files, _ := filepath.Glob(filepath.Join(repo.BaseDir(), profile, "*", "*"+fileExt))
+	for _, f := range files {
+		s, err := openSnapshot(f)
+		if err != nil {
+			return nil, false
+		}
+		for _, t := range s.index.References[ref] {
+			if !contains(types, t) {
+				types = append(types, t)
+			}
+		}
+		s.Close()
+	}
+	return types, true
+}
@@ -0,0 +1,214 @@
+package sync
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	gosync "sync"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestBinarySnapshots(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	os.Setenv("__AWLESS_HOME", tmpDir)
+	BinarySnapshots = true
+	defer func() { BinarySnapshots = false }()
+
+	_, cidr, _ := net.ParseCIDR("10.0.0.0/24")
+	g := graph.NewGraph()
+	vpc := resourcetest.VPC("vpc_1").Build()
+	sub := resourcetest.Subnet("sub_1").Build()
+	inst := resourcetest.Instance("inst_1").Prop("Name", "web").Build()
+	sgrp := resourcetest.SecurityGroup("sg_1").Prop("Name", "web-sg").Prop("InboundRules", []*graph.FirewallRule{
+		{PortRange: graph.PortRange{FromPort: 443, ToPort: 443}, Protocol: "tcp", IPRanges: []*net.IPNet{cidr}},
+	}).Build()
+	g.AddResource(vpc, sub, inst, sgrp)
+	g.AddParentRelation(vpc, sub)
+	g.AddParentRelation(sub, inst)
+	g.AddAppliesOnRelation(sgrp, inst)
+
+	if _, err = NewSyncer().Sync(&mockService{g: g, name: "infra", region: "eu-west-1", profile: "default"}); err != nil {
+		t.Fatal(err)
+	}
+	ntPath := filepath.Join(tmpDir, "aws", "rdf", "default", "eu-west-1", "infra.nt")
+	if _, err = os.Stat(snapshotPath(ntPath)); err != nil {
+		t.Fatalf("expected snapshot written: %s", err)
+	}
+
+	t.Run("load all", func(t *testing.T) {
+		fromSnapshot, err := LoadLocalGraphs("default", "eu-west-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromNT, err := graph.NewGraphFromFile(ntPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := sortedLines(fromSnapshot.(*graph.Graph).MustMarshal()), sortedLines(fromNT.MustMarshal()); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got\n%v\nwant\n%v", got, want)
+		}
+	})
+
+	t.Run("load type", func(t *testing.T) {
+		g, err := LoadLocalGraphForType("infra", "securitygroup", []string{"default"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sgrps, err := g.Find(cloud.NewQuery("securitygroup"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sgrps) != 1 {
+			t.Fatalf("got %d securitygroups, want 1", len(sgrps))
+		}
+		rules, _ := sgrps[0].Property("InboundRules")
+		if r, ok := rules.([]*graph.FirewallRule); !ok || len(r) != 1 || r[0].PortRange.FromPort != 443 {
+			t.Fatalf("unexpected rules %#v", rules)
+		}
+		if instances, _ := g.Find(cloud.NewQuery("instance")); len(instances) != 0 {
+			t.Fatalf("expected only securitygroups, got %d instances", len(instances))
+		}
+	})
+
+	t.Run("load service types", func(t *testing.T) {
+		g := LoadLocalGraphForService("infra", "default", "eu-west-1", "instance")
+		if instances, _ := g.Find(cloud.NewQuery("instance")); len(instances) != 1 {
+			t.Fatalf("got %d instances, want 1", len(instances))
+		}
+		if subnets, _ := g.Find(cloud.NewQuery("subnet")); len(subnets) != 0 {
+			t.Fatalf("expected only instances, got %d subnets", len(subnets))
+		}
+		all, err := LoadLocalGraphs("default", "eu-west-1", "instance", "subnet")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if subnets, _ := all.Find(cloud.NewQuery("subnet")); len(subnets) != 1 {
+			t.Fatalf("got %d subnets, want 1", len(subnets))
+		}
+		if vpcs, _ := all.Find(cloud.NewQuery("vpc")); len(vpcs) != 0 {
+			t.Fatalf("expected no vpcs, got %d", len(vpcs))
+		}
+	})
+
+	t.Run("concurrent writes", func(t *testing.T) {
+		var wg gosync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := writeSnapshot(ntPath, g); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+		s, err := openSnapshot(ntPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.Close()
+		if tmps, _ := filepath.Glob(snapshotPath(ntPath) + ".tmp*"); len(tmps) != 0 {
+			t.Fatalf("expected no temporary file left, got %v", tmps)
+		}
+	})
+
+	t.Run("reference index", func(t *testing.T) {
+		tcases := []struct {
+			ref    string
+			expect []string
+		}{
+			{ref: "inst_1", expect: []string{"instance"}},
+			{ref: "web-sg", expect: []string{"securitygroup"}},
+			{ref: "unknown"},
+		}
+		for _, tcase := range tcases {
+			types, indexed := LocalReferenceTypes("default", tcase.ref)
+			if !indexed {
+				t.Fatalf("%s: expected indexed", tcase.ref)
+			}
+			if got, want := types, tcase.expect; !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s: got %v, want %v", tcase.ref, got, want)
+			}
+		}
+	})
+
+	t.Run("stale snapshot", func(t *testing.T) {
+		updated := graph.NewGraph()
+		updated.AddResource(resourcetest.Instance("inst_2").Build())
+		if err := ioutil.WriteFile(ntPath, []byte(updated.MustMarshal()), 0600); err != nil {
+			t.Fatal(err)
+		}
+		later := time.Now().Add(time.Minute)
+		os.Chtimes(ntPath, later, later)
+
+		if _, indexed := LocalReferenceTypes("default", "inst_2"); indexed {
+			t.Fatal("expected stale snapshot not indexed")
+		}
+		g, err := LoadLocalGraphForType("infra", "instance", []string{"default"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		instances, err := g.Find(cloud.NewQuery("instance"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(instances) != 1 || instances[0].Properties()["ID"] != "inst_2" {
+			t.Fatalf("expected instances of updated file, got %v", instances)
+		}
+		if types, indexed := LocalReferenceTypes("default", "inst_2"); !indexed || !reflect.DeepEqual(types, []string{"instance"}) {
+			t.Fatalf("expected rebuilt snapshot, got %v, %t", types, indexed)
+		}
+	})
+}
+
+func BenchmarkLoadLocalGraphForType(b *testing.B) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	os.Setenv("__AWLESS_HOME", tmpDir)
+	defer func() { BinarySnapshots = false }()
+
+	g := graph.NewGraph()
+	for i := 0; i < 2000; i++ {
+		g.AddResource(resourcetest.Instance(fmt.Sprintf("inst_%d", i)).Prop("Name", fmt.Sprintf("web-%d", i)).Build())
+		g.AddResource(resourcetest.Subnet(fmt.Sprintf("sub_%d", i)).Build())
+		g.AddResource(resourcetest.SecurityGroup(fmt.Sprintf("sg_%d", i)).Build())
+	}
+	BinarySnapshots = true
+	if _, err = NewSyncer().Sync(&mockService{g: g, name: "infra", region: "eu-west-1", profile: "default"}); err != nil {
+		b.Fatal(err)
+	}
+
+	for _, snapshots := range []bool{false, true} {
+		BinarySnapshots = snapshots
+		b.Run(fmt.Sprintf("snapshots=%t", snapshots), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := LoadLocalGraphForType("infra", "instance", []string{"default"}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func sortedLines(s string) []string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	sort.Strings(lines)
+	return lines
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/wallix/awless/sync/repo"
+)
+
+const (
+	watchStatusFile = "sync-status.json"
+	watchLockFile   = "sync-watch.lock"
+)
+
+// WatchHeartbeat is the maximum delay between two refreshes of the status of a running `awless sync --watch`
+const WatchHeartbeat = 30 * time.Second
+
+// WatchStatus is the status of a running `awless sync --watch`
+type WatchStatus struct {
+	PID      int                       `json:"pid"`
+	Started  time.Time                 `json:"started"`
+	Updated  time.Time                 `json:"updated"`
+	Services map[string]*ServiceStatus `json:"services"`
+}
+
+// ServiceStatus is the sync status of a service watched for a profile and region
+type ServiceStatus struct {
+	Profile   string    `json:"profile"`
+	Region    string    `json:"region"`
+	Service   string    `json:"service"`
+	Interval  string    `json:"interval"`
+	LastSync  time.Time `json:"lastSync"`
+	NextSync  time.Time `json:"nextSync"`
+	LastError string    `json:"lastError,omitempty"`
+}
+
+// ServiceStatusKey is the key of a service in the watch status
+func ServiceStatusKey(profile, region, service string) string {
+	if IsGlobalService(service) {
+		region = GlobalRegion
+	}
+	return path.Join(profile, region, service)
+}
+
+// IsAlive returns true when the status has been refreshed recently by its watcher
+func (s *WatchStatus) IsAlive(now time.Time) bool {
+	return now.Sub(s.Updated) < 3*WatchHeartbeat
+}
+
+func WatchStatusFile() string {
+	return filepath.Join(os.Getenv("__AWLESS_HOME"), watchStatusFile)
+}
+
+// ReadWatchStatus returns the status of the last `awless sync --watch`, nil when none ran
+func ReadWatchStatus() (*WatchStatus, error) {
+	b, err := ioutil.ReadFile(WatchStatusFile())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	status := &WatchStatus{}
+	return status, json.Unmarshal(b, status)
+}
+
+// WriteWatchStatus atomically writes the status, so that readers never see a partial file
+func WriteWatchStatus(status *WatchStatus) error {
+	b, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := WatchStatusFile() + ".tmp"
+	if err = ioutil.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, WatchStatusFile())
+}
+
+func RemoveWatchStatus() error {
+	if err := os.Remove(WatchStatusFile()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// WatchLock prevents concurrent `awless sync --watch`. Its watcher refreshes it on each heartbeat,
+// so that the lock of a watcher that crashed is taken over once it is older than 3 heartbeats
+type WatchLock struct {
+	path string
+}
+
+// LockWatch exclusively creates the lock of `awless sync --watch`, failing when a running watcher holds it
+func LockWatch() (*WatchLock, error) {
+	lock := &WatchLock{path: filepath.Join(os.Getenv("__AWLESS_HOME"), watchLockFile)}
+	for retried := false; ; retried = true {
+		f, err := os.OpenFile(lock.path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_, err = fmt.Fprint(f, os.Getpid())
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			return lock, err
+		}
+		if !os.IsExist(err) || retried {
+			return nil, err
+		}
+		info, err := os.Stat(lock.path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil && time.Since(info.ModTime()) < 3*WatchHeartbeat {
+			pid, _ := ioutil.ReadFile(lock.path)
+			return nil, fmt.Errorf("sync --watch already running with pid %s (lock in %s)", pid, lock.path)
+		}
+		if err = os.Remove(lock.path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("cannot remove stale lock %s: %s", lock.path, err)
+		}
+	}
+}
+
+// Refresh marks the lock as held by a running watcher
+func (l *WatchLock) Refresh() error {
+	now := time.Now()
+	return os.Chtimes(l.path, now, now)
+}
+
+func (l *WatchLock) Release() error {
+	return os.Remove(l.path)
+}
+
+// LastLocalSync returns when the local data of a service was last written by a sync, false when never synced
+func LastLocalSync(profile, region, service string) (time.Time, bool) {
+	if IsGlobalService(service) {
+		region = GlobalRegion
+	}
+	info, err := os.Stat(filepath.Join(repo.BaseDir(), profile, region, service+fileExt))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return info.ModTime(), true
+}
@@ -0,0 +1,103 @@
+package sync
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestWatchStatus(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	os.Setenv("__AWLESS_HOME", tmpDir)
+
+	if status, err := ReadWatchStatus(); err != nil || status != nil {
+		t.Fatalf("expected no status, got %v, %v", status, err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	status := &WatchStatus{PID: 42, Started: now, Updated: now, Services: map[string]*ServiceStatus{
+		ServiceStatusKey("default", "eu-west-1", "access"): {Profile: "default", Region: "global", Service: "access", Interval: "1h0m0s", NextSync: now.Add(time.Hour)},
+	}}
+	if err = WriteWatchStatus(status); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadWatchStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := read.PID, 42; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if _, ok := read.Services["default/global/access"]; !ok {
+		t.Fatalf("expected global service status, got %#v", read.Services)
+	}
+	if !read.IsAlive(now.Add(WatchHeartbeat)) {
+		t.Fatal("expected alive watch status")
+	}
+	if read.IsAlive(now.Add(10 * WatchHeartbeat)) {
+		t.Fatal("expected dead watch status")
+	}
+	if err = RemoveWatchStatus(); err != nil {
+		t.Fatal(err)
+	}
+	if status, _ := ReadWatchStatus(); status != nil {
+		t.Fatal("expected removed status")
+	}
+
+	if _, ok := LastLocalSync("default", "eu-west-1", "infra"); ok {
+		t.Fatal("expected never synced service")
+	}
+	g := graph.NewGraph()
+	g.AddResource(resourcetest.Instance("inst_1").Build())
+	if _, err := NewSyncer().Sync(&mockService{g: g, name: "infra", region: "eu-west-1", profile: "default"}); err != nil {
+		t.Fatal(err)
+	}
+	if last, ok := LastLocalSync("default", "eu-west-1", "infra"); !ok || time.Since(last) > time.Minute {
+		t.Fatalf("unexpected last sync %s, %t", last, ok)
+	}
+}
+
+func TestWatchLock(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	os.Setenv("__AWLESS_HOME", tmpDir)
+
+	lock, err := LockWatch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = LockWatch(); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Fatalf("got %v, want already running error", err)
+	}
+	if err = lock.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+
+	stale := time.Now().Add(-4 * WatchHeartbeat)
+	if err = os.Chtimes(filepath.Join(tmpDir, watchLockFile), stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	taken, err := LockWatch()
+	if err != nil {
+		t.Fatalf("expected stale lock to be taken over: %s", err)
+	}
+	if err = taken.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(filepath.Join(tmpDir, watchLockFile)); !os.IsNotExist(err) {
+		t.Fatalf("expected released lock, got %v", err)
+	}
+}
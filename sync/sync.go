@@ -17,7 +17,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,18 +27,23 @@ import (
 	"runtime"
 
 	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync/repo"
 )
 
-const fileExt = ".nt"
+const (
+	fileExt     = ".nt"
+	accountFile = "account"
+)
 
 var DefaultSyncer Syncer
 
 type Syncer interface {
 	repo.Repo
 	Sync(...cloud.Service) (map[string]cloud.GraphAPI, error)
+	SyncTypes([]string, ...cloud.Service) (map[string]cloud.GraphAPI, error)
 }
 
 type noopsyncer struct {
@@ -51,9 +56,20 @@ func (s *noopsyncer) Sync(services ...cloud.Service) (map[string]cloud.GraphAPI,
 	return map[string]cloud.GraphAPI{}, nil
 }
 
+func (s *noopsyncer) SyncTypes([]string, ...cloud.Service) (map[string]cloud.GraphAPI, error) {
+	return map[string]cloud.GraphAPI{}, nil
+}
+
 type syncer struct {
 	repo.Repo
 	logger *logger.Logger
+
+	// writeMu serializes the writing and committing of fetched graphs,
+	// so that several regions can be synced concurrently
+	writeMu gosync.Mutex
+
+	// events publishes the resource changes of each sync, when not nil
+	events EventPublisher
 }
 
 func NewSyncer(l ...*logger.Logger) Syncer {
@@ -73,7 +89,26 @@ func NewSyncer(l ...*logger.Logger) Syncer {
 	return s
 }
 
+// NewSyncerWithEvents returns a syncer publishing the resource changes found between
+// the previous and the new revision of each synced service
+func NewSyncerWithEvents(p EventPublisher, l ...*logger.Logger) Syncer {
+	s := NewSyncer(l...).(*syncer)
+	s.events = p
+	return s
+}
+
 func (s *syncer) Sync(services ...cloud.Service) (map[string]cloud.GraphAPI, error) {
+	return s.sync(nil, services...)
+}
+
+// SyncTypes fetches only the given resource types, skipping the services without them, and merges them into
+// the last synced files of their services, replacing only the resources of these types. The types failing
+// to fetch keep their previously synced resources
+func (s *syncer) SyncTypes(resourceTypes []string, services ...cloud.Service) (map[string]cloud.GraphAPI, error) {
+	return s.sync(resourceTypes, services...)
+}
+
+func (s *syncer) sync(resourceTypes []string, services ...cloud.Service) (map[string]cloud.GraphAPI, error) {
 	var workers gosync.WaitGroup
 
 	type result struct {
@@ -85,16 +120,39 @@ func (s *syncer) Sync(services ...cloud.Service) (map[string]cloud.GraphAPI, err
 
 	resultc := make(chan *result, len(services))
 
+	typesOf := func(srv cloud.Service) []string { return srv.ResourceTypes() }
+	if resourceTypes != nil {
+		typesOf = func(srv cloud.Service) (types []string) {
+			for _, t := range srv.ResourceTypes() {
+				if contains(resourceTypes, t) {
+					types = append(types, t)
+				}
+			}
+			return
+		}
+	}
+
+	var disabled []cloud.Service
 	for _, service := range services {
+		if resourceTypes != nil && len(typesOf(service)) == 0 {
+			continue
+		}
 		if service.IsSyncDisabled() {
 			s.logger.Verbosef("sync: *disabled* for service %s", service.Name())
+			disabled = append(disabled, service)
 			continue
 		}
 		workers.Add(1)
 		go func(srv cloud.Service) {
 			defer workers.Done()
 			start := time.Now()
-			g, err := srv.Fetch(context.Background())
+			var g cloud.GraphAPI
+			var err error
+			if resourceTypes == nil {
+				g, err = srv.Fetch(context.Background())
+			} else {
+				g, err = fetchTypes(srv, typesOf(srv))
+			}
 			resultc <- &result{service: srv, gph: g, start: start, err: err}
 		}(service)
 	}
@@ -128,7 +186,12 @@ Loop:
 		}
 	}
 
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	var filepaths []string
+	var events []*ChangeEvent
+	now := time.Now().UTC()
 
 	for name, g := range graphs {
 		serviceRegion := servicesByName[name].Region()
@@ -137,6 +200,23 @@ Loop:
 		os.MkdirAll(serviceDir, 0700)
 
 		fullpath := filepath.Join(serviceDir, fmt.Sprintf("%s%s", name, fileExt))
+		statuses := fetchStatusesOf(g, now)
+		if resourceTypes != nil {
+			merged, err := mergeIntoPreviousSync(fullpath, g, statuses)
+			if err != nil {
+				allErrors = append(allErrors, fmt.Errorf("merging %s: %s", fullpath, err))
+				continue
+			}
+			g, graphs[name] = merged, merged
+			statuses = mergeFetchStatuses(s.BaseDir(), serviceProfile, serviceRegion, name, statuses)
+		}
+		if s.events != nil {
+			evts, err := s.diffWithPreviousSync(fullpath, g, serviceProfile, serviceRegion, name, statuses, now)
+			if err != nil {
+				allErrors = append(allErrors, fmt.Errorf("computing changes of %s: %s", name, err))
+			}
+			events = append(events, evts...)
+		}
 		f, err := os.OpenFile(fullpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 		if err != nil {
 			allErrors = append(allErrors, fmt.Errorf("opening %s: %s", fullpath, err))
@@ -161,6 +241,32 @@ Loop:
 
 		filepaths = append(filepaths, relPath)
 		closeFile()
+
+		if gph, ok := g.(*graph.Graph); ok && BinarySnapshots {
+			if err := writeSnapshot(fullpath, gph); err != nil {
+				s.logger.Verbosef("cannot write snapshot of %s: %s", fullpath, err)
+			}
+		}
+
+		if len(statuses) > 0 {
+			if relPath, err := writeFetchStatuses(s.BaseDir(), serviceProfile, serviceRegion, name, statuses); err != nil {
+				allErrors = append(allErrors, fmt.Errorf("writing fetch status of %s: %s", name, err))
+			} else {
+				filepaths = append(filepaths, relPath)
+			}
+		}
+	}
+
+	for _, srv := range disabled {
+		statuses := disabledFetchStatuses(typesOf(srv), now)
+		if resourceTypes != nil {
+			statuses = mergeFetchStatuses(s.BaseDir(), srv.Profile(), srv.Region(), srv.Name(), statuses)
+		}
+		if relPath, err := writeFetchStatuses(s.BaseDir(), srv.Profile(), srv.Region(), srv.Name(), statuses); err != nil {
+			allErrors = append(allErrors, fmt.Errorf("writing fetch status of %s: %s", srv.Name(), err))
+		} else {
+			filepaths = append(filepaths, relPath)
+		}
 	}
 
 	if runtime.GOOS != "windows" { // https://github.com/wallix/awless/issues/119
@@ -169,9 +275,96 @@ Loop:
 		}
 	}
 
+	if len(events) > 0 {
+		if err := s.events.Publish(events); err != nil {
+			allErrors = append(allErrors, fmt.Errorf("publishing sync events: %s", err))
+		}
+	}
+
 	return graphs, concatErrors(allErrors)
 }
 
+// fetchTypes fetches the given resource types of a service one after the other,
+// as fetching a type resets the fetch cache of the service
+func fetchTypes(srv cloud.Service, resourceTypes []string) (cloud.GraphAPI, error) {
+	g := graph.NewGraph()
+	var errs []string
+	for _, t := range resourceTypes {
+		fetched, err := srv.FetchByType(context.Background(), t)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", t, err))
+		}
+		typed, ok := fetched.(*graph.Graph)
+		if !ok {
+			if err == nil {
+				errs = append(errs, fmt.Sprintf("%s: unexpected graph type %T", t, fetched))
+			}
+			g.SetFetchStatus(t, &graph.FetchStatus{Err: fmt.Errorf("fetching %s failed", t)})
+			continue
+		}
+		g.AddGraph(typed)
+		for typ, st := range typed.FetchStatuses() {
+			g.SetFetchStatus(typ, st)
+		}
+	}
+	if len(errs) > 0 {
+		return g, errors.New(strings.Join(errs, "; "))
+	}
+	return g, nil
+}
+
+// mergeIntoPreviousSync returns the previously synced graph of a service whose resources of the fetched types
+// are replaced by the fetched ones. The types failing to fetch are left unchanged
+func mergeIntoPreviousSync(previousFile string, fetched cloud.GraphAPI, statuses map[string]*TypeFetchStatus) (*graph.Graph, error) {
+	typed, ok := fetched.(*graph.Graph)
+	if !ok {
+		return nil, fmt.Errorf("unexpected graph type %T", fetched)
+	}
+	previous, err := graph.NewGraphFromFile(previousFile)
+	if os.IsNotExist(err) {
+		previous = graph.NewGraph()
+	} else if err != nil {
+		return nil, err
+	}
+	var replaced []string
+	for t, st := range statuses {
+		if st.Status != TypeFetchFailed {
+			replaced = append(replaced, t)
+		}
+	}
+	previous.ReplaceResources(typed, replaced...)
+	return previous, nil
+}
+
+// diffWithPreviousSync diffs the previously synced file of a service with its newly fetched graph.
+// A service synced for the first time has no events, as all its resources would be seen as created.
+// Types whose fetch failed or is disabled have no events, as all their resources would be seen as deleted
+func (s *syncer) diffWithPreviousSync(previousFile string, g cloud.GraphAPI, profile, region, service string, statuses map[string]*TypeFetchStatus, now time.Time) ([]*ChangeEvent, error) {
+	if _, err := os.Stat(previousFile); os.IsNotExist(err) {
+		return nil, nil
+	}
+	newGraph, ok := g.(*graph.Graph)
+	if !ok {
+		return nil, fmt.Errorf("unexpected graph type %T", g)
+	}
+	previous, err := graph.NewGraphFromFile(previousFile)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := graph.ResourceDiffer.Run("", previous, newGraph)
+	if err != nil {
+		return nil, err
+	}
+	var events []*ChangeEvent
+	for _, e := range changeEvents(diff, profile, region, service, now) {
+		if st, ok := statuses[e.Type]; ok && !st.IsSynced() {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
 func concatErrors(errs []error) error {
 	if len(errs) == 0 {
 		return nil
@@ -185,57 +378,112 @@ func concatErrors(errs []error) error {
 	return errors.New(strings.Join(lines, "\n"))
 }
 
-func LoadLocalGraphForService(serviceName, profile, region string) cloud.GraphAPI {
+// GlobalRegion is the region directory of the services not bound to a region
+const GlobalRegion = "global"
+
+func IsGlobalService(serviceName string) bool {
+	return serviceName == "access" || serviceName == "dns" || serviceName == "cdn"
+}
+
+// LoadLocalGraphForService loads the data synced for a service, only the resources of the given types
+// (and their relations) when any and the binary snapshots are enabled
+func LoadLocalGraphForService(serviceName, profile, region string, resourceTypes ...string) cloud.GraphAPI {
 	regionDir := region
-	if serviceName == "access" || serviceName == "dns" || serviceName == "cdn" {
-		regionDir = "global"
+	if IsGlobalService(serviceName) {
+		regionDir = GlobalRegion
 	}
 	path := filepath.Join(repo.BaseDir(), profile, regionDir, fmt.Sprintf("%s%s", serviceName, fileExt))
-	g, err := graph.NewGraphFromFile(path)
+	g, err := loadGraphFiles([]string{path}, resourceTypes...)
 	if err != nil {
 		return graph.NewGraph()
 	}
 	return g
 }
 
-func LoadLocalGraphs(profile, region string) (cloud.GraphAPI, error) {
+// LoadLocalGraphForType merges the resources of the given type synced for the given profiles in the given regions
+// (all synced regions when none given). Their region, profile and account (when known) are set as properties,
+// unless already set. Merged resources are identified by `<profile>/<region>/<id>`, so that resources with
+// the same id in different profiles or regions are all kept: their ID property is the original id.
+func LoadLocalGraphForType(serviceName, resourceType string, profiles []string, regions ...string) (cloud.GraphAPI, error) {
+	regionDirs := regions
+	if len(regions) == 0 {
+		regionDirs = []string{"*"}
+	} else if IsGlobalService(serviceName) {
+		regionDirs = []string{GlobalRegion}
+	}
+	merged := graph.NewGraph()
+	for _, profile := range profiles {
+		account := LocalAccount(profile)
+		for _, regionDir := range regionDirs {
+			files, _ := filepath.Glob(filepath.Join(repo.BaseDir(), profile, regionDir, fmt.Sprintf("%s%s", serviceName, fileExt)))
+			for _, f := range files {
+				g, err := loadGraphFiles([]string{f}, resourceType)
+				if err != nil {
+					return merged, fmt.Errorf("loading '%s': %s", f, err)
+				}
+				resources, err := g.GetAllResources(resourceType)
+				if err != nil {
+					return merged, err
+				}
+				region := filepath.Base(filepath.Dir(f))
+				for _, res := range resources {
+					setPropertyIfMissing(res, properties.Region, region)
+					setPropertyIfMissing(res, properties.AWSProfile, profile)
+					if account != "" {
+						setPropertyIfMissing(res, properties.Account, account)
+					}
+					if err := merged.AddResource(res.CopyWithId(fmt.Sprintf("%s/%s/%s", profile, region, res.Id()))); err != nil {
+						return merged, err
+					}
+				}
+			}
+		}
+	}
+	return merged, nil
+}
+
+func setPropertyIfMissing(res *graph.Resource, key string, value interface{}) {
+	if _, ok := res.Property(key); !ok {
+		res.SetProperty(key, value)
+	}
+}
+
+// WriteLocalAccount records the account ID of a synced profile
+func WriteLocalAccount(profile, account string) error {
+	dir := filepath.Join(repo.BaseDir(), profile)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, accountFile), []byte(account), 0600)
+}
+
+// LocalAccount returns the account ID recorded for a synced profile, empty when unknown
+func LocalAccount(profile string) string {
+	b, err := ioutil.ReadFile(filepath.Join(repo.BaseDir(), profile, accountFile))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// LoadLocalGraphs loads the data synced for a profile in a region and globally, only the resources
+// of the given types (and their relations) when any and the binary snapshots are enabled
+func LoadLocalGraphs(profile, region string, resourceTypes ...string) (cloud.GraphAPI, error) {
 	var files []string
-	globalFiles, _ := filepath.Glob(filepath.Join(repo.BaseDir(), profile, "global", fmt.Sprintf("*%s", fileExt)))
+	globalFiles, _ := filepath.Glob(filepath.Join(repo.BaseDir(), profile, GlobalRegion, fmt.Sprintf("*%s", fileExt)))
 	regionFiles, _ := filepath.Glob(filepath.Join(repo.BaseDir(), profile, region, fmt.Sprintf("*%s", fileExt)))
 
 	files = append(files, globalFiles...)
 	files = append(files, regionFiles...)
 
-	g := graph.NewGraph()
-
-	var readers []io.Reader
-	for _, f := range files {
-		reader, err := os.Open(f)
-		if err != nil {
-			return g, fmt.Errorf("loading '%s': %s", f, err)
-		}
-		readers = append(readers, reader)
-	}
-
-	err := g.UnmarshalFromReaders(readers...)
-	return g, err
+	return loadGraphFiles(files, resourceTypes...)
 }
 
-func LoadAllLocalGraphs(profile string) (cloud.GraphAPI, error) {
+// LoadAllLocalGraphs loads the data synced for a profile in all regions, only the resources
+// of the given types (and their relations) when any and the binary snapshots are enabled
+func LoadAllLocalGraphs(profile string, resourceTypes ...string) (cloud.GraphAPI, error) {
 	path := filepath.Join(repo.BaseDir(), profile, "*", fmt.Sprintf("*%s", fileExt))
 	files, _ := filepath.Glob(path)
 
-	g := graph.NewGraph()
-
-	var readers []io.Reader
-	for _, f := range files {
-		reader, err := os.Open(f)
-		if err != nil {
-			return g, fmt.Errorf("loading '%s': %s", f, err)
-		}
-		readers = append(readers, reader)
-	}
-
-	err := g.UnmarshalFromReaders(readers...)
-	return g, err
+	return loadGraphFiles(files, resourceTypes...)
 }
@@ -2,7 +2,11 @@ package sync
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"reflect"
+	gosync "sync"
 	"testing"
 
 	"github.com/wallix/awless/cloud"
@@ -12,6 +16,7 @@ import (
 	"path/filepath"
 
 	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
 )
 
 func TestSyncTripleFiles(t *testing.T) {
@@ -65,17 +70,181 @@ func TestSyncTripleFiles(t *testing.T) {
 	}
 }
 
+func TestLoadLocalGraphForType(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	os.Setenv("__AWLESS_HOME", tmpDir)
+
+	var services []cloud.Service
+	for _, profile := range []string{"default", "prod"} {
+		for _, region := range []string{"eu-west-1", "us-east-1"} {
+			g := graph.NewGraph()
+			g.AddResource(resourcetest.Instance("inst_" + profile + "_" + region).Build())
+			g.AddResource(resourcetest.Instance("inst_shared").Prop("Name", profile+"_"+region).Build())
+			g.AddResource(resourcetest.Subnet("sub_" + profile + "_" + region).Build())
+			services = append(services, &mockService{g: g, name: "infra", region: region, profile: profile})
+		}
+	}
+	if err := WriteLocalAccount("prod", "123456789012"); err != nil {
+		t.Fatal(err)
+	}
+	syncer := NewSyncer()
+	var wg gosync.WaitGroup
+	for _, srv := range services {
+		wg.Add(1)
+		go func(srv cloud.Service) {
+			defer wg.Done()
+			if _, err := syncer.Sync(srv); err != nil {
+				t.Error(err)
+			}
+		}(srv)
+	}
+	wg.Wait()
+
+	tcases := []struct {
+		profiles, regions []string
+		expect            map[string]string
+	}{
+		{
+			profiles: []string{"default"},
+			expect: map[string]string{
+				"inst_default_eu-west-1": "eu-west-1,default,", "inst_default_us-east-1": "us-east-1,default,",
+				"default/eu-west-1/inst_shared": "eu-west-1,default,", "default/us-east-1/inst_shared": "us-east-1,default,",
+			},
+		},
+		{
+			profiles: []string{"default", "prod"},
+			regions:  []string{"us-east-1"},
+			expect: map[string]string{
+				"inst_default_us-east-1": "us-east-1,default,", "inst_prod_us-east-1": "us-east-1,prod,123456789012",
+				"default/us-east-1/inst_shared": "us-east-1,default,", "prod/us-east-1/inst_shared": "us-east-1,prod,123456789012",
+			},
+		},
+	}
+	for i, tcase := range tcases {
+		g, err := LoadLocalGraphForType("infra", "instance", tcase.profiles, tcase.regions...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		instances, err := g.Find(cloud.NewQuery("instance"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make(map[string]string)
+		for _, inst := range instances {
+			props := inst.Properties()
+			if props["ID"] == "inst_shared" && props["Name"] != fmt.Sprintf("%s_%s", props["AWSProfile"], props["Region"]) {
+				t.Fatalf("%d: %s: got properties of another profile or region %v", i+1, inst.Id(), props)
+			}
+			key := fmt.Sprint(props["ID"])
+			if key == "inst_shared" {
+				key = inst.Id()
+			}
+			got[key] = fmt.Sprintf("%s,%s,%s", props["Region"], props["AWSProfile"], stringOrEmpty(props["Account"]))
+		}
+		if want := tcase.expect; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+		if subnets, _ := g.Find(cloud.NewQuery("subnet")); len(subnets) != 0 {
+			t.Fatalf("%d: expected only instances, got %d subnets", i+1, len(subnets))
+		}
+	}
+}
+
+func TestSyncTypes(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	os.Setenv("__AWLESS_HOME", tmpDir)
+
+	infra := &mockService{g: graph.NewGraph(), name: "infra", region: "eu-west-1", profile: "default", types: []string{"instance", "subnet", "securitygroup"}}
+	infra.g.AddResource(resourcetest.Instance("inst_1").Build(), resourcetest.Instance("inst_2").Build(), resourcetest.Subnet("sub_1").Build(), resourcetest.SecurityGroup("sg_1").Build())
+	infra.g.SetFetchStatus("subnet", &graph.FetchStatus{})
+	access := &mockService{g: graph.NewGraph(), name: "access", region: "global", profile: "default", types: []string{"user"}}
+	access.g.AddResource(resourcetest.User("user_1").Build())
+	syncer := NewSyncer()
+	if _, err = syncer.Sync(infra, access); err != nil {
+		t.Fatal(err)
+	}
+
+	infra.g = graph.NewGraph()
+	infra.g.AddResource(resourcetest.Instance("inst_3").Build(), resourcetest.Subnet("sub_2").Build(), resourcetest.SecurityGroup("sg_2").Build())
+	infra.typeErrs = map[string]error{"securitygroup": errors.New("timeout")}
+	access.g = graph.NewGraph()
+	graphs, err := syncer.SyncTypes([]string{"instance", "securitygroup"}, infra, access)
+	if err == nil {
+		t.Fatal("expected error for failed type")
+	}
+	if _, ok := graphs["access"]; ok {
+		t.Fatal("expected service without synced types to be skipped")
+	}
+
+	synced := LoadLocalGraphForService("infra", "default", "eu-west-1")
+	for typ, want := range map[string][]string{"instance": {"inst_3"}, "subnet": {"sub_1"}, "securitygroup": {"sg_1"}} {
+		resources, err := synced.Find(cloud.NewQuery(typ))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, r := range resources {
+			got = append(got, r.Id())
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: got %v, want %v", typ, got, want)
+		}
+	}
+	if users, _ := LoadLocalGraphForService("access", "default", "eu-west-1").Find(cloud.NewQuery("user")); len(users) != 1 {
+		t.Fatalf("expected unchanged users, got %d", len(users))
+	}
+
+	for typ, want := range map[string]string{"instance": TypeFetched, "subnet": TypeFetched, "securitygroup": TypeFetchFailed} {
+		st, ok := LocalFetchStatus("default", "eu-west-1", typ)
+		if !ok {
+			t.Fatalf("%s: expected fetch status", typ)
+		}
+		if got := st.Status; got != want {
+			t.Fatalf("%s: got %s, want %s", typ, got, want)
+		}
+	}
+}
+
+func stringOrEmpty(i interface{}) string {
+	if i == nil {
+		return ""
+	}
+	return fmt.Sprint(i)
+}
+
 type mockService struct {
 	name, region, profile string
 	g                     *graph.Graph
+	disabled              bool
+	types                 []string
+	typeErrs              map[string]error
 }
 
 func (s *mockService) Region() string                                { return s.region }
 func (s *mockService) Profile() string                               { return s.profile }
 func (s *mockService) Name() string                                  { return s.name }
-func (s *mockService) ResourceTypes() []string                       { return []string{} }
+func (s *mockService) ResourceTypes() []string                       { return s.types }
 func (s *mockService) Fetch(context.Context) (cloud.GraphAPI, error) { return s.g, nil }
-func (s *mockService) IsSyncDisabled() bool                          { return false }
-func (s *mockService) FetchByType(context.Context, string) (cloud.GraphAPI, error) {
-	return nil, nil
+func (s *mockService) IsSyncDisabled() bool                          { return s.disabled }
+func (s *mockService) FetchByType(_ context.Context, t string) (cloud.GraphAPI, error) {
+	g := graph.NewGraph()
+	if err := s.typeErrs[t]; err != nil {
+		g.SetFetchStatus(t, &graph.FetchStatus{Err: err})
+		return g, err
+	}
+	resources, err := s.g.GetAllResources(t)
+	if err != nil {
+		return g, err
+	}
+	g.AddResource(resources...)
+	g.SetFetchStatus(t, &graph.FetchStatus{})
+	return g, nil
 }
@@ -0,0 +1,198 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package catalog fetches templates from repositories of templates: a local directory,
+// a git repository or an HTTP base URL serving a manifest.json.
+//
+// Templates of a catalog are addressed as `catalog:name@version`. The version is
+// optional: for git catalogs it is a tag, a branch or a commit; for directory and HTTP
+// catalogs it is a subdirectory (i.e. `<base>/<version>/<name>.aws`).
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	FileExt      = ".aws"
+	ManifestFile = "manifest.json"
+	// SignatureExt is the extension of the detached signature of a template
+	SignatureExt = ".sig"
+)
+
+// Metadata describes a template of a catalog, as listed in its manifest.json
+type Metadata struct {
+	Title, Name, MinimalVersion string
+	Tags                        []string
+	Versions                    []string `json:",omitempty"`
+}
+
+type Catalog interface {
+	Name() string
+	Location() string
+	List() ([]*Metadata, error)
+	// Get returns the content of a template and its location, used to resolve its relative includes
+	Get(name, version string) ([]byte, string, error)
+	// Signature returns the detached signature of a template, nil when it is not signed
+	Signature(name, version string) ([]byte, error)
+}
+
+// IsLocal returns whether the templates of the catalog are read from the local filesystem
+func IsLocal(c Catalog) bool {
+	_, ok := c.(*dirCatalog)
+	return ok
+}
+
+// New returns the catalog at location, caching what it fetches in cacheDir to be used offline
+func New(name, location, cacheDir string) Catalog {
+	switch {
+	case isGitLocation(location):
+		return &gitCatalog{name: name, url: strings.TrimPrefix(location, "git+"), cacheDir: cacheDir}
+	case strings.HasPrefix(location, "http://"), strings.HasPrefix(location, "https://"):
+		return &httpCatalog{name: name, baseURL: strings.TrimSuffix(location, "/"), cacheDir: cacheDir}
+	default:
+		return &dirCatalog{name: name, dir: expandHome(location)}
+	}
+}
+
+func isGitLocation(location string) bool {
+	for _, prefix := range []string{"git+", "git://", "git@", "ssh://"} {
+		if strings.HasPrefix(location, prefix) {
+			return true
+		}
+	}
+	return strings.HasSuffix(location, ".git")
+}
+
+// Ref is a reference to a template of a catalog: `catalog:name@version`
+type Ref struct {
+	Catalog, Name, Version string
+}
+
+var refRegex = regexp.MustCompile(`^([a-zA-Z][\w-]+):([^@:]+)(?:@([^@:]+))?$`)
+
+// ParseRef parses a `catalog:name@version` reference.
+// It does not check the catalog exists (i.e. a relative path could be mistaken for a ref).
+// Names and versions escaping the root of the catalog (i.e. `repo:../vpc`) are not refs
+func ParseRef(s string) (*Ref, bool) {
+	matches := refRegex.FindStringSubmatch(s)
+	if len(matches) != 4 {
+		return nil, false
+	}
+	if strings.HasPrefix(matches[2], "//") { // i.e. an URL
+		return nil, false
+	}
+	name := strings.TrimSuffix(strings.TrimPrefix(matches[2], "/"), FileExt)
+	if name == "" || escapesRoot(name) || (matches[3] != "" && escapesRoot(matches[3])) {
+		return nil, false
+	}
+	return &Ref{Catalog: matches[1], Name: path.Clean(name), Version: matches[3]}, true
+}
+
+func (r *Ref) String() string {
+	if r.Version != "" {
+		return fmt.Sprintf("%s:%s@%s", r.Catalog, r.Name, r.Version)
+	}
+	return fmt.Sprintf("%s:%s", r.Catalog, r.Name)
+}
+
+// Resolve returns the reference of a template included by the template of this reference
+func (r *Ref) Resolve(include string) (*Ref, error) {
+	name := strings.TrimSuffix(path.Join(path.Dir(r.Name), include), FileExt)
+	if escapesRoot(name) {
+		return nil, fmt.Errorf("%s: include %s escapes the root of catalog %s", r, include, r.Catalog)
+	}
+	return &Ref{Catalog: r.Catalog, Name: name, Version: r.Version}, nil
+}
+
+// escapesRoot returns whether a slash separated path relative to the root of a catalog leads outside of it
+func escapesRoot(p string) bool {
+	p = path.Clean(p)
+	return p == ".." || strings.HasPrefix(p, "../") || path.IsAbs(p)
+}
+
+func templateFile(name, version string) string {
+	return path.Join(version, strings.TrimSuffix(name, FileExt)+FileExt)
+}
+
+func parseManifest(content []byte) ([]*Metadata, error) {
+	var all []*Metadata
+	if err := json.Unmarshal(content, &all); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %s", err)
+	}
+	return all, nil
+}
+
+var (
+	titleRegex          = regexp.MustCompile(`^#\s*Title:\s*(.+)$`)
+	tagsRegex           = regexp.MustCompile(`^#\s*Tags:\s*(.+)$`)
+	minimalVersionRegex = regexp.MustCompile(`^#\s*MinimalVersion:\s*(v?\d{1,3}\.\d{1,3}\.\d{1,3})`)
+)
+
+// metadataFromHeader builds the metadata of a template from its header comments, for catalogs without manifest
+func metadataFromHeader(name string, content []byte) *Metadata {
+	meta := &Metadata{Name: name, Title: name}
+	scn := bufio.NewScanner(bytes.NewReader(content))
+	for scn.Scan() {
+		line := strings.TrimSpace(scn.Text())
+		if !strings.HasPrefix(line, "#") {
+			continue
+		}
+		if m := titleRegex.FindStringSubmatch(line); len(m) > 1 {
+			meta.Title = strings.TrimSpace(m[1])
+		}
+		if m := tagsRegex.FindStringSubmatch(line); len(m) > 1 {
+			for _, t := range strings.Split(m[1], ",") {
+				if t = strings.TrimSpace(t); t != "" {
+					meta.Tags = append(meta.Tags, t)
+				}
+			}
+		}
+		if m := minimalVersionRegex.FindStringSubmatch(line); len(m) > 1 {
+			meta.MinimalVersion = m[1]
+		}
+	}
+	return meta
+}
+
+func sortMetadata(all []*Metadata) []*Metadata {
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+func expandHome(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		return filepath.Join(os.Getenv("HOME"), strings.TrimPrefix(p, "~"))
+	}
+	return p
+}
+
+func writeCache(file string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, content, 0600)
+}
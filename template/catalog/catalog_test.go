@@ -0,0 +1,266 @@
+package catalog
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/client"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/file"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport/server"
+)
+
+func TestParseRef(t *testing.T) {
+	tcases := []struct {
+		in  string
+		exp *Ref
+	}{
+		{in: "repo:create_vpc", exp: &Ref{Catalog: "repo", Name: "create_vpc"}},
+		{in: "myrepo:infra/vpc.aws@v1.2.0", exp: &Ref{Catalog: "myrepo", Name: "infra/vpc", Version: "v1.2.0"}},
+		{in: "my-repo:/vpc@master", exp: &Ref{Catalog: "my-repo", Name: "vpc", Version: "master"}},
+		{in: "vpc.aws"},
+		{in: "/tmp/vpc.aws"},
+		{in: "C:\\templates\\vpc.aws"},
+		{in: "https://example.com/vpc.aws"},
+		{in: "repo:"},
+		{in: "repo:infra/../vpc", exp: &Ref{Catalog: "repo", Name: "vpc"}},
+		{in: "repo:../vpc"},
+		{in: "repo:infra/../../vpc"},
+		{in: "repo:vpc@../.."},
+	}
+	for _, tcase := range tcases {
+		ref, ok := ParseRef(tcase.in)
+		if tcase.exp == nil {
+			if ok {
+				t.Fatalf("%s: unexpected ref %#v", tcase.in, ref)
+			}
+			continue
+		}
+		if !ok {
+			t.Fatalf("%s: expected ref", tcase.in)
+		}
+		if got, want := ref, tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+	}
+
+	ref, _ := ParseRef("myrepo:infra/instance@v1")
+	resolved, err := ref.Resolve("../network/vpc.aws")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := resolved.String(), "myrepo:network/vpc@v1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	for _, include := range []string{"../../x", "../../../etc/passwd"} {
+		if _, err := ref.Resolve(include); err == nil {
+			t.Fatalf("%s: expected error for include escaping the catalog", include)
+		}
+	}
+}
+
+func TestDirCatalog(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	writeFile(t, filepath.Join(dir, "vpc.aws"), "# Title: Create a VPC\n# Tags: vpc, network\ncreate vpc cidr={cidr}")
+	writeFile(t, filepath.Join(dir, "v1", "vpc.aws"), "create vpc cidr=10.0.0.0/16")
+	writeFile(t, filepath.Join(dir, "readme.md"), "templates")
+
+	cat := New("mine", dir, "")
+	list, err := cat.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := list, []*Metadata{{Name: "vpc", Title: "Create a VPC", Tags: []string{"vpc", "network"}}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	content, location, err := cat.Get("vpc", "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(content), "create vpc cidr=10.0.0.0/16"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := location, filepath.Join(dir, "v1", "vpc.aws"); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	writeFile(t, filepath.Join(dir, ManifestFile), `[{"Name": "vpc", "Title": "VPC", "Versions": ["v1"]}]`)
+	list, err = cat.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := list, []*Metadata{{Name: "vpc", Title: "VPC", Versions: []string{"v1"}}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestHTTPCatalog(t *testing.T) {
+	cacheDir := tempDir(t)
+	defer os.RemoveAll(cacheDir)
+
+	files := map[string]string{
+		"/manifest.json":  `[{"Name": "vpc", "Title": "Create a VPC", "Tags": ["vpc"]}]`,
+		"/vpc.aws":        "create vpc cidr={cidr}",
+		"/v2/vpc.aws":     "create vpc cidr={vpc.cidr}",
+		"/v2/private.aws": "",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(content))
+	}))
+
+	cat := New("web", server.URL+"/", cacheDir)
+	list, err := cat.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := list, []*Metadata{{Name: "vpc", Title: "Create a VPC", Tags: []string{"vpc"}}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	content, location, err := cat.Get("vpc", "v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(content), "create vpc cidr={vpc.cidr}"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := location, server.URL+"/v2/vpc.aws"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if _, _, err = cat.Get("unknown", ""); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, _, err = cat.Get("../../escaped", ""); err == nil {
+		t.Fatal("expected error for template outside of the catalog")
+	}
+	if _, err = os.Stat(filepath.Join(cacheDir, "..", "..", "escaped.aws")); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing cached outside of the cache dir, got %v", err)
+	}
+
+	server.Close()
+
+	if _, err = cat.List(); err != nil {
+		t.Fatalf("expected cached manifest, got %s", err)
+	}
+	content, _, err = cat.Get("vpc", "v2")
+	if err != nil {
+		t.Fatalf("expected cached template, got %s", err)
+	}
+	if got, want := string(content), "create vpc cidr={vpc.cidr}"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if _, _, err = cat.Get("vpc", ""); err == nil {
+		t.Fatal("expected error for template never fetched")
+	}
+}
+
+func TestGitCatalog(t *testing.T) {
+	origin := tempDir(t)
+	defer os.RemoveAll(origin)
+	cacheDir := tempDir(t)
+	defer os.RemoveAll(cacheDir)
+
+	repo, err := git.PlainInit(origin, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	originURL := filepath.Join(origin, ".git")
+	endpoint, err := transport.NewEndpoint(originURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// serve the repository in-process rather than with the installed git-upload-pack
+	client.InstallProtocol("file", server.NewServer(server.MapLoader{endpoint.String(): repo.Storer}))
+	defer client.InstallProtocol("file", file.DefaultClient)
+	commit := func(file, content string) plumbing.Hash {
+		writeFile(t, filepath.Join(origin, file), content)
+		wt, err := repo.Worktree()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = wt.Add(file); err != nil {
+			t.Fatal(err)
+		}
+		h, err := wt.Commit("update "+file, &git.CommitOptions{Author: &object.Signature{Name: "test", Email: "test@awless.io", When: time.Now()}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+	first := commit("vpc.aws", "# Title: Create a VPC\ncreate vpc cidr=10.0.0.0/16")
+	if err = repo.Storer.SetReference(plumbing.NewHashReference("refs/tags/v1", first)); err != nil {
+		t.Fatal(err)
+	}
+	commit("vpc.aws", "# Title: Create a VPC\ncreate vpc cidr={vpc.cidr}")
+
+	cat := New("git", originURL, filepath.Join(cacheDir, "git"))
+	list, err := cat.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := list, []*Metadata{{Name: "vpc", Title: "Create a VPC", Versions: []string{"v1"}}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	tcases := []struct {
+		version, exp string
+	}{
+		{version: "", exp: "# Title: Create a VPC\ncreate vpc cidr={vpc.cidr}"},
+		{version: "master", exp: "# Title: Create a VPC\ncreate vpc cidr={vpc.cidr}"},
+		{version: "v1", exp: "# Title: Create a VPC\ncreate vpc cidr=10.0.0.0/16"},
+		{version: first.String(), exp: "# Title: Create a VPC\ncreate vpc cidr=10.0.0.0/16"},
+	}
+	for _, tcase := range tcases {
+		content, location, err := cat.Get("vpc", tcase.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(content), tcase.exp; got != want {
+			t.Fatalf("%s: got %s, want %s", tcase.version, got, want)
+		}
+		if got, want := location, (&Ref{Catalog: "git", Name: "vpc", Version: tcase.version}).String(); got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	}
+	if _, _, err = cat.Get("vpc", "v3"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	client.InstallProtocol("file", server.NewServer(server.MapLoader{}))
+	offline := New("git", originURL, filepath.Join(cacheDir, "git"))
+	if _, _, err := offline.Get("vpc", "v1"); err != nil {
+		t.Fatalf("expected cached clone, got %s", err)
+	}
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "awless-catalog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,54 @@
+package catalog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type dirCatalog struct {
+	name, dir string
+}
+
+func (c *dirCatalog) Name() string     { return c.name }
+func (c *dirCatalog) Location() string { return c.dir }
+
+func (c *dirCatalog) List() ([]*Metadata, error) {
+	if content, err := ioutil.ReadFile(filepath.Join(c.dir, ManifestFile)); err == nil {
+		return parseManifest(content)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return nil, err
+	}
+	var all []*Metadata
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != FileExt {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(c.dir, f.Name()))
+		if err != nil {
+			return all, err
+		}
+		all = append(all, metadataFromHeader(strings.TrimSuffix(f.Name(), FileExt), content))
+	}
+	return sortMetadata(all), nil
+}
+
+func (c *dirCatalog) Get(name, version string) ([]byte, string, error) {
+	path := filepath.Join(c.dir, filepath.FromSlash(templateFile(name, version)))
+	content, err := ioutil.ReadFile(path)
+	return content, path, err
+}
+
+func (c *dirCatalog) Signature(name, version string) ([]byte, error) {
+	sig, err := ioutil.ReadFile(filepath.Join(c.dir, filepath.FromSlash(templateFile(name, version)+SignatureExt)))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return sig, err
+}
@@ -0,0 +1,196 @@
+package catalog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/wallix/awless/logger"
+	git "gopkg.in/src-d/go-git.v4"
+	gitconfig "gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+)
+
+// gitCatalog keeps a bare clone of the repository in its cache dir, updated once per run
+type gitCatalog struct {
+	name, url, cacheDir string
+
+	once sync.Once
+	repo *git.Repository
+	err  error
+}
+
+func (c *gitCatalog) Name() string     { return c.name }
+func (c *gitCatalog) Location() string { return c.url }
+
+func (c *gitCatalog) List() ([]*Metadata, error) {
+	repo, err := c.open()
+	if err != nil {
+		return nil, err
+	}
+	commit, err := resolveCommit(repo, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var all []*Metadata
+	if f, err := commit.File(ManifestFile); err == nil {
+		content, err := f.Contents()
+		if err != nil {
+			return nil, err
+		}
+		all, err = parseManifest([]byte(content))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		tree, err := commit.Tree()
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range tree.Entries {
+			if !entry.Mode.IsFile() || filepath.Ext(entry.Name) != FileExt {
+				continue
+			}
+			f, err := tree.TreeEntryFile(&entry)
+			if err != nil {
+				return all, err
+			}
+			content, err := f.Contents()
+			if err != nil {
+				return all, err
+			}
+			all = append(all, metadataFromHeader(strings.TrimSuffix(entry.Name, FileExt), []byte(content)))
+		}
+	}
+
+	tags, err := tagNames(repo)
+	if err != nil {
+		return all, err
+	}
+	for _, meta := range all {
+		if len(meta.Versions) == 0 {
+			meta.Versions = tags
+		}
+	}
+	return sortMetadata(all), nil
+}
+
+func (c *gitCatalog) Get(name, version string) ([]byte, string, error) {
+	ref := &Ref{Catalog: c.name, Name: strings.TrimSuffix(name, FileExt), Version: version}
+	repo, err := c.open()
+	if err != nil {
+		return nil, ref.String(), err
+	}
+	commit, err := resolveCommit(repo, version)
+	if err != nil {
+		return nil, ref.String(), err
+	}
+	f, err := commit.File(templateFile(name, ""))
+	if err != nil {
+		return nil, ref.String(), fmt.Errorf("template catalog %s: %s: %s", c.name, templateFile(name, ""), err)
+	}
+	content, err := f.Contents()
+	return []byte(content), ref.String(), err
+}
+
+func (c *gitCatalog) Signature(name, version string) ([]byte, error) {
+	repo, err := c.open()
+	if err != nil {
+		return nil, err
+	}
+	commit, err := resolveCommit(repo, version)
+	if err != nil {
+		return nil, err
+	}
+	f, err := commit.File(templateFile(name, "") + SignatureExt)
+	if err == object.ErrFileNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	sig, err := f.Contents()
+	return []byte(sig), err
+}
+
+func (c *gitCatalog) open() (*git.Repository, error) {
+	c.once.Do(func() {
+		c.repo, c.err = c.update()
+	})
+	return c.repo, c.err
+}
+
+// update clones the repository on first use, and otherwise fetches it, using the
+// cached clone as is when the remote cannot be reached
+func (c *gitCatalog) update() (*git.Repository, error) {
+	if c.cacheDir == "" {
+		return nil, fmt.Errorf("template catalog %s: no cache directory to clone %s", c.name, c.url)
+	}
+	repo, err := git.PlainOpen(c.cacheDir)
+	if err == git.ErrRepositoryNotExists {
+		logger.ExtraVerbosef("cloning template catalog %s from %s", c.name, c.url)
+		if err = os.MkdirAll(filepath.Dir(c.cacheDir), 0700); err != nil {
+			return nil, err
+		}
+		repo, err = git.PlainClone(c.cacheDir, true, &git.CloneOptions{URL: c.url})
+		if err != nil {
+			os.RemoveAll(c.cacheDir)
+			return nil, fmt.Errorf("template catalog %s: clone %s: %s", c.name, c.url, err)
+		}
+		return repo, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger.ExtraVerbosef("fetching template catalog %s from %s", c.name, c.url)
+	err = repo.Fetch(&git.FetchOptions{
+		RefSpecs: []gitconfig.RefSpec{"+refs/heads/*:refs/heads/*"},
+		Tags:     git.AllTags,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		logger.Warningf("template catalog %s: cannot fetch %s: %s. Using cached templates", c.name, c.url, err)
+	}
+	return repo, nil
+}
+
+var hashRegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// resolveCommit resolves a version as a tag, a branch or a full commit hash (HEAD when empty)
+func resolveCommit(repo *git.Repository, version string) (*object.Commit, error) {
+	if version == "" {
+		version = string(plumbing.HEAD)
+	}
+	if hashRegex.MatchString(version) {
+		return repo.CommitObject(plumbing.NewHash(version))
+	}
+	for _, name := range []string{version, "refs/tags/" + version, "refs/heads/" + version, "refs/remotes/origin/" + version} {
+		ref, err := storer.ResolveReference(repo.Storer, plumbing.ReferenceName(name))
+		if err != nil {
+			continue
+		}
+		if tag, err := repo.TagObject(ref.Hash()); err == nil {
+			return tag.Commit()
+		}
+		return repo.CommitObject(ref.Hash())
+	}
+	return nil, fmt.Errorf("unknown version %s (expecting a tag, a branch or a full commit hash)", version)
+}
+
+func tagNames(repo *git.Repository) ([]string, error) {
+	iter, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		names = append(names, ref.Name().Short())
+		return nil
+	})
+	return names, err
+}
@@ -0,0 +1,96 @@
+package catalog
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+
+	"github.com/wallix/awless/logger"
+)
+
+type httpCatalog struct {
+	name, baseURL, cacheDir string
+}
+
+func (c *httpCatalog) Name() string     { return c.name }
+func (c *httpCatalog) Location() string { return c.baseURL }
+
+func (c *httpCatalog) List() ([]*Metadata, error) {
+	content, err := c.fetch(ManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	return parseManifest(content)
+}
+
+func (c *httpCatalog) Get(name, version string) ([]byte, string, error) {
+	file := templateFile(name, version)
+	content, err := c.fetch(file)
+	return content, fmt.Sprintf("%s/%s", c.baseURL, file), err
+}
+
+func (c *httpCatalog) Signature(name, version string) ([]byte, error) {
+	sig, err := c.fetch(templateFile(name, version) + SignatureExt)
+	if _, notFound := err.(*notFoundError); notFound {
+		return nil, nil
+	}
+	return sig, err
+}
+
+// fetch gets a file of the catalog, falling back on the cached one when the catalog cannot be reached
+func (c *httpCatalog) fetch(file string) ([]byte, error) {
+	if escapesRoot(file) {
+		return nil, fmt.Errorf("template catalog %s: %s is outside of the catalog", c.name, file)
+	}
+	url := fmt.Sprintf("%s/%s", c.baseURL, file)
+	var cached string
+	if c.cacheDir != "" {
+		cached = filepath.Join(c.cacheDir, filepath.FromSlash(file))
+	}
+	logger.ExtraVerbosef("fetching '%s' of template catalog %s", url, c.name)
+	content, err := readHTTPContent(url)
+	if err != nil {
+		if _, unreachable := err.(*unreachableError); unreachable && cached != "" {
+			if content, cerr := ioutil.ReadFile(cached); cerr == nil {
+				logger.Warningf("template catalog %s: %s. Using cached %s", c.name, err, file)
+				return content, nil
+			}
+		}
+		return nil, err
+	}
+	if cached != "" {
+		if err := writeCache(cached, content); err != nil {
+			logger.Warningf("template catalog %s: cannot cache %s: %s", c.name, file, err)
+		}
+	}
+	return content, nil
+}
+
+type unreachableError struct {
+	error
+}
+
+type notFoundError struct {
+	error
+}
+
+func readHTTPContent(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, &unreachableError{err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("'%s' when fetching '%s'", resp.Status, url)
+		switch {
+		case resp.StatusCode >= 500:
+			return nil, &unreachableError{err}
+		case resp.StatusCode == http.StatusNotFound:
+			return nil, &notFoundError{err}
+		}
+		return nil, err
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -14,6 +14,7 @@ type Mode []compileFunc
 
 var (
 	TestCompileMode = []compileFunc{
+		resolveIncludesPass,
 		injectCommandsInNodesPass,
 		failOnDeclarationWithNoResultPass,
 		processAndValidateParamsPass,
@@ -22,11 +23,13 @@ var (
 		resolveMissingHolesPass,
 		removeOptionalHolesPass,
 		resolveAliasPass,
+		evaluateFunctionsPass,
 		inlineVariableValuePass,
 		resolveParamsAndExtractRefsPass,
 	}
 
 	NewRunnerCompileMode = []compileFunc{
+		resolveIncludesPass,
 		injectCommandsInNodesPass,
 		failOnDeclarationWithNoResultPass,
 		processAndValidateParamsPass,
@@ -35,6 +38,7 @@ var (
 		resolveMissingHolesPass,
 		removeOptionalHolesPass,
 		resolveAliasPass,
+		evaluateFunctionsPass,
 		inlineVariableValuePass,
 		failOnUnresolvedHolesPass,
 		failOnUnresolvedAliasPass,
@@ -175,7 +179,7 @@ func resolveParamsAndExtractRefsPass(tpl *Template, cenv env.Compiling) (*Templa
 					case ast.RefNode:
 						hasRef = true
 						arr = append(arr, e)
-					case ast.HoleNode, ast.ConcatenationNode, ast.AliasNode, ast.ListNode:
+					case ast.HoleNode, ast.ConcatenationNode, ast.AliasNode, ast.ListNode, ast.FunctionNode:
 						return tpl, cenv, fmt.Errorf("%s: unresolved value in list of type %T", k, e)
 					default:
 						arr = append(arr, e)
@@ -191,6 +195,8 @@ func resolveParamsAndExtractRefsPass(tpl *Template, cenv env.Compiling) (*Templa
 				node.ParamNodes[k] = paramNode.Concat()
 			case ast.HoleNode, ast.AliasNode:
 				return tpl, cenv, fmt.Errorf("%s: unresolved value of type %T", k, paramNode)
+			case ast.FunctionNode:
+				return tpl, cenv, fmt.Errorf("%s: unevaluated function %s", k, paramNode)
 			}
 		}
 	}
@@ -270,6 +276,50 @@ func inlineVariableValuePass(tpl *Template, cenv env.Compiling) (*Template, env.
 	return newTpl, cenv, nil
 }
 
+// evaluateFunctionsPass replaces function calls with their result, checked against the type of the param they are assigned to
+func evaluateFunctionsPass(tpl *Template, cenv env.Compiling) (*Template, env.Compiling, error) {
+	type paramTyper interface {
+		ParamType(string) (string, bool)
+	}
+
+	values := make(map[string]interface{})
+	for _, st := range tpl.Statements {
+		var cmd *ast.CommandNode
+		switch n := st.Node.(type) {
+		case *ast.CommandNode:
+			cmd = n
+		case *ast.DeclarationNode:
+			cmd, _ = n.Expr.(*ast.CommandNode)
+		}
+		err := ast.ProcessFunctions(st, func(fn ast.FunctionNode, key string) (interface{}, bool, error) {
+			res, done, err := evaluateFunction(fn, values)
+			if err != nil || !done {
+				return nil, done, cmdErr(cmd, err)
+			}
+			if cmd == nil {
+				return res, true, nil
+			}
+			if typer, ok := cmd.Command.(paramTyper); ok {
+				if awsType, hasType := typer.ParamType(key); hasType {
+					if res, err = convertFunctionResult(res, awsType); err != nil {
+						return nil, false, cmdErr(cmd, "%s: %s: %s", key, fn.Name(), err)
+					}
+				}
+			}
+			return res, true, nil
+		})
+		if err != nil {
+			return tpl, cenv, err
+		}
+		if decl, isDecl := st.Node.(*ast.DeclarationNode); isDecl {
+			if right, isRightExpr := decl.Expr.(*ast.RightExpressionNode); isRightExpr {
+				values[decl.Ident] = right.Node()
+			}
+		}
+	}
+	return tpl, cenv, nil
+}
+
 func resolveHolesPass(tpl *Template, cenv env.Compiling) (*Template, env.Compiling, error) {
 	processed := ast.ProcessHoles(tpl.AST, cenv.Get(env.FILLERS))
 	cenv.Push(env.PROCESSED_FILLERS, processed)
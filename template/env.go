@@ -16,6 +16,7 @@ type runEnv struct {
 	log    *logger.Logger
 	dryRun bool
 	ctx    map[string]interface{}
+	sim    *env.Simulation
 }
 
 func NewRunEnv(cenv env.Compiling, context ...map[string]interface{}) env.Running {
@@ -38,9 +39,19 @@ func (e *runEnv) IsDryRun() bool {
 }
 
 func (e *runEnv) SetDryRun(b bool) {
+	if b {
+		e.sim = env.NewSimulation()
+	}
 	e.dryRun = b
 }
 
+func (e *runEnv) Simulation() *env.Simulation {
+	if e.sim == nil {
+		e.sim = env.NewSimulation()
+	}
+	return e.sim
+}
+
 func (e *runEnv) Context() (out map[string]interface{}) {
 	out = make(map[string]interface{})
 	for k, v := range e.ctx {
@@ -58,6 +69,7 @@ type compileEnv struct {
 	lookupCommandFunc func(...string) interface{}
 	aliasFunc         func(paramPath, alias string) string
 	missingHolesFunc  func(string, []string, bool) string
+	includeFunc       func(path, from string) (string, string, error)
 	log               *logger.Logger
 	paramsSuggested   int
 }
@@ -74,6 +86,10 @@ func (e *compileEnv) MissingHolesFunc() func(string, []string, bool) string {
 	return e.missingHolesFunc
 }
 
+func (e *compileEnv) IncludeFunc() func(path, from string) (string, string, error) {
+	return e.includeFunc
+}
+
 func (e *compileEnv) ParamsMode() int {
 	return e.paramsSuggested
 }
@@ -143,6 +159,11 @@ func (b *envBuilder) WithLookupCommandFunc(fn func(...string) interface{}) *envB
 	return b
 }
 
+func (b *envBuilder) WithIncludeFunc(fn func(path, from string) (string, string, error)) *envBuilder {
+	b.E.includeFunc = fn
+	return b
+}
+
 func (b *envBuilder) WithLog(l *logger.Logger) *envBuilder {
 	b.E.log = l
 	return b
@@ -1,6 +1,8 @@
 package env
 
 import (
+	"sync"
+
 	"github.com/wallix/awless/logger"
 )
 
@@ -25,6 +27,47 @@ type Running interface {
 	Context() map[string]interface{}
 	IsDryRun() bool
 	SetDryRun(b bool)
+	Simulation() *Simulation
+}
+
+// Simulation records the resources created and deleted by the commands already dry run in a template,
+// so that the next commands are not checked against the local data only. It is reset for each dry run.
+type Simulation struct {
+	mu      sync.Mutex
+	created map[string]bool
+	deleted map[string]bool
+}
+
+func NewSimulation() *Simulation {
+	return &Simulation{created: make(map[string]bool), deleted: make(map[string]bool)}
+}
+
+// Create records a resource created by a dry run command, by its type and a reference (id, name, ...)
+func (s *Simulation) Create(resourceType, ref string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.created[resourceType+"/"+ref] = true
+	delete(s.deleted, resourceType+"/"+ref)
+}
+
+// Delete records a resource deleted by a dry run command, by its type and a reference (id, name, ...)
+func (s *Simulation) Delete(resourceType, ref string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleted[resourceType+"/"+ref] = true
+	delete(s.created, resourceType+"/"+ref)
+}
+
+func (s *Simulation) IsCreated(resourceType, ref string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.created[resourceType+"/"+ref]
+}
+
+func (s *Simulation) IsDeleted(resourceType, ref string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deleted[resourceType+"/"+ref]
 }
 
 type Compiling interface {
@@ -32,6 +75,7 @@ type Compiling interface {
 	LookupCommandFunc() func(...string) interface{}
 	AliasFunc() func(paramPath, alias string) string
 	MissingHolesFunc() func(string, []string, bool) string
+	IncludeFunc() func(path, from string) (string, string, error)
 	ParamsMode() int
 	Push(int, ...map[string]interface{})
 	Get(int) map[string]interface{}
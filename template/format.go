@@ -0,0 +1,38 @@
+package template
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+// Format returns the canonical text of the template: statements are printed with their
+// AST String(), comments are kept in place and consecutive blank lines collapse into one
+func (t *Template) Format() string {
+	type line struct {
+		pos  ast.Position
+		text string
+	}
+	var lines []line
+	for _, st := range t.Statements {
+		lines = append(lines, line{pos: st.Pos, text: st.String()})
+	}
+	for _, c := range t.Comments {
+		lines = append(lines, line{pos: c.Pos, text: strings.TrimSpace(c.Text)})
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].pos.Line < lines[j].pos.Line
+	})
+
+	var buff bytes.Buffer
+	for i, l := range lines {
+		if i > 0 && l.pos.Line > lines[i-1].pos.Line+1 {
+			buff.WriteByte('\n')
+		}
+		buff.WriteString(l.text)
+		buff.WriteByte('\n')
+	}
+	return buff.String()
+}
@@ -0,0 +1,248 @@
+package template
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+type builtinFunc struct {
+	minArgs, maxArgs int
+	call             func(args []interface{}) (interface{}, error)
+}
+
+var builtinFuncs = map[string]builtinFunc{
+	"cidrsubnet": {3, 3, func(args []interface{}) (interface{}, error) {
+		prefix, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		newbits, err := intArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		netnum, err := intArg(args, 2)
+		if err != nil {
+			return nil, err
+		}
+		return cidrSubnet(prefix, newbits, netnum)
+	}},
+	"env": {1, 1, func(args []interface{}) (interface{}, error) {
+		name, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", name)
+		}
+		return value, nil
+	}},
+	"lower": {1, 1, func(args []interface{}) (interface{}, error) {
+		s, err := stringArg(args, 0)
+		return strings.ToLower(s), err
+	}},
+	"uuid": {0, 0, func(args []interface{}) (interface{}, error) {
+		return newUUID()
+	}},
+	"now": {0, 1, func(args []interface{}) (interface{}, error) {
+		layout := time.RFC3339
+		if len(args) > 0 {
+			var err error
+			if layout, err = stringArg(args, 0); err != nil {
+				return nil, err
+			}
+		}
+		return time.Now().UTC().Format(layout), nil
+	}},
+	"file": {1, 1, func(args []interface{}) (interface{}, error) {
+		path, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadFile(expandHome(path))
+		return string(content), err
+	}},
+	"base64": {1, 1, func(args []interface{}) (interface{}, error) {
+		s, err := stringArg(args, 0)
+		return base64.StdEncoding.EncodeToString([]byte(s)), err
+	}},
+}
+
+// BuiltinFunctions lists the functions usable in template values
+func BuiltinFunctions() (names []string) {
+	for name := range builtinFuncs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+func callBuiltinFunc(name string, args []interface{}) (interface{}, error) {
+	fn, ok := builtinFuncs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function '%s', expected one of %s", name, strings.Join(BuiltinFunctions(), ", "))
+	}
+	if l := len(args); l < fn.minArgs || l > fn.maxArgs {
+		if fn.minArgs == fn.maxArgs {
+			return nil, fmt.Errorf("%s: expects %d argument(s), got %d", name, fn.minArgs, l)
+		}
+		return nil, fmt.Errorf("%s: expects %d to %d argument(s), got %d", name, fn.minArgs, fn.maxArgs, l)
+	}
+	res, err := fn.call(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	return res, nil
+}
+
+// convertFunctionResult checks the result of a function against the awsType of the param it is assigned to
+func convertFunctionResult(res interface{}, awsType string) (interface{}, error) {
+	s := fmt.Sprint(res)
+	switch {
+	case awsType == "awsuserdatatobase64" || strings.HasPrefix(awsType, "awsfileto"):
+		return nil, errors.New("expected a file path or URL read when running the command, not a function result")
+	case awsType == "awsint" || strings.Contains(awsType, "int64"):
+		i, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer, got '%s'", s)
+		}
+		return i, nil
+	case strings.HasPrefix(awsType, "awsbool"):
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("expected a boolean, got '%s'", s)
+		}
+		return b, nil
+	case awsType == "awsfloat":
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected a float, got '%s'", s)
+		}
+		return f, nil
+	default:
+		return res, nil
+	}
+}
+
+// expandHome resolves a leading ~ of a path to the user home directory
+func expandHome(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		return filepath.Join(os.Getenv("HOME"), strings.TrimPrefix(p, "~"))
+	}
+	return p
+}
+
+func stringArg(args []interface{}, i int) (string, error) {
+	switch v := args[i].(type) {
+	case string:
+		return v, nil
+	case int, float64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("argument %d: expected a string, got %T", i+1, v)
+	}
+}
+
+func intArg(args []interface{}, i int) (int, error) {
+	switch v := args[i].(type) {
+	case int:
+		return v, nil
+	case string:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("argument %d: expected an integer, got '%s'", i+1, v)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("argument %d: expected an integer, got %T", i+1, v)
+	}
+}
+
+// cidrSubnet computes the subnet number netnum of the network prefix extended by newbits
+func cidrSubnet(prefix string, newbits, netnum int) (string, error) {
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return "", err
+	}
+	ones, bits := network.Mask.Size()
+	newOnes := ones + newbits
+	if newbits < 0 || newOnes > bits {
+		return "", fmt.Errorf("cannot extend prefix /%d by %d bits", ones, newbits)
+	}
+	num := big.NewInt(int64(netnum))
+	if netnum < 0 || num.BitLen() > newbits {
+		return "", fmt.Errorf("network number %d does not fit in %d bits", netnum, newbits)
+	}
+	ip := new(big.Int).SetBytes(network.IP)
+	ip.Or(ip, num.Lsh(num, uint(bits-newOnes)))
+
+	ipBytes := ip.Bytes()
+	subnetIP := make(net.IP, len(network.IP))
+	copy(subnetIP[len(subnetIP)-len(ipBytes):], ipBytes)
+
+	subnet := &net.IPNet{IP: subnetIP, Mask: net.CIDRMask(newOnes, bits)}
+	return subnet.String(), nil
+}
+
+// newUUID generates a random (version 4) UUID
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("cannot generate random uuid")
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// evaluateFunction calls the builtin function with its evaluated arguments.
+// Refs in arguments are resolved from the given values of the variables declared so far.
+func evaluateFunction(fn ast.FunctionNode, values map[string]interface{}) (interface{}, bool, error) {
+	var args []interface{}
+	for _, arg := range fn.Args() {
+		val, done, err := evaluateFunctionArg(fn, arg, values)
+		if err != nil || !done {
+			return nil, done, err
+		}
+		args = append(args, val)
+	}
+	res, err := callBuiltinFunc(fn.Name(), args)
+	return res, err == nil, err
+}
+
+func evaluateFunctionArg(fn ast.FunctionNode, arg interface{}, values map[string]interface{}) (interface{}, bool, error) {
+	switch a := arg.(type) {
+	case ast.FunctionNode:
+		return evaluateFunction(a, values)
+	case ast.InterfaceNode:
+		return a.Value(), true, nil
+	case ast.RefNode:
+		val, ok := values[a.Ref()]
+		if !ok {
+			return nil, false, fmt.Errorf("%s: %s is only known when running the template", fn.Name(), a)
+		}
+		return evaluateFunctionArg(fn, val, values)
+	case ast.HoleNode, ast.AliasNode:
+		return nil, false, nil
+	case ast.ConcatenationNode:
+		if len(ast.CollectHoles(a)) > 0 {
+			return nil, false, nil
+		}
+		return a.Concat(), true, nil
+	default:
+		return a, true, nil
+	}
+}
@@ -0,0 +1,181 @@
+package template
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/template/env"
+)
+
+func TestBuiltinFunctions(t *testing.T) {
+	os.Setenv("AWLESS_TEST_FUNCTION", "Value")
+	defer os.Unsetenv("AWLESS_TEST_FUNCTION")
+
+	dir, err := ioutil.TempDir("", "awless-functions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	userdata := filepath.Join(dir, "userdata.sh")
+	if err = ioutil.WriteFile(userdata, []byte("#!/bin/bash"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", dir)
+
+	tcases := []struct {
+		name   string
+		args   []interface{}
+		exp    string
+		expErr string
+	}{
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0/16", 8, 2}, exp: "10.0.2.0/24"},
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0/16", 4, 15}, exp: "10.0.240.0/20"},
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0/16", "8", "255"}, exp: "10.0.255.0/24"},
+		{name: "cidrsubnet", args: []interface{}{"fd00:fd12:3456:7890::/56", 16, 162}, exp: "fd00:fd12:3456:7800:a200::/72"},
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0/16", 8, 256}, expErr: "network number 256 does not fit in 8 bits"},
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0/30", 8, 1}, expErr: "cannot extend prefix /30 by 8 bits"},
+		{name: "cidrsubnet", args: []interface{}{"10.0.0.0/16", 8}, expErr: "cidrsubnet: expects 3 argument(s), got 2"},
+		{name: "env", args: []interface{}{"AWLESS_TEST_FUNCTION"}, exp: "Value"},
+		{name: "env", args: []interface{}{"AWLESS_TEST_UNSET_FUNCTION"}, expErr: "environment variable AWLESS_TEST_UNSET_FUNCTION is not set"},
+		{name: "lower", args: []interface{}{"My-Instance"}, exp: "my-instance"},
+		{name: "base64", args: []interface{}{"#!/bin/bash"}, exp: "IyEvYmluL2Jhc2g="},
+		{name: "file", args: []interface{}{userdata}, exp: "#!/bin/bash"},
+		{name: "file", args: []interface{}{"~/userdata.sh"}, exp: "#!/bin/bash"},
+		{name: "file", args: []interface{}{filepath.Join(dir, "missing")}, expErr: "no such file"},
+		{name: "now", args: []interface{}{"2006"}, exp: time.Now().UTC().Format("2006")},
+		{name: "now", args: []interface{}{"2006", "01"}, expErr: "now: expects 0 to 1 argument(s), got 2"},
+		{name: "upper", args: []interface{}{"any"}, expErr: "unknown function 'upper'"},
+	}
+
+	for i, tcase := range tcases {
+		res, err := callBuiltinFunc(tcase.name, tcase.args)
+		if tcase.expErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tcase.expErr) {
+				t.Fatalf("%d: got %v, want error containing %s", i+1, err, tcase.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := res, tcase.exp; got != want {
+			t.Fatalf("%d: got %v, want %s", i+1, got, want)
+		}
+	}
+
+	uuid, err := callBuiltinFunc("uuid", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$").MatchString(uuid.(string)) {
+		t.Fatalf("invalid uuid %s", uuid)
+	}
+}
+
+func TestParseFunctions(t *testing.T) {
+	tcases := []struct {
+		in, exp string
+	}{
+		{in: `create subnet cidr=cidrsubnet("10.0.0.0/16", 8, 2)`, exp: `create subnet cidr=cidrsubnet("10.0.0.0/16", 8, 2)`},
+		{in: `create instance name=lower(env('USER')) userdata=base64(file("~/userdata.sh"))`, exp: `create instance name=lower(env("USER")) userdata=base64(file("~/userdata.sh"))`},
+		{in: `create instance name=lower({instance.name}+'-suffix') subnet=$sub`, exp: `create instance name=lower({instance.name}+'-suffix') subnet=$sub`},
+		{in: `create bucket name=uuid( )`, exp: `create bucket name=uuid()`},
+		{in: `create tag key=date resource=@myinstance value=now("2006-01-02")`, exp: `create tag key=date resource=@myinstance value=now("2006-01-02")`},
+		{in: `id = uuid()`, exp: `id = uuid()`},
+		{in: `create securitygroup cidr=[lower(A),cidrsubnet($cidr,8,1)]`, exp: `create securitygroup cidr=[lower("A"),cidrsubnet($cidr, 8, 1)]`},
+	}
+	for i, tcase := range tcases {
+		tpl, err := Parse(tcase.in)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := tpl.String(), tcase.exp; got != want {
+			t.Fatalf("%d: got %s, want %s", i+1, got, want)
+		}
+		if _, err = Parse(tpl.String()); err != nil {
+			t.Fatalf("%d: cannot parse back %s: %s", i+1, tpl.String(), err)
+		}
+	}
+}
+
+func TestEvaluateFunctionsPass(t *testing.T) {
+	os.Setenv("AWLESS_TEST_COUNT", "3")
+	defer os.Unsetenv("AWLESS_TEST_COUNT")
+
+	tcases := []struct {
+		tpl     string
+		fillers map[string]interface{}
+		exp     string
+		expErr  string
+	}{
+		{
+			tpl: `create subnet cidr=cidrsubnet("10.0.0.0/16", 8, 2) vpc=vpc-1234 availabilityzone=eu-west-1a`,
+			exp: "create subnet availabilityzone=eu-west-1a cidr=10.0.2.0/24 vpc=vpc-1234",
+		},
+		{
+			tpl:     "create subnet cidr=cidrsubnet({vpc.cidr}, 8, {subnet.index}) vpc=vpc-1234 availabilityzone=eu-west-1a",
+			fillers: map[string]interface{}{"vpc.cidr": "10.0.0.0/16", "subnet.index": 3},
+			exp:     "create subnet availabilityzone=eu-west-1a cidr=10.0.3.0/24 vpc=vpc-1234",
+		},
+		{
+			tpl: "vpccidr = 192.168.0.0/16\nname = lower('My-Subnet')\ncreate subnet cidr=cidrsubnet($vpccidr, 8, 1) vpc=vpc-1234 name=$name availabilityzone=eu-west-1a",
+			exp: "create subnet availabilityzone=eu-west-1a cidr=192.168.1.0/24 name=my-subnet vpc=vpc-1234",
+		},
+		{
+			tpl: `create instance count=env("AWLESS_TEST_COUNT") image=ami-1234 name=any subnet=sub-1234 type=t2.micro`,
+			exp: "create instance count=3 image=ami-1234 name=any subnet=sub-1234 type=t2.micro",
+		},
+		{
+			tpl:    `create instance count=lower("many") image=ami-1234 name=any subnet=sub-1234 type=t2.micro`,
+			expErr: "create instance: count: lower: expected an integer, got 'many'",
+		},
+		{
+			tpl:    `create instance count=1 image=ami-1234 name=any subnet=sub-1234 type=t2.micro userdata=base64(lower("#!/bin/bash"))`,
+			expErr: "create instance: userdata: base64: expected a file path or URL read when running the command",
+		},
+		{
+			tpl:    "vpc = create vpc cidr=10.0.0.0/16\ncreate subnet cidr=cidrsubnet($vpc, 8, 1) vpc=$vpc availabilityzone=eu-west-1a",
+			expErr: "create subnet: cidrsubnet: $vpc is only known when running the template",
+		},
+		{
+			tpl:    `create subnet cidr=subnet("10.0.0.0/16") vpc=vpc-1234 availabilityzone=eu-west-1a`,
+			expErr: "create subnet: unknown function 'subnet'",
+		},
+	}
+
+	for i, tcase := range tcases {
+		cenv := NewEnv().WithLookupCommandFunc(lookupMockCommand).Build()
+		cenv.Push(env.FILLERS, tcase.fillers)
+		compiled, _, err := Compile(MustParse(tcase.tpl), cenv, NewRunnerCompileMode)
+		if tcase.expErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tcase.expErr) {
+				t.Fatalf("%d: got %v, want error containing %s", i+1, err, tcase.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := compiled.String(), tcase.exp; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+
+	t.Run("variable evaluated once", func(t *testing.T) {
+		cenv := NewEnv().WithLookupCommandFunc(lookupMockCommand).Build()
+		compiled, _, err := Compile(MustParse("id = uuid()\ncreate bucket name=$id\ncreate queue name=$id"), cenv, NewRunnerCompileMode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cmds := compiled.CommandNodesIterator()
+		if got, want := cmds[0].ParamNodes["name"], cmds[1].ParamNodes["name"]; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package template
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+// resolveIncludesPass inlines included templates. Holes of an included template
+// are scoped with its name (i.e. {cidr} in vpc.aws becomes {vpc.cidr}) and filled
+// with the include params. Its declared variables are available to the caller.
+func resolveIncludesPass(tpl *Template, cenv env.Compiling) (*Template, env.Compiling, error) {
+	statements, err := expandIncludes(tpl.Statements, cenv.IncludeFunc(), "", nil)
+	if err != nil {
+		return tpl, cenv, err
+	}
+	tpl.Statements = statements
+	return tpl, cenv, nil
+}
+
+func expandIncludes(statements []*ast.Statement, includeFunc func(path, from string) (string, string, error), from string, visited []string) ([]*ast.Statement, error) {
+	var expanded []*ast.Statement
+	declared := make(declarationSites)
+	for _, st := range statements {
+		include, isInclude := st.Node.(*ast.IncludeNode)
+		if !isInclude {
+			if decl, ok := st.Node.(*ast.DeclarationNode); ok {
+				if err := declared.add(decl.Ident, declarationSite{line: st.Pos.Line}, from); err != nil {
+					return nil, err
+				}
+			}
+			expanded = append(expanded, st)
+			continue
+		}
+		if includeFunc == nil {
+			return nil, fmt.Errorf("include %s: no template loader defined", include.Path)
+		}
+		text, fullPath, err := includeFunc(include.Path, from)
+		if err != nil {
+			return nil, fmt.Errorf("include %s: %s", include.Path, err)
+		}
+		stack := append(append([]string{}, visited...), fullPath)
+		if contains(visited, fullPath) {
+			return nil, fmt.Errorf("include loop detected: %s", strings.Join(stack, " -> "))
+		}
+		sub, err := Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("include %s: %s", include.Path, err)
+		}
+		subStatements, err := expandIncludes(sub.Statements, includeFunc, fullPath, stack)
+		if err != nil {
+			return nil, err
+		}
+		subTree := &ast.AST{Statements: subStatements}
+
+		scope := includeScope(include.Path)
+		scoped := make(map[string]interface{})
+		for _, hole := range ast.CollectHoles(subTree) {
+			scoped[hole.Hole()] = ast.NewHoleNode(scope + "." + hole.Hole())
+		}
+		ast.ProcessHoles(subTree, scoped)
+
+		fillers := make(map[string]interface{})
+		for k, v := range include.ParamNodes {
+			fillers[scope+"."+k] = v
+		}
+		processed := ast.ProcessHoles(subTree, fillers)
+		for k := range include.ParamNodes {
+			if _, ok := processed[scope+"."+k]; !ok {
+				return nil, fmt.Errorf("include %s: unexpected param '%s': no such hole in template", include.Path, k)
+			}
+		}
+
+		site := declarationSite{include: include.Path, line: st.Pos.Line}
+		for _, sub := range subTree.Statements {
+			if decl, ok := sub.Node.(*ast.DeclarationNode); ok {
+				if err := declared.add(decl.Ident, site, from); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		expanded = append(expanded, subTree.Statements...)
+	}
+	return expanded, nil
+}
+
+// declarationSite is where a variable is declared in a template: by one of its statements or by an included template
+type declarationSite struct {
+	include string
+	line    int
+}
+
+func (s declarationSite) String() string {
+	switch {
+	case s.include == "":
+		return fmt.Sprintf("line %d", s.line)
+	case s.line > 0:
+		return fmt.Sprintf("include %s (line %d)", s.include, s.line)
+	default:
+		return fmt.Sprintf("include %s", s.include)
+	}
+}
+
+// declarationSites detects the variables declared twice because of includes (i.e. a template included twice,
+// or two included templates declaring the same variable), as the statements of included templates are inlined
+type declarationSites map[string]declarationSite
+
+func (d declarationSites) add(ident string, site declarationSite, from string) error {
+	if prev, ok := d[ident]; ok && prev != site && (prev.include != "" || site.include != "") {
+		err := fmt.Errorf("variable '%s' declared by both %s and %s", ident, prev, site)
+		if from != "" {
+			err = fmt.Errorf("%s: %s", from, err)
+		}
+		return err
+	}
+	d[ident] = site
+	return nil
+}
+
+// includeScope is the name of the included template without extension,
+// i.e. vpc for "modules/vpc.aws" or "repo:vpc"
+func includeScope(includePath string) string {
+	name := path.Base(includePath)
+	if i := strings.LastIndexByte(name, ':'); i > -1 {
+		name = name[i+1:]
+	}
+	return strings.TrimSuffix(name, path.Ext(name))
+}
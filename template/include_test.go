@@ -0,0 +1,121 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+func TestParseInclude(t *testing.T) {
+	tcases := []struct {
+		in, exp string
+	}{
+		{in: `include "modules/vpc.aws"`, exp: `include "modules/vpc.aws"`},
+		{in: `include 'repo:create_vpc' cidr=10.0.0.0/16`, exp: `include "repo:create_vpc" cidr=10.0.0.0/16`},
+		{in: `include modules/vpc.aws name={vpc.name} subnets=[$sub1,$sub2]`, exp: `include "modules/vpc.aws" name={vpc.name} subnets=[$sub1,$sub2]`},
+		{in: "sub1 = create subnet\ninclude \"bastion.aws\" subnet=$sub1", exp: "sub1 = create subnet\ninclude \"bastion.aws\" subnet=$sub1"},
+	}
+	for i, tcase := range tcases {
+		tpl, err := Parse(tcase.in)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := tpl.String(), tcase.exp; got != want {
+			t.Fatalf("%d: got %s, want %s", i+1, got, want)
+		}
+	}
+
+	tpl := MustParse(`include "modules/vpc.aws" cidr=10.0.0.0/16`)
+	include, ok := tpl.Statements[0].Node.(*ast.IncludeNode)
+	if !ok {
+		t.Fatalf("expected include node, got %T", tpl.Statements[0].Node)
+	}
+	if got, want := include.Path, "modules/vpc.aws"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestResolveIncludesPass(t *testing.T) {
+	templates := map[string]string{
+		"vpc.aws":     "vpc = create vpc cidr={cidr} name={name}\ncreate subnet vpc=$vpc cidr={subnet.cidr}",
+		"bastion.aws": "include vpc.aws cidr={network}\ncreate instance subnet=$vpc name=bastion",
+		"loop.aws":    "include other.aws",
+		"other.aws":   "include loop.aws",
+		"invalid.aws": "create instance name=",
+		"network.aws": "vpc = create vpc cidr=10.0.0.0/8",
+		"twice.aws":   "include vpc.aws\ninclude vpc.aws",
+	}
+	includeFunc := func(path, from string) (string, string, error) {
+		text, ok := templates[path]
+		if !ok {
+			return "", "", fmt.Errorf("template not found")
+		}
+		return text, "/templates/" + path, nil
+	}
+	cenv := NewEnv().WithIncludeFunc(includeFunc).Build()
+
+	tcases := []struct {
+		tpl, exp, expErr string
+	}{
+		{
+			tpl: "include vpc.aws cidr=10.0.0.0/16\ncreate instance subnet=$vpc",
+			exp: "vpc = create vpc cidr=10.0.0.0/16 name={vpc.name}\ncreate subnet cidr={vpc.subnet.cidr} vpc=$vpc\ncreate instance subnet=$vpc",
+		},
+		{
+			tpl: "name = my-vpc\ninclude 'vpc.aws' cidr={vpc.cidr} name=$name subnet.cidr=@mysubnet",
+			exp: "name = my-vpc\nvpc = create vpc cidr={vpc.cidr} name=$name\ncreate subnet cidr=@mysubnet vpc=$vpc",
+		},
+		{
+			tpl: "include bastion.aws network=10.0.0.0/16",
+			exp: "vpc = create vpc cidr=10.0.0.0/16 name={bastion.vpc.name}\ncreate subnet cidr={bastion.vpc.subnet.cidr} vpc=$vpc\ncreate instance name=bastion subnet=$vpc",
+		},
+		{tpl: "include loop.aws", expErr: "include loop detected: /templates/loop.aws -> /templates/other.aws -> /templates/loop.aws"},
+		{tpl: "include vpc.aws region=eu-west-1", expErr: "unexpected param 'region'"},
+		{tpl: "include unknown.aws", expErr: "include unknown.aws: template not found"},
+		{tpl: "include invalid.aws", expErr: "include invalid.aws: error parsing template"},
+		{tpl: "include vpc.aws cidr=10.0.0.0/16\ninclude vpc.aws cidr=10.1.0.0/16", expErr: "variable 'vpc' declared by both include vpc.aws (line 1) and include vpc.aws (line 2)"},
+		{tpl: "include vpc.aws\ninclude network.aws", expErr: "variable 'vpc' declared by both include vpc.aws (line 1) and include network.aws (line 2)"},
+		{tpl: "vpc = create vpc\ninclude vpc.aws", expErr: "variable 'vpc' declared by both line 1 and include vpc.aws (line 2)"},
+		{tpl: "include twice.aws", expErr: "/templates/twice.aws: variable 'vpc' declared by both include vpc.aws (line 1) and include vpc.aws (line 2)"},
+	}
+
+	for i, tcase := range tcases {
+		compiled, _, err := resolveIncludesPass(MustParse(tcase.tpl), cenv)
+		if tcase.expErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tcase.expErr) {
+				t.Fatalf("%d: got %v, want error containing %s", i+1, err, tcase.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := compiled.String(), tcase.exp; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+
+	t.Run("no loader", func(t *testing.T) {
+		_, _, err := resolveIncludesPass(MustParse("include vpc.aws"), NewEnv().Build())
+		if err == nil || !strings.Contains(err.Error(), "no template loader defined") {
+			t.Fatalf("got %v, want no loader error", err)
+		}
+	})
+}
+
+func TestIncludeScope(t *testing.T) {
+	tcases := map[string]string{
+		"vpc.aws":                          "vpc",
+		"modules/network/vpc.aws":          "vpc",
+		"repo:create_vpc":                  "create_vpc",
+		"https://example.com/bastion.aws":  "bastion",
+		"/home/user/templates/bastion.txt": "bastion",
+	}
+	for in, exp := range tcases {
+		if got, want := includeScope(in), exp; got != want {
+			t.Fatalf("%s: got %s, want %s", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package integrity verifies the content of templates against a SHA-256 digest or
+// a detached ed25519 signature, either raw (base64 of the 64 bytes signature) or in
+// the minisign format (https://jedisct1.github.io/minisign/).
+package integrity
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ed25519"
+)
+
+const (
+	untrustedCommentPrefix = "untrusted comment:"
+	trustedCommentPrefix   = "trusted comment:"
+)
+
+var (
+	legacyAlg    = []byte("Ed")
+	prehashedAlg = []byte("ED")
+)
+
+var ErrNoTrustedKeys = errors.New("no trusted keys")
+
+// VerifySHA256 checks the hex encoded SHA-256 digest of content
+func VerifySHA256(content []byte, digest string) error {
+	sum := sha256.Sum256(content)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, strings.TrimSpace(digest)) {
+		return fmt.Errorf("sha256 mismatch: got %s, expected %s", got, digest)
+	}
+	return nil
+}
+
+// PublicKey is an ed25519 public key trusted to sign templates
+type PublicKey struct {
+	Name string
+	// ID is the key id of minisign keys, empty for raw ed25519 keys
+	ID  []byte
+	Key ed25519.PublicKey
+}
+
+func (k *PublicKey) String() string {
+	if len(k.ID) > 0 {
+		return fmt.Sprintf("%s (%X)", k.Name, reverse(k.ID))
+	}
+	return k.Name
+}
+
+// ParsePublicKey parses a base64 raw ed25519 public key or a minisign public key
+// (its base64 line, with or without its untrusted comment line)
+func ParsePublicKey(name, s string) (*PublicKey, error) {
+	var encoded string
+	for _, line := range nonEmptyLines(s) {
+		if !strings.HasPrefix(line, untrustedCommentPrefix) {
+			encoded = line
+			break
+		}
+	}
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("public key %s: %s", name, err)
+	}
+	switch {
+	case len(b) == ed25519.PublicKeySize:
+		return &PublicKey{Name: name, Key: ed25519.PublicKey(b)}, nil
+	case len(b) == 2+8+ed25519.PublicKeySize && bytes.Equal(b[:2], legacyAlg):
+		return &PublicKey{Name: name, ID: b[2:10], Key: ed25519.PublicKey(b[10:])}, nil
+	default:
+		return nil, fmt.Errorf("public key %s: expecting a base64 ed25519 or minisign public key", name)
+	}
+}
+
+// Verify checks the detached signature of content, returning the trusted key it was signed with
+func Verify(content, signature []byte, keys []*PublicKey) (*PublicKey, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoTrustedKeys
+	}
+	lines := nonEmptyLines(string(signature))
+	if len(lines) > 0 && strings.HasPrefix(lines[0], untrustedCommentPrefix) {
+		return verifyMinisign(content, lines, keys)
+	}
+	if len(lines) != 1 {
+		return nil, errors.New("invalid signature: expecting a base64 ed25519 signature or a minisign signature")
+	}
+	sig, err := base64.StdEncoding.DecodeString(lines[0])
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return nil, errors.New("invalid signature: expecting a base64 ed25519 signature or a minisign signature")
+	}
+	for _, k := range keys {
+		if ed25519.Verify(k.Key, content, sig) {
+			return k, nil
+		}
+	}
+	return nil, errors.New("signature does not match any trusted key")
+}
+
+func verifyMinisign(content []byte, lines []string, keys []*PublicKey) (*PublicKey, error) {
+	if len(lines) != 4 || !strings.HasPrefix(lines[2], trustedCommentPrefix) {
+		return nil, errors.New("invalid minisign signature: expecting 4 lines")
+	}
+	b, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(b) != 2+8+ed25519.SignatureSize {
+		return nil, errors.New("invalid minisign signature")
+	}
+	alg, keyID, sig := b[:2], b[2:10], b[10:]
+	message := content
+	switch {
+	case bytes.Equal(alg, legacyAlg):
+	case bytes.Equal(alg, prehashedAlg):
+		sum := blake2b.Sum512(content)
+		message = sum[:]
+	default:
+		return nil, fmt.Errorf("invalid minisign signature: unsupported algorithm %q", alg)
+	}
+	globalSig, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return nil, errors.New("invalid minisign signature: invalid global signature")
+	}
+	trustedComment := strings.TrimPrefix(lines[2], trustedCommentPrefix)
+	trustedComment = strings.TrimPrefix(trustedComment, " ")
+
+	for _, k := range keys {
+		if len(k.ID) > 0 && !bytes.Equal(k.ID, keyID) {
+			continue
+		}
+		if !ed25519.Verify(k.Key, message, sig) {
+			continue
+		}
+		if !ed25519.Verify(k.Key, append(append([]byte{}, sig...), trustedComment...), globalSig) {
+			return nil, fmt.Errorf("invalid minisign signature: trusted comment does not match signature of key %s", k)
+		}
+		return k, nil
+	}
+	return nil, fmt.Errorf("signature with key id %X does not match any trusted key", reverse(keyID))
+}
+
+func nonEmptyLines(s string) (lines []string) {
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return
+}
+
+// reverse returns the key id as displayed by minisign (little endian)
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
@@ -0,0 +1,143 @@
+package integrity
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/ed25519"
+)
+
+func TestVerifySHA256(t *testing.T) {
+	content := []byte("create vpc cidr=10.0.0.0/16")
+	if err := VerifySHA256(content, "4ba2b3ec2b6e8e4e0a8a5e8c0e2c2a9b9c7cd9e5b22f5e6d0d24e1b6b5e1e3a1"); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := VerifySHA256([]byte("abc"), " BA7816BF8F01CFEA414140DE5DAE2223B00361A396177A9CB410FF61F20015AD\n"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	pub, _ := generateKey(t)
+	key, err := ParsePublicKey("raw", base64.StdEncoding.EncodeToString(pub))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(key.Key), string(pub); got != want || len(key.ID) != 0 {
+		t.Fatalf("got %#v", key)
+	}
+
+	id := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	minisignKey := fmt.Sprintf("untrusted comment: minisign public key 0807060504030201\n%s\n", minisignEncode(legacyAlg, id, pub))
+	key, err = ParsePublicKey("mini", minisignKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(key.Key), string(pub); got != want {
+		t.Fatalf("got %x, want %x", got, want)
+	}
+	if got, want := key.String(), "mini (0807060504030201)"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if _, err = ParsePublicKey("invalid", "not base64"); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err = ParsePublicKey("short", base64.StdEncoding.EncodeToString([]byte("short"))); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestVerifyRawSignature(t *testing.T) {
+	content := []byte("create vpc cidr=10.0.0.0/16")
+	pub, priv := generateKey(t)
+	other, _ := generateKey(t)
+	keys := []*PublicKey{{Name: "other", Key: other}, {Name: "trusted", Key: pub}}
+	sig := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, content)) + "\n")
+
+	key, err := Verify(content, sig, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := key.Name, "trusted"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if _, err = Verify([]byte("create vpc cidr=0.0.0.0/0"), sig, keys); err == nil {
+		t.Fatal("expected error for tampered content")
+	}
+	if _, err = Verify(content, sig, keys[:1]); err == nil {
+		t.Fatal("expected error for untrusted key")
+	}
+	if _, err = Verify(content, sig, nil); err != ErrNoTrustedKeys {
+		t.Fatalf("got %v, want %v", err, ErrNoTrustedKeys)
+	}
+	if _, err = Verify(content, []byte("garbage"), keys); err == nil {
+		t.Fatal("expected error for invalid signature")
+	}
+}
+
+func TestVerifyMinisignSignature(t *testing.T) {
+	content := []byte("create vpc cidr=10.0.0.0/16")
+	pub, priv := generateKey(t)
+	id := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	key := &PublicKey{Name: "mini", ID: id, Key: pub}
+
+	for _, alg := range [][]byte{legacyAlg, prehashedAlg} {
+		sig := minisignSign(priv, alg, id, content, "timestamp:1508000000\tfile:vpc.aws")
+		verified, err := Verify(content, []byte(sig), []*PublicKey{key})
+		if err != nil {
+			t.Fatalf("%s: %s", alg, err)
+		}
+		if verified != key {
+			t.Fatalf("%s: got %s", alg, verified)
+		}
+		if _, err = Verify([]byte("create vpc cidr=0.0.0.0/0"), []byte(sig), []*PublicKey{key}); err == nil {
+			t.Fatalf("%s: expected error for tampered content", alg)
+		}
+		tampered := strings.Replace(sig, "file:vpc.aws", "file:other.aws", 1)
+		if _, err = Verify(content, []byte(tampered), []*PublicKey{key}); err == nil {
+			t.Fatalf("%s: expected error for tampered trusted comment", alg)
+		}
+	}
+
+	otherID := &PublicKey{Name: "other", ID: []byte{8, 7, 6, 5, 4, 3, 2, 1}, Key: pub}
+	sig := minisignSign(priv, legacyAlg, id, content, "comment")
+	if _, err := Verify(content, []byte(sig), []*PublicKey{otherID}); err == nil {
+		t.Fatal("expected error for unknown key id")
+	}
+	if _, err := Verify(content, []byte(sig), []*PublicKey{{Name: "raw", Key: pub}}); err != nil {
+		t.Fatalf("expected raw key to verify minisign signature, got %s", err)
+	}
+}
+
+func generateKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pub, priv
+}
+
+func minisignEncode(alg, id, b []byte) string {
+	var all []byte
+	all = append(all, alg...)
+	all = append(all, id...)
+	all = append(all, b...)
+	return base64.StdEncoding.EncodeToString(all)
+}
+
+func minisignSign(priv ed25519.PrivateKey, alg, id, content []byte, trustedComment string) string {
+	message := content
+	if string(alg) == string(prehashedAlg) {
+		sum := blake2b.Sum512(content)
+		message = sum[:]
+	}
+	sig := ed25519.Sign(priv, message)
+	globalSig := ed25519.Sign(priv, append(append([]byte{}, sig...), trustedComment...))
+	return fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		minisignEncode(alg, id, sig), trustedComment, base64.StdEncoding.EncodeToString(globalSig))
+}
@@ -33,6 +33,7 @@ type Node interface {
 
 type AST struct {
 	Statements []*Statement
+	Comments   []*Comment
 
 	// state to build the AST
 	stmtBuilder *statementBuilder
@@ -40,6 +41,23 @@ type AST struct {
 
 type Statement struct {
 	Node
+	Pos      Position
+	ParamPos map[string]Position
+}
+
+// Position in the template text, starting at line 1 column 1
+type Position struct {
+	Line, Col int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Col)
+}
+
+// Comment is a commented line, kept apart from statements
+type Comment struct {
+	Text string
+	Pos  Position
 }
 
 type DeclarationNode struct {
@@ -200,8 +218,14 @@ func (c *CommandNode) ToFillerParams() map[string]interface{} {
 }
 
 func (s *Statement) Clone() *Statement {
-	newStat := &Statement{}
+	newStat := &Statement{Pos: s.Pos}
 	newStat.Node = s.Node.clone()
+	if s.ParamPos != nil {
+		newStat.ParamPos = make(map[string]Position)
+		for k, v := range s.ParamPos {
+			newStat.ParamPos[k] = v
+		}
+	}
 
 	return newStat
 }
@@ -214,6 +238,38 @@ func (a *AST) String() string {
 	return strings.Join(all, "\n")
 }
 
+// IncludeNode inlines the template at Path, filling its holes with ParamNodes
+type IncludeNode struct {
+	Path       string
+	ParamNodes map[string]interface{}
+}
+
+func (n *IncludeNode) String() string {
+	var all []string
+	for k, v := range n.ParamNodes {
+		all = append(all, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(all)
+
+	var buff bytes.Buffer
+	fmt.Fprintf(&buff, "include %s", doubleQuote(n.Path))
+	if len(all) > 0 {
+		fmt.Fprintf(&buff, " %s", strings.Join(all, " "))
+	}
+	return buff.String()
+}
+
+func (n *IncludeNode) clone() Node {
+	include := &IncludeNode{
+		Path:       n.Path,
+		ParamNodes: make(map[string]interface{}),
+	}
+	for k, v := range n.ParamNodes {
+		include.ParamNodes[k] = v
+	}
+	return include
+}
+
 func (n *DeclarationNode) clone() Node {
 	decl := &DeclarationNode{
 		Ident: n.Ident,
@@ -233,6 +289,9 @@ func (a *AST) Clone() *AST {
 	for _, stat := range a.Statements {
 		clone.Statements = append(clone.Statements, stat.Clone())
 	}
+	for _, c := range a.Comments {
+		clone.Comments = append(clone.Comments, &Comment{Text: c.Text, Pos: c.Pos})
+	}
 	return clone
 }
 
@@ -21,6 +21,8 @@ const (
 	ruleDeclaration
 	ruleValueExpr
 	ruleCmdExpr
+	ruleIncludeExpr
+	ruleIncludePath
 	ruleParams
 	ruleParam
 	ruleIdentifier
@@ -29,6 +31,10 @@ const (
 	ruleListWithoutSquareBrackets
 	ruleNoRefValue
 	ruleValue
+	ruleFunctionValue
+	ruleFunctionName
+	ruleFunctionArgs
+	ruleFunctionArg
 	ruleCustomTypedValue
 	ruleUnquotedParamValue
 	ruleUnquotedParam
@@ -55,8 +61,8 @@ const (
 	ruleEndOfLine
 	ruleEndOfFile
 	ruleAction0
-	ruleAction1
 	rulePegText
+	ruleAction1
 	ruleAction2
 	ruleAction3
 	ruleAction4
@@ -80,6 +86,14 @@ const (
 	ruleAction22
 	ruleAction23
 	ruleAction24
+	ruleAction25
+	ruleAction26
+	ruleAction27
+	ruleAction28
+	ruleAction29
+	ruleAction30
+	ruleAction31
+	ruleAction32
 )
 
 var rul3s = [...]string{
@@ -91,6 +105,8 @@ var rul3s = [...]string{
 	"Declaration",
 	"ValueExpr",
 	"CmdExpr",
+	"IncludeExpr",
+	"IncludePath",
 	"Params",
 	"Param",
 	"Identifier",
@@ -99,6 +115,10 @@ var rul3s = [...]string{
 	"ListWithoutSquareBrackets",
 	"NoRefValue",
 	"Value",
+	"FunctionValue",
+	"FunctionName",
+	"FunctionArgs",
+	"FunctionArg",
 	"CustomTypedValue",
 	"UnquotedParamValue",
 	"UnquotedParam",
@@ -125,8 +145,8 @@ var rul3s = [...]string{
 	"EndOfLine",
 	"EndOfFile",
 	"Action0",
-	"Action1",
 	"PegText",
+	"Action1",
 	"Action2",
 	"Action3",
 	"Action4",
@@ -150,6 +170,14 @@ var rul3s = [...]string{
 	"Action22",
 	"Action23",
 	"Action24",
+	"Action25",
+	"Action26",
+	"Action27",
+	"Action28",
+	"Action29",
+	"Action30",
+	"Action31",
+	"Action32",
 }
 
 type token32 struct {
@@ -266,7 +294,7 @@ type Peg struct {
 
 	Buffer string
 	buffer []rune
-	rules  [67]func() bool
+	rules  [81]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -361,53 +389,69 @@ func (p *Peg) Execute() {
 		case ruleAction0:
 			p.NewStatement()
 		case ruleAction1:
-			p.StatementDone()
+			p.markStatement(p.position(end))
 		case ruleAction2:
-			p.addDeclarationIdentifier(text)
+			p.StatementDone()
 		case ruleAction3:
-			p.addValue()
+			p.addDeclarationIdentifier(text)
 		case ruleAction4:
-			p.addAction(text)
+			p.addValue()
 		case ruleAction5:
-			p.addEntity(text)
+			p.addAction(text)
 		case ruleAction6:
-			p.addParamKey(text)
+			p.addEntity(text)
 		case ruleAction7:
-			p.addFirstValueInList()
+			p.addIncludePath(text)
 		case ruleAction8:
-			p.lastValueInList()
+			p.addParamKey(text)
 		case ruleAction9:
-			p.addFirstValueInList()
+			p.markParamKey(text, p.position(begin))
 		case ruleAction10:
-			p.lastValueInList()
+			p.addFirstValueInList()
 		case ruleAction11:
-			p.addAliasParam(text)
+			p.lastValueInList()
 		case ruleAction12:
-			p.addParamRefValue(text)
+			p.addFirstValueInList()
 		case ruleAction13:
-			p.addParamValue(text)
+			p.lastValueInList()
 		case ruleAction14:
-			p.addParamValue(text)
+			p.addAliasParam(text)
 		case ruleAction15:
-			p.addFirstValueInConcatenation()
+			p.addParamRefValue(text)
 		case ruleAction16:
-			p.lastValueInConcatenation()
+			p.addFunctionName(text)
 		case ruleAction17:
-			p.addFirstValueInConcatenation()
+			p.lastValueInFunction()
 		case ruleAction18:
-			p.lastValueInConcatenation()
+			p.addParamRefValue(text)
 		case ruleAction19:
-			p.addStringValue(text)
+			p.addAliasParam(text)
 		case ruleAction20:
-			p.addParamHoleValue(text)
+			p.addParamValue(text)
 		case ruleAction21:
-			p.addFirstValueInConcatenation()
+			p.addParamValue(text)
 		case ruleAction22:
-			p.lastValueInConcatenation()
-		case ruleAction23:
 			p.addFirstValueInConcatenation()
+		case ruleAction23:
+			p.lastValueInConcatenation()
 		case ruleAction24:
+			p.addFirstValueInConcatenation()
+		case ruleAction25:
+			p.lastValueInConcatenation()
+		case ruleAction26:
+			p.addStringValue(text)
+		case ruleAction27:
+			p.addParamHoleValue(text)
+		case ruleAction28:
+			p.addFirstValueInConcatenation()
+		case ruleAction29:
+			p.lastValueInConcatenation()
+		case ruleAction30:
+			p.addFirstValueInConcatenation()
+		case ruleAction31:
 			p.lastValueInConcatenation()
+		case ruleAction32:
+			p.addComment(text)
 
 		}
 	}
@@ -502,307 +546,499 @@ func (p *Peg) Init() {
 					{
 						add(ruleAction0, position)
 					}
-					if !_rules[ruleWhiteSpacing]() {
-						goto l0
+					{
+						position8 := position
+						if !_rules[ruleWhiteSpacing]() {
+							goto l0
+						}
+						add(rulePegText, position8)
+					}
+					{
+						add(ruleAction1, position)
 					}
 					{
-						position8, tokenIndex8 := position, tokenIndex
+						position10, tokenIndex10 := position, tokenIndex
+						{
+							position12 := position
+							if buffer[position] != rune('i') {
+								goto l11
+							}
+							position++
+							if buffer[position] != rune('n') {
+								goto l11
+							}
+							position++
+							if buffer[position] != rune('c') {
+								goto l11
+							}
+							position++
+							if buffer[position] != rune('l') {
+								goto l11
+							}
+							position++
+							if buffer[position] != rune('u') {
+								goto l11
+							}
+							position++
+							if buffer[position] != rune('d') {
+								goto l11
+							}
+							position++
+							if buffer[position] != rune('e') {
+								goto l11
+							}
+							position++
+							if !_rules[ruleMustWhiteSpacing]() {
+								goto l11
+							}
+							{
+								position13 := position
+								{
+									switch buffer[position] {
+									case '\'':
+										if !_rules[ruleSingleQuotedValue]() {
+											goto l11
+										}
+										break
+									case '"':
+										if !_rules[ruleDoubleQuotedValue]() {
+											goto l11
+										}
+										break
+									default:
+										{
+											position15 := position
+											if !_rules[ruleUnquotedParam]() {
+												goto l11
+											}
+											add(rulePegText, position15)
+										}
+										break
+									}
+								}
+
+								{
+									add(ruleAction7, position)
+								}
+								add(ruleIncludePath, position13)
+							}
+							{
+								position17, tokenIndex17 := position, tokenIndex
+								if !_rules[ruleMustWhiteSpacing]() {
+									goto l17
+								}
+								if !_rules[ruleParams]() {
+									goto l17
+								}
+								goto l18
+							l17:
+								position, tokenIndex = position17, tokenIndex17
+							}
+						l18:
+							add(ruleIncludeExpr, position12)
+						}
+						goto l10
+					l11:
+						position, tokenIndex = position10, tokenIndex10
 						if !_rules[ruleCmdExpr]() {
-							goto l9
+							goto l19
 						}
-						goto l8
-					l9:
-						position, tokenIndex = position8, tokenIndex8
+						goto l10
+					l19:
+						position, tokenIndex = position10, tokenIndex10
 						{
-							position11 := position
+							position21 := position
 							{
-								position12 := position
+								position22 := position
 								if !_rules[ruleIdentifier]() {
-									goto l10
+									goto l20
 								}
-								add(rulePegText, position12)
+								add(rulePegText, position22)
 							}
 							{
-								add(ruleAction2, position)
+								add(ruleAction3, position)
 							}
 							if !_rules[ruleEqual]() {
-								goto l10
+								goto l20
 							}
 							{
-								position14, tokenIndex14 := position, tokenIndex
+								position24, tokenIndex24 := position, tokenIndex
 								if !_rules[ruleCmdExpr]() {
-									goto l15
+									goto l25
 								}
-								goto l14
-							l15:
-								position, tokenIndex = position14, tokenIndex14
+								goto l24
+							l25:
+								position, tokenIndex = position24, tokenIndex24
 								{
-									position16 := position
+									position26 := position
 									{
-										add(ruleAction3, position)
+										add(ruleAction4, position)
 									}
 									if !_rules[ruleCompositeValue]() {
-										goto l10
+										goto l20
 									}
-									add(ruleValueExpr, position16)
+									add(ruleValueExpr, position26)
 								}
 							}
-						l14:
-							add(ruleDeclaration, position11)
+						l24:
+							add(ruleDeclaration, position21)
 						}
-						goto l8
-					l10:
-						position, tokenIndex = position8, tokenIndex8
+						goto l10
+					l20:
+						position, tokenIndex = position10, tokenIndex10
 						{
-							position18 := position
+							position28 := position
 							{
-								position19, tokenIndex19 := position, tokenIndex
-								if buffer[position] != rune('#') {
-									goto l20
-								}
-								position++
-							l21:
+								position29 := position
 								{
-									position22, tokenIndex22 := position, tokenIndex
+									position30, tokenIndex30 := position, tokenIndex
+									if buffer[position] != rune('#') {
+										goto l31
+									}
+									position++
+								l32:
 									{
-										position23, tokenIndex23 := position, tokenIndex
-										if !_rules[ruleEndOfLine]() {
-											goto l23
+										position33, tokenIndex33 := position, tokenIndex
+										{
+											position34, tokenIndex34 := position, tokenIndex
+											if !_rules[ruleEndOfLine]() {
+												goto l34
+											}
+											goto l33
+										l34:
+											position, tokenIndex = position34, tokenIndex34
 										}
-										goto l22
-									l23:
-										position, tokenIndex = position23, tokenIndex23
+										if !matchDot() {
+											goto l33
+										}
+										goto l32
+									l33:
+										position, tokenIndex = position33, tokenIndex33
 									}
-									if !matchDot() {
-										goto l22
+									goto l30
+								l31:
+									position, tokenIndex = position30, tokenIndex30
+									if buffer[position] != rune('/') {
+										goto l0
 									}
-									goto l21
-								l22:
-									position, tokenIndex = position22, tokenIndex22
-								}
-								goto l19
-							l20:
-								position, tokenIndex = position19, tokenIndex19
-								if buffer[position] != rune('/') {
-									goto l0
-								}
-								position++
-								if buffer[position] != rune('/') {
-									goto l0
-								}
-								position++
-							l24:
-								{
-									position25, tokenIndex25 := position, tokenIndex
+									position++
+									if buffer[position] != rune('/') {
+										goto l0
+									}
+									position++
+								l35:
 									{
-										position26, tokenIndex26 := position, tokenIndex
-										if !_rules[ruleEndOfLine]() {
-											goto l26
+										position36, tokenIndex36 := position, tokenIndex
+										{
+											position37, tokenIndex37 := position, tokenIndex
+											if !_rules[ruleEndOfLine]() {
+												goto l37
+											}
+											goto l36
+										l37:
+											position, tokenIndex = position37, tokenIndex37
 										}
-										goto l25
-									l26:
-										position, tokenIndex = position26, tokenIndex26
-									}
-									if !matchDot() {
-										goto l25
+										if !matchDot() {
+											goto l36
+										}
+										goto l35
+									l36:
+										position, tokenIndex = position36, tokenIndex36
 									}
-									goto l24
-								l25:
-									position, tokenIndex = position25, tokenIndex25
 								}
+							l30:
+								add(rulePegText, position29)
+							}
+							{
+								add(ruleAction32, position)
 							}
-						l19:
-							add(ruleComment, position18)
+							add(ruleComment, position28)
 						}
 					}
-				l8:
+				l10:
 					if !_rules[ruleWhiteSpacing]() {
 						goto l0
 					}
-				l27:
+				l39:
 					{
-						position28, tokenIndex28 := position, tokenIndex
+						position40, tokenIndex40 := position, tokenIndex
 						if !_rules[ruleEndOfLine]() {
-							goto l28
+							goto l40
 						}
-						goto l27
-					l28:
-						position, tokenIndex = position28, tokenIndex28
+						goto l39
+					l40:
+						position, tokenIndex = position40, tokenIndex40
 					}
 					{
-						add(ruleAction1, position)
+						add(ruleAction2, position)
 					}
 					add(ruleStatement, position6)
 				}
-			l30:
+			l42:
 				{
-					position31, tokenIndex31 := position, tokenIndex
+					position43, tokenIndex43 := position, tokenIndex
 					if !_rules[ruleBlankLine]() {
-						goto l31
+						goto l43
 					}
-					goto l30
-				l31:
-					position, tokenIndex = position31, tokenIndex31
+					goto l42
+				l43:
+					position, tokenIndex = position43, tokenIndex43
 				}
 			l2:
 				{
 					position3, tokenIndex3 := position, tokenIndex
-				l32:
+				l44:
 					{
-						position33, tokenIndex33 := position, tokenIndex
+						position45, tokenIndex45 := position, tokenIndex
 						if !_rules[ruleBlankLine]() {
-							goto l33
+							goto l45
 						}
-						goto l32
-					l33:
-						position, tokenIndex = position33, tokenIndex33
+						goto l44
+					l45:
+						position, tokenIndex = position45, tokenIndex45
 					}
 					{
-						position34 := position
+						position46 := position
 						{
 							add(ruleAction0, position)
 						}
-						if !_rules[ruleWhiteSpacing]() {
-							goto l3
+						{
+							position48 := position
+							if !_rules[ruleWhiteSpacing]() {
+								goto l3
+							}
+							add(rulePegText, position48)
+						}
+						{
+							add(ruleAction1, position)
 						}
 						{
-							position36, tokenIndex36 := position, tokenIndex
+							position50, tokenIndex50 := position, tokenIndex
+							{
+								position52 := position
+								if buffer[position] != rune('i') {
+									goto l51
+								}
+								position++
+								if buffer[position] != rune('n') {
+									goto l51
+								}
+								position++
+								if buffer[position] != rune('c') {
+									goto l51
+								}
+								position++
+								if buffer[position] != rune('l') {
+									goto l51
+								}
+								position++
+								if buffer[position] != rune('u') {
+									goto l51
+								}
+								position++
+								if buffer[position] != rune('d') {
+									goto l51
+								}
+								position++
+								if buffer[position] != rune('e') {
+									goto l51
+								}
+								position++
+								if !_rules[ruleMustWhiteSpacing]() {
+									goto l51
+								}
+								{
+									position53 := position
+									{
+										switch buffer[position] {
+										case '\'':
+											if !_rules[ruleSingleQuotedValue]() {
+												goto l51
+											}
+											break
+										case '"':
+											if !_rules[ruleDoubleQuotedValue]() {
+												goto l51
+											}
+											break
+										default:
+											{
+												position55 := position
+												if !_rules[ruleUnquotedParam]() {
+													goto l51
+												}
+												add(rulePegText, position55)
+											}
+											break
+										}
+									}
+
+									{
+										add(ruleAction7, position)
+									}
+									add(ruleIncludePath, position53)
+								}
+								{
+									position57, tokenIndex57 := position, tokenIndex
+									if !_rules[ruleMustWhiteSpacing]() {
+										goto l57
+									}
+									if !_rules[ruleParams]() {
+										goto l57
+									}
+									goto l58
+								l57:
+									position, tokenIndex = position57, tokenIndex57
+								}
+							l58:
+								add(ruleIncludeExpr, position52)
+							}
+							goto l50
+						l51:
+							position, tokenIndex = position50, tokenIndex50
 							if !_rules[ruleCmdExpr]() {
-								goto l37
+								goto l59
 							}
-							goto l36
-						l37:
-							position, tokenIndex = position36, tokenIndex36
+							goto l50
+						l59:
+							position, tokenIndex = position50, tokenIndex50
 							{
-								position39 := position
+								position61 := position
 								{
-									position40 := position
+									position62 := position
 									if !_rules[ruleIdentifier]() {
-										goto l38
+										goto l60
 									}
-									add(rulePegText, position40)
+									add(rulePegText, position62)
 								}
 								{
-									add(ruleAction2, position)
+									add(ruleAction3, position)
 								}
 								if !_rules[ruleEqual]() {
-									goto l38
+									goto l60
 								}
 								{
-									position42, tokenIndex42 := position, tokenIndex
+									position64, tokenIndex64 := position, tokenIndex
 									if !_rules[ruleCmdExpr]() {
-										goto l43
+										goto l65
 									}
-									goto l42
-								l43:
-									position, tokenIndex = position42, tokenIndex42
+									goto l64
+								l65:
+									position, tokenIndex = position64, tokenIndex64
 									{
-										position44 := position
+										position66 := position
 										{
-											add(ruleAction3, position)
+											add(ruleAction4, position)
 										}
 										if !_rules[ruleCompositeValue]() {
-											goto l38
+											goto l60
 										}
-										add(ruleValueExpr, position44)
+										add(ruleValueExpr, position66)
 									}
 								}
-							l42:
-								add(ruleDeclaration, position39)
+							l64:
+								add(ruleDeclaration, position61)
 							}
-							goto l36
-						l38:
-							position, tokenIndex = position36, tokenIndex36
+							goto l50
+						l60:
+							position, tokenIndex = position50, tokenIndex50
 							{
-								position46 := position
+								position68 := position
 								{
-									position47, tokenIndex47 := position, tokenIndex
-									if buffer[position] != rune('#') {
-										goto l48
-									}
-									position++
-								l49:
+									position69 := position
 									{
-										position50, tokenIndex50 := position, tokenIndex
+										position70, tokenIndex70 := position, tokenIndex
+										if buffer[position] != rune('#') {
+											goto l71
+										}
+										position++
+									l72:
 										{
-											position51, tokenIndex51 := position, tokenIndex
-											if !_rules[ruleEndOfLine]() {
-												goto l51
+											position73, tokenIndex73 := position, tokenIndex
+											{
+												position74, tokenIndex74 := position, tokenIndex
+												if !_rules[ruleEndOfLine]() {
+													goto l74
+												}
+												goto l73
+											l74:
+												position, tokenIndex = position74, tokenIndex74
+											}
+											if !matchDot() {
+												goto l73
 											}
-											goto l50
-										l51:
-											position, tokenIndex = position51, tokenIndex51
+											goto l72
+										l73:
+											position, tokenIndex = position73, tokenIndex73
 										}
-										if !matchDot() {
-											goto l50
+										goto l70
+									l71:
+										position, tokenIndex = position70, tokenIndex70
+										if buffer[position] != rune('/') {
+											goto l3
 										}
-										goto l49
-									l50:
-										position, tokenIndex = position50, tokenIndex50
-									}
-									goto l47
-								l48:
-									position, tokenIndex = position47, tokenIndex47
-									if buffer[position] != rune('/') {
-										goto l3
-									}
-									position++
-									if buffer[position] != rune('/') {
-										goto l3
-									}
-									position++
-								l52:
-									{
-										position53, tokenIndex53 := position, tokenIndex
+										position++
+										if buffer[position] != rune('/') {
+											goto l3
+										}
+										position++
+									l75:
 										{
-											position54, tokenIndex54 := position, tokenIndex
-											if !_rules[ruleEndOfLine]() {
-												goto l54
+											position76, tokenIndex76 := position, tokenIndex
+											{
+												position77, tokenIndex77 := position, tokenIndex
+												if !_rules[ruleEndOfLine]() {
+													goto l77
+												}
+												goto l76
+											l77:
+												position, tokenIndex = position77, tokenIndex77
 											}
-											goto l53
-										l54:
-											position, tokenIndex = position54, tokenIndex54
-										}
-										if !matchDot() {
-											goto l53
+											if !matchDot() {
+												goto l76
+											}
+											goto l75
+										l76:
+											position, tokenIndex = position76, tokenIndex76
 										}
-										goto l52
-									l53:
-										position, tokenIndex = position53, tokenIndex53
 									}
+								l70:
+									add(rulePegText, position69)
 								}
-							l47:
-								add(ruleComment, position46)
+								{
+									add(ruleAction32, position)
+								}
+								add(ruleComment, position68)
 							}
 						}
-					l36:
+					l50:
 						if !_rules[ruleWhiteSpacing]() {
 							goto l3
 						}
-					l55:
+					l79:
 						{
-							position56, tokenIndex56 := position, tokenIndex
+							position80, tokenIndex80 := position, tokenIndex
 							if !_rules[ruleEndOfLine]() {
-								goto l56
+								goto l80
 							}
-							goto l55
-						l56:
-							position, tokenIndex = position56, tokenIndex56
+							goto l79
+						l80:
+							position, tokenIndex = position80, tokenIndex80
 						}
 						{
-							add(ruleAction1, position)
+							add(ruleAction2, position)
 						}
-						add(ruleStatement, position34)
+						add(ruleStatement, position46)
 					}
-				l58:
+				l82:
 					{
-						position59, tokenIndex59 := position, tokenIndex
+						position83, tokenIndex83 := position, tokenIndex
 						if !_rules[ruleBlankLine]() {
-							goto l59
+							goto l83
 						}
-						goto l58
-					l59:
-						position, tokenIndex = position59, tokenIndex59
+						goto l82
+					l83:
+						position, tokenIndex = position83, tokenIndex83
 					}
 					goto l2
 				l3:
@@ -812,17 +1048,17 @@ func (p *Peg) Init() {
 					goto l0
 				}
 				{
-					position60 := position
+					position84 := position
 					{
-						position61, tokenIndex61 := position, tokenIndex
+						position85, tokenIndex85 := position, tokenIndex
 						if !matchDot() {
-							goto l61
+							goto l85
 						}
 						goto l0
-					l61:
-						position, tokenIndex = position61, tokenIndex61
+					l85:
+						position, tokenIndex = position85, tokenIndex85
 					}
-					add(ruleEndOfFile, position60)
+					add(ruleEndOfFile, position84)
 				}
 				add(ruleScript, position1)
 			}
@@ -831,1514 +1067,1757 @@ func (p *Peg) Init() {
 			position, tokenIndex = position0, tokenIndex0
 			return false
 		},
-		/* 1 Statement <- <(Action0 WhiteSpacing (CmdExpr / Declaration / Comment) WhiteSpacing EndOfLine* Action1)> */
+		/* 1 Statement <- <(Action0 <WhiteSpacing> Action1 (IncludeExpr / CmdExpr / Declaration / Comment) WhiteSpacing EndOfLine* Action2)> */
 		nil,
 		/* 2 Action <- <[a-z]+> */
 		nil,
 		/* 3 Entity <- <([a-z] / [0-9])+> */
 		nil,
-		/* 4 Declaration <- <(<Identifier> Action2 Equal (CmdExpr / ValueExpr))> */
+		/* 4 Declaration <- <(<Identifier> Action3 Equal (CmdExpr / ValueExpr))> */
 		nil,
-		/* 5 ValueExpr <- <(Action3 CompositeValue)> */
+		/* 5 ValueExpr <- <(Action4 CompositeValue)> */
 		nil,
-		/* 6 CmdExpr <- <(<Action> Action4 MustWhiteSpacing <Entity> Action5 (MustWhiteSpacing Params)?)> */
+		/* 6 CmdExpr <- <(<Action> Action5 MustWhiteSpacing <Entity> Action6 (MustWhiteSpacing Params)?)> */
 		func() bool {
-			position67, tokenIndex67 := position, tokenIndex
+			position91, tokenIndex91 := position, tokenIndex
 			{
-				position68 := position
+				position92 := position
 				{
-					position69 := position
+					position93 := position
 					{
-						position70 := position
+						position94 := position
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l67
+							goto l91
 						}
 						position++
-					l71:
+					l95:
 						{
-							position72, tokenIndex72 := position, tokenIndex
+							position96, tokenIndex96 := position, tokenIndex
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l72
+								goto l96
 							}
 							position++
-							goto l71
-						l72:
-							position, tokenIndex = position72, tokenIndex72
+							goto l95
+						l96:
+							position, tokenIndex = position96, tokenIndex96
 						}
-						add(ruleAction, position70)
+						add(ruleAction, position94)
 					}
-					add(rulePegText, position69)
+					add(rulePegText, position93)
 				}
 				{
-					add(ruleAction4, position)
+					add(ruleAction5, position)
 				}
 				if !_rules[ruleMustWhiteSpacing]() {
-					goto l67
+					goto l91
 				}
 				{
-					position74 := position
+					position98 := position
 					{
-						position75 := position
+						position99 := position
 						{
-							position78, tokenIndex78 := position, tokenIndex
+							position102, tokenIndex102 := position, tokenIndex
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l79
+								goto l103
 							}
 							position++
-							goto l78
-						l79:
-							position, tokenIndex = position78, tokenIndex78
+							goto l102
+						l103:
+							position, tokenIndex = position102, tokenIndex102
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l67
+								goto l91
 							}
 							position++
 						}
-					l78:
-					l76:
+					l102:
+					l100:
 						{
-							position77, tokenIndex77 := position, tokenIndex
+							position101, tokenIndex101 := position, tokenIndex
 							{
-								position80, tokenIndex80 := position, tokenIndex
+								position104, tokenIndex104 := position, tokenIndex
 								if c := buffer[position]; c < rune('a') || c > rune('z') {
-									goto l81
+									goto l105
 								}
 								position++
-								goto l80
-							l81:
-								position, tokenIndex = position80, tokenIndex80
+								goto l104
+							l105:
+								position, tokenIndex = position104, tokenIndex104
 								if c := buffer[position]; c < rune('0') || c > rune('9') {
-									goto l77
+									goto l101
 								}
 								position++
 							}
-						l80:
-							goto l76
-						l77:
-							position, tokenIndex = position77, tokenIndex77
+						l104:
+							goto l100
+						l101:
+							position, tokenIndex = position101, tokenIndex101
 						}
-						add(ruleEntity, position75)
+						add(ruleEntity, position99)
 					}
-					add(rulePegText, position74)
+					add(rulePegText, position98)
 				}
 				{
-					add(ruleAction5, position)
+					add(ruleAction6, position)
 				}
 				{
-					position83, tokenIndex83 := position, tokenIndex
+					position107, tokenIndex107 := position, tokenIndex
 					if !_rules[ruleMustWhiteSpacing]() {
-						goto l83
+						goto l107
 					}
-					{
-						position85 := position
-						{
-							position88 := position
-							{
-								position89 := position
-								if !_rules[ruleIdentifier]() {
-									goto l83
-								}
-								add(rulePegText, position89)
-							}
-							{
-								add(ruleAction6, position)
-							}
-							if !_rules[ruleEqual]() {
-								goto l83
-							}
-							if !_rules[ruleCompositeValue]() {
-								goto l83
-							}
-							if !_rules[ruleWhiteSpacing]() {
-								goto l83
-							}
-							add(ruleParam, position88)
-						}
-					l86:
-						{
-							position87, tokenIndex87 := position, tokenIndex
-							{
-								position91 := position
-								{
-									position92 := position
-									if !_rules[ruleIdentifier]() {
-										goto l87
-									}
-									add(rulePegText, position92)
-								}
-								{
-									add(ruleAction6, position)
-								}
-								if !_rules[ruleEqual]() {
-									goto l87
-								}
-								if !_rules[ruleCompositeValue]() {
-									goto l87
-								}
-								if !_rules[ruleWhiteSpacing]() {
-									goto l87
-								}
-								add(ruleParam, position91)
-							}
-							goto l86
-						l87:
-							position, tokenIndex = position87, tokenIndex87
-						}
-						add(ruleParams, position85)
+					if !_rules[ruleParams]() {
+						goto l107
 					}
-					goto l84
-				l83:
-					position, tokenIndex = position83, tokenIndex83
+					goto l108
+				l107:
+					position, tokenIndex = position107, tokenIndex107
 				}
-			l84:
-				add(ruleCmdExpr, position68)
+			l108:
+				add(ruleCmdExpr, position92)
 			}
 			return true
-		l67:
-			position, tokenIndex = position67, tokenIndex67
+		l91:
+			position, tokenIndex = position91, tokenIndex91
 			return false
 		},
-		/* 7 Params <- <Param+> */
+		/* 7 IncludeExpr <- <('i' 'n' 'c' 'l' 'u' 'd' 'e' MustWhiteSpacing IncludePath (MustWhiteSpacing Params)?)> */
 		nil,
-		/* 8 Param <- <(<Identifier> Action6 Equal CompositeValue WhiteSpacing)> */
+		/* 8 IncludePath <- <(((&('\'') SingleQuotedValue) | (&('"') DoubleQuotedValue) | (&('*' | '+' | '-' | '.' | '/' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | ':' | ';' | '<' | '>' | '@' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z' | '~') <UnquotedParam>)) Action7)> */
 		nil,
-		/* 9 Identifier <- <((&('.') '.') | (&('_') '_') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
+		/* 9 Params <- <Param+> */
 		func() bool {
-			position96, tokenIndex96 := position, tokenIndex
+			position111, tokenIndex111 := position, tokenIndex
 			{
-				position97 := position
+				position112 := position
 				{
-					switch buffer[position] {
-					case '.':
-						if buffer[position] != rune('.') {
-							goto l96
+					position115 := position
+					{
+						position116 := position
+						if !_rules[ruleIdentifier]() {
+							goto l111
 						}
-						position++
-						break
-					case '_':
-						if buffer[position] != rune('_') {
-							goto l96
+						add(rulePegText, position116)
+					}
+					{
+						add(ruleAction8, position)
+					}
+					{
+						add(ruleAction9, position)
+					}
+					if !_rules[ruleEqual]() {
+						goto l111
+					}
+					if !_rules[ruleCompositeValue]() {
+						goto l111
+					}
+					if !_rules[ruleWhiteSpacing]() {
+						goto l111
+					}
+					add(ruleParam, position115)
+				}
+			l113:
+				{
+					position114, tokenIndex114 := position, tokenIndex
+					{
+						position119 := position
+						{
+							position120 := position
+							if !_rules[ruleIdentifier]() {
+								goto l114
+							}
+							add(rulePegText, position120)
 						}
-						position++
-						break
-					case '-':
+						{
+							add(ruleAction8, position)
+						}
+						{
+							add(ruleAction9, position)
+						}
+						if !_rules[ruleEqual]() {
+							goto l114
+						}
+						if !_rules[ruleCompositeValue]() {
+							goto l114
+						}
+						if !_rules[ruleWhiteSpacing]() {
+							goto l114
+						}
+						add(ruleParam, position119)
+					}
+					goto l113
+				l114:
+					position, tokenIndex = position114, tokenIndex114
+				}
+				add(ruleParams, position112)
+			}
+			return true
+		l111:
+			position, tokenIndex = position111, tokenIndex111
+			return false
+		},
+		/* 10 Param <- <(<Identifier> Action8 Action9 Equal CompositeValue WhiteSpacing)> */
+		nil,
+		/* 11 Identifier <- <((&('.') '.') | (&('_') '_') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
+		func() bool {
+			position124, tokenIndex124 := position, tokenIndex
+			{
+				position125 := position
+				{
+					switch buffer[position] {
+					case '.':
+						if buffer[position] != rune('.') {
+							goto l124
+						}
+						position++
+						break
+					case '_':
+						if buffer[position] != rune('_') {
+							goto l124
+						}
+						position++
+						break
+					case '-':
 						if buffer[position] != rune('-') {
-							goto l96
+							goto l124
 						}
 						position++
 						break
 					case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l96
+							goto l124
 						}
 						position++
 						break
 					case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l96
+							goto l124
 						}
 						position++
 						break
 					default:
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l96
+							goto l124
 						}
 						position++
 						break
 					}
 				}
 
-			l98:
+			l126:
 				{
-					position99, tokenIndex99 := position, tokenIndex
+					position127, tokenIndex127 := position, tokenIndex
 					{
 						switch buffer[position] {
 						case '.':
 							if buffer[position] != rune('.') {
-								goto l99
+								goto l127
 							}
 							position++
 							break
 						case '_':
 							if buffer[position] != rune('_') {
-								goto l99
+								goto l127
 							}
 							position++
 							break
 						case '-':
 							if buffer[position] != rune('-') {
-								goto l99
+								goto l127
 							}
 							position++
 							break
 						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l99
+								goto l127
 							}
 							position++
 							break
 						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l99
+								goto l127
 							}
 							position++
 							break
 						default:
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l99
+								goto l127
 							}
 							position++
 							break
 						}
 					}
 
-					goto l98
-				l99:
-					position, tokenIndex = position99, tokenIndex99
+					goto l126
+				l127:
+					position, tokenIndex = position127, tokenIndex127
 				}
-				add(ruleIdentifier, position97)
+				add(ruleIdentifier, position125)
 			}
 			return true
-		l96:
-			position, tokenIndex = position96, tokenIndex96
+		l124:
+			position, tokenIndex = position124, tokenIndex124
 			return false
 		},
-		/* 10 CompositeValue <- <(ListValue / ListWithoutSquareBrackets / Value)> */
+		/* 12 CompositeValue <- <(ListValue / ListWithoutSquareBrackets / Value)> */
 		func() bool {
-			position102, tokenIndex102 := position, tokenIndex
+			position130, tokenIndex130 := position, tokenIndex
 			{
-				position103 := position
+				position131 := position
 				{
-					position104, tokenIndex104 := position, tokenIndex
+					position132, tokenIndex132 := position, tokenIndex
 					{
-						position106 := position
+						position134 := position
 						{
-							add(ruleAction7, position)
+							add(ruleAction10, position)
 						}
 						if buffer[position] != rune('[') {
-							goto l105
+							goto l133
 						}
 						position++
 						{
-							position108, tokenIndex108 := position, tokenIndex
+							position136, tokenIndex136 := position, tokenIndex
 							if !_rules[ruleWhiteSpacing]() {
-								goto l108
+								goto l136
 							}
 							if !_rules[ruleValue]() {
-								goto l108
+								goto l136
 							}
 							if !_rules[ruleWhiteSpacing]() {
-								goto l108
+								goto l136
 							}
-							goto l109
-						l108:
-							position, tokenIndex = position108, tokenIndex108
+							goto l137
+						l136:
+							position, tokenIndex = position136, tokenIndex136
 						}
-					l109:
-					l110:
+					l137:
+					l138:
 						{
-							position111, tokenIndex111 := position, tokenIndex
+							position139, tokenIndex139 := position, tokenIndex
 							if buffer[position] != rune(',') {
-								goto l111
+								goto l139
 							}
 							position++
 							if !_rules[ruleWhiteSpacing]() {
-								goto l111
+								goto l139
 							}
 							if !_rules[ruleValue]() {
-								goto l111
+								goto l139
 							}
 							if !_rules[ruleWhiteSpacing]() {
-								goto l111
+								goto l139
 							}
-							goto l110
-						l111:
-							position, tokenIndex = position111, tokenIndex111
+							goto l138
+						l139:
+							position, tokenIndex = position139, tokenIndex139
 						}
 						if buffer[position] != rune(']') {
-							goto l105
+							goto l133
 						}
 						position++
 						{
-							add(ruleAction8, position)
+							add(ruleAction11, position)
 						}
-						add(ruleListValue, position106)
+						add(ruleListValue, position134)
 					}
-					goto l104
-				l105:
-					position, tokenIndex = position104, tokenIndex104
+					goto l132
+				l133:
+					position, tokenIndex = position132, tokenIndex132
 					{
-						position114 := position
+						position142 := position
 						{
-							add(ruleAction9, position)
+							add(ruleAction12, position)
 						}
 						if !_rules[ruleWhiteSpacing]() {
-							goto l113
+							goto l141
 						}
 						if !_rules[ruleValue]() {
-							goto l113
+							goto l141
 						}
 						if !_rules[ruleWhiteSpacing]() {
-							goto l113
+							goto l141
 						}
 						if buffer[position] != rune(',') {
-							goto l113
+							goto l141
 						}
 						position++
 						if !_rules[ruleWhiteSpacing]() {
-							goto l113
+							goto l141
 						}
 						if !_rules[ruleValue]() {
-							goto l113
+							goto l141
 						}
 						if !_rules[ruleWhiteSpacing]() {
-							goto l113
+							goto l141
 						}
-					l116:
+					l144:
 						{
-							position117, tokenIndex117 := position, tokenIndex
+							position145, tokenIndex145 := position, tokenIndex
 							if buffer[position] != rune(',') {
-								goto l117
+								goto l145
 							}
 							position++
 							if !_rules[ruleWhiteSpacing]() {
-								goto l117
+								goto l145
 							}
 							if !_rules[ruleValue]() {
-								goto l117
+								goto l145
 							}
 							if !_rules[ruleWhiteSpacing]() {
-								goto l117
+								goto l145
 							}
-							goto l116
-						l117:
-							position, tokenIndex = position117, tokenIndex117
+							goto l144
+						l145:
+							position, tokenIndex = position145, tokenIndex145
 						}
 						{
-							add(ruleAction10, position)
+							add(ruleAction13, position)
 						}
-						add(ruleListWithoutSquareBrackets, position114)
+						add(ruleListWithoutSquareBrackets, position142)
 					}
-					goto l104
-				l113:
-					position, tokenIndex = position104, tokenIndex104
+					goto l132
+				l141:
+					position, tokenIndex = position132, tokenIndex132
 					if !_rules[ruleValue]() {
-						goto l102
+						goto l130
 					}
 				}
-			l104:
-				add(ruleCompositeValue, position103)
+			l132:
+				add(ruleCompositeValue, position131)
 			}
 			return true
-		l102:
-			position, tokenIndex = position102, tokenIndex102
+		l130:
+			position, tokenIndex = position130, tokenIndex130
 			return false
 		},
-		/* 11 ListValue <- <(Action7 '[' (WhiteSpacing Value WhiteSpacing)? (',' WhiteSpacing Value WhiteSpacing)* ']' Action8)> */
+		/* 13 ListValue <- <(Action10 '[' (WhiteSpacing Value WhiteSpacing)? (',' WhiteSpacing Value WhiteSpacing)* ']' Action11)> */
 		nil,
-		/* 12 ListWithoutSquareBrackets <- <(Action9 (WhiteSpacing Value WhiteSpacing) (',' WhiteSpacing Value WhiteSpacing)+ Action10)> */
+		/* 14 ListWithoutSquareBrackets <- <(Action12 (WhiteSpacing Value WhiteSpacing) (',' WhiteSpacing Value WhiteSpacing)+ Action13)> */
 		nil,
-		/* 13 NoRefValue <- <(ConcatenationValue / HoleWithSuffixValue / HoleValue / HolesStringValue / (AliasValue Action11) / (DoubleQuote CustomTypedValue DoubleQuote) / (SingleQuote CustomTypedValue SingleQuote) / CustomTypedValue / QuotedStringValue / UnquotedParamValue)> */
+		/* 15 NoRefValue <- <(FunctionValue / ConcatenationValue / HoleWithSuffixValue / HoleValue / HolesStringValue / (AliasValue Action14) / (DoubleQuote CustomTypedValue DoubleQuote) / (SingleQuote CustomTypedValue SingleQuote) / CustomTypedValue / QuotedStringValue / UnquotedParamValue)> */
 		nil,
-		/* 14 Value <- <((RefValue Action12) / NoRefValue)> */
+		/* 16 Value <- <((RefValue Action15) / NoRefValue)> */
 		func() bool {
-			position122, tokenIndex122 := position, tokenIndex
+			position150, tokenIndex150 := position, tokenIndex
 			{
-				position123 := position
+				position151 := position
 				{
-					position124, tokenIndex124 := position, tokenIndex
-					{
-						position126 := position
-						if buffer[position] != rune('$') {
-							goto l125
-						}
-						position++
-						{
-							position127 := position
-							if !_rules[ruleIdentifier]() {
-								goto l125
-							}
-							add(rulePegText, position127)
-						}
-						add(ruleRefValue, position126)
+					position152, tokenIndex152 := position, tokenIndex
+					if !_rules[ruleRefValue]() {
+						goto l153
 					}
 					{
-						add(ruleAction12, position)
+						add(ruleAction15, position)
 					}
-					goto l124
-				l125:
-					position, tokenIndex = position124, tokenIndex124
+					goto l152
+				l153:
+					position, tokenIndex = position152, tokenIndex152
 					{
-						position129 := position
+						position155 := position
 						{
-							position130, tokenIndex130 := position, tokenIndex
+							position156, tokenIndex156 := position, tokenIndex
+							if !_rules[ruleFunctionValue]() {
+								goto l157
+							}
+							goto l156
+						l157:
+							position, tokenIndex = position156, tokenIndex156
+							if !_rules[ruleConcatenationValue]() {
+								goto l158
+							}
+							goto l156
+						l158:
+							position, tokenIndex = position156, tokenIndex156
 							{
-								position132 := position
+								position160 := position
 								{
-									position133, tokenIndex133 := position, tokenIndex
-									{
-										add(ruleAction15, position)
-									}
-									if !_rules[ruleHoleValue]() {
-										goto l134
-									}
-									if !_rules[ruleWhiteSpacing]() {
-										goto l134
-									}
-									if buffer[position] != rune('+') {
-										goto l134
-									}
-									position++
-									if !_rules[ruleWhiteSpacing]() {
-										goto l134
-									}
-									{
-										position138, tokenIndex138 := position, tokenIndex
-										if !_rules[ruleQuotedStringValue]() {
-											goto l139
-										}
-										goto l138
-									l139:
-										position, tokenIndex = position138, tokenIndex138
-										if !_rules[ruleHoleValue]() {
-											goto l134
-										}
-									}
-								l138:
-								l136:
-									{
-										position137, tokenIndex137 := position, tokenIndex
-										if !_rules[ruleWhiteSpacing]() {
-											goto l137
-										}
-										if buffer[position] != rune('+') {
-											goto l137
-										}
-										position++
-										if !_rules[ruleWhiteSpacing]() {
-											goto l137
-										}
-										{
-											position140, tokenIndex140 := position, tokenIndex
-											if !_rules[ruleQuotedStringValue]() {
-												goto l141
-											}
-											goto l140
-										l141:
-											position, tokenIndex = position140, tokenIndex140
-											if !_rules[ruleHoleValue]() {
-												goto l137
-											}
-										}
-									l140:
-										goto l136
-									l137:
-										position, tokenIndex = position137, tokenIndex137
-									}
-									{
-										add(ruleAction16, position)
-									}
-									goto l133
-								l134:
-									position, tokenIndex = position133, tokenIndex133
-									{
-										add(ruleAction17, position)
-									}
-									if !_rules[ruleQuotedStringValue]() {
-										goto l131
-									}
-									if !_rules[ruleWhiteSpacing]() {
-										goto l131
-									}
-									if buffer[position] != rune('+') {
-										goto l131
-									}
-									position++
-									if !_rules[ruleWhiteSpacing]() {
-										goto l131
-									}
-									{
-										position146, tokenIndex146 := position, tokenIndex
-										if !_rules[ruleQuotedStringValue]() {
-											goto l147
-										}
-										goto l146
-									l147:
-										position, tokenIndex = position146, tokenIndex146
-										if !_rules[ruleHoleValue]() {
-											goto l131
-										}
-									}
-								l146:
-								l144:
-									{
-										position145, tokenIndex145 := position, tokenIndex
-										if !_rules[ruleWhiteSpacing]() {
-											goto l145
-										}
-										if buffer[position] != rune('+') {
-											goto l145
-										}
-										position++
-										if !_rules[ruleWhiteSpacing]() {
-											goto l145
-										}
-										{
-											position148, tokenIndex148 := position, tokenIndex
-											if !_rules[ruleQuotedStringValue]() {
-												goto l149
-											}
-											goto l148
-										l149:
-											position, tokenIndex = position148, tokenIndex148
-											if !_rules[ruleHoleValue]() {
-												goto l145
-											}
-										}
-									l148:
-										goto l144
-									l145:
-										position, tokenIndex = position145, tokenIndex145
-									}
-									{
-										add(ruleAction18, position)
-									}
-								}
-							l133:
-								add(ruleConcatenationValue, position132)
-							}
-							goto l130
-						l131:
-							position, tokenIndex = position130, tokenIndex130
-							{
-								position152 := position
-								{
-									add(ruleAction23, position)
+									add(ruleAction30, position)
 								}
 								{
-									position154 := position
+									position162 := position
 									if !_rules[ruleHoleValue]() {
-										goto l151
+										goto l159
 									}
 									if !_rules[ruleUnquotedParamValue]() {
-										goto l151
+										goto l159
 									}
-								l155:
+								l163:
 									{
-										position156, tokenIndex156 := position, tokenIndex
+										position164, tokenIndex164 := position, tokenIndex
 										if !_rules[ruleUnquotedParamValue]() {
-											goto l156
+											goto l164
 										}
-										goto l155
-									l156:
-										position, tokenIndex = position156, tokenIndex156
+										goto l163
+									l164:
+										position, tokenIndex = position164, tokenIndex164
 									}
-								l157:
+								l165:
 									{
-										position158, tokenIndex158 := position, tokenIndex
+										position166, tokenIndex166 := position, tokenIndex
 										{
-											position159, tokenIndex159 := position, tokenIndex
+											position167, tokenIndex167 := position, tokenIndex
 											if !_rules[ruleUnquotedParamValue]() {
-												goto l159
+												goto l167
 											}
-											goto l160
-										l159:
-											position, tokenIndex = position159, tokenIndex159
+											goto l168
+										l167:
+											position, tokenIndex = position167, tokenIndex167
 										}
-									l160:
+									l168:
 										if !_rules[ruleHoleValue]() {
-											goto l158
+											goto l166
 										}
 										{
-											position161, tokenIndex161 := position, tokenIndex
+											position169, tokenIndex169 := position, tokenIndex
 											if !_rules[ruleUnquotedParamValue]() {
-												goto l161
+												goto l169
 											}
-											goto l162
-										l161:
-											position, tokenIndex = position161, tokenIndex161
+											goto l170
+										l169:
+											position, tokenIndex = position169, tokenIndex169
 										}
-									l162:
-										goto l157
-									l158:
-										position, tokenIndex = position158, tokenIndex158
+									l170:
+										goto l165
+									l166:
+										position, tokenIndex = position166, tokenIndex166
 									}
-									add(rulePegText, position154)
+									add(rulePegText, position162)
 								}
 								{
-									add(ruleAction24, position)
+									add(ruleAction31, position)
 								}
-								add(ruleHoleWithSuffixValue, position152)
+								add(ruleHoleWithSuffixValue, position160)
 							}
-							goto l130
-						l151:
-							position, tokenIndex = position130, tokenIndex130
+							goto l156
+						l159:
+							position, tokenIndex = position156, tokenIndex156
 							if !_rules[ruleHoleValue]() {
-								goto l164
+								goto l172
 							}
-							goto l130
-						l164:
-							position, tokenIndex = position130, tokenIndex130
+							goto l156
+						l172:
+							position, tokenIndex = position156, tokenIndex156
 							{
-								position166 := position
+								position174 := position
 								{
-									add(ruleAction21, position)
+									add(ruleAction28, position)
 								}
 								{
-									position168 := position
+									position176 := position
 									{
-										position171, tokenIndex171 := position, tokenIndex
+										position179, tokenIndex179 := position, tokenIndex
 										if !_rules[ruleUnquotedParamValue]() {
-											goto l171
+											goto l179
 										}
-										goto l172
-									l171:
-										position, tokenIndex = position171, tokenIndex171
+										goto l180
+									l179:
+										position, tokenIndex = position179, tokenIndex179
 									}
-								l172:
+								l180:
 									if !_rules[ruleHoleValue]() {
-										goto l165
+										goto l173
 									}
 									{
-										position173, tokenIndex173 := position, tokenIndex
+										position181, tokenIndex181 := position, tokenIndex
 										if !_rules[ruleUnquotedParamValue]() {
-											goto l173
+											goto l181
 										}
-										goto l174
-									l173:
-										position, tokenIndex = position173, tokenIndex173
+										goto l182
+									l181:
+										position, tokenIndex = position181, tokenIndex181
 									}
-								l174:
-								l169:
+								l182:
+								l177:
 									{
-										position170, tokenIndex170 := position, tokenIndex
+										position178, tokenIndex178 := position, tokenIndex
 										{
-											position175, tokenIndex175 := position, tokenIndex
+											position183, tokenIndex183 := position, tokenIndex
 											if !_rules[ruleUnquotedParamValue]() {
-												goto l175
+												goto l183
 											}
-											goto l176
-										l175:
-											position, tokenIndex = position175, tokenIndex175
+											goto l184
+										l183:
+											position, tokenIndex = position183, tokenIndex183
 										}
-									l176:
+									l184:
 										if !_rules[ruleHoleValue]() {
-											goto l170
+											goto l178
 										}
 										{
-											position177, tokenIndex177 := position, tokenIndex
+											position185, tokenIndex185 := position, tokenIndex
 											if !_rules[ruleUnquotedParamValue]() {
-												goto l177
+												goto l185
 											}
-											goto l178
-										l177:
-											position, tokenIndex = position177, tokenIndex177
+											goto l186
+										l185:
+											position, tokenIndex = position185, tokenIndex185
 										}
+									l186:
+										goto l177
 									l178:
-										goto l169
-									l170:
-										position, tokenIndex = position170, tokenIndex170
+										position, tokenIndex = position178, tokenIndex178
 									}
-									add(rulePegText, position168)
+									add(rulePegText, position176)
 								}
 								{
-									add(ruleAction22, position)
+									add(ruleAction29, position)
 								}
-								add(ruleHolesStringValue, position166)
+								add(ruleHolesStringValue, position174)
 							}
-							goto l130
-						l165:
-							position, tokenIndex = position130, tokenIndex130
-							{
-								position181 := position
-								{
-									position182, tokenIndex182 := position, tokenIndex
-									if buffer[position] != rune('@') {
-										goto l183
-									}
-									position++
-									{
-										position184 := position
-										if !_rules[ruleUnquotedParam]() {
-											goto l183
-										}
-										add(rulePegText, position184)
-									}
-									goto l182
-								l183:
-									position, tokenIndex = position182, tokenIndex182
-									if buffer[position] != rune('@') {
-										goto l185
-									}
-									position++
-									if !_rules[ruleDoubleQuotedValue]() {
-										goto l185
-									}
-									goto l182
-								l185:
-									position, tokenIndex = position182, tokenIndex182
-									if buffer[position] != rune('@') {
-										goto l180
-									}
-									position++
-									if !_rules[ruleSingleQuotedValue]() {
-										goto l180
-									}
-								}
-							l182:
-								add(ruleAliasValue, position181)
+							goto l156
+						l173:
+							position, tokenIndex = position156, tokenIndex156
+							if !_rules[ruleAliasValue]() {
+								goto l188
 							}
 							{
-								add(ruleAction11, position)
+								add(ruleAction14, position)
 							}
-							goto l130
-						l180:
-							position, tokenIndex = position130, tokenIndex130
+							goto l156
+						l188:
+							position, tokenIndex = position156, tokenIndex156
 							if !_rules[ruleDoubleQuote]() {
-								goto l187
+								goto l190
 							}
 							if !_rules[ruleCustomTypedValue]() {
-								goto l187
+								goto l190
 							}
 							if !_rules[ruleDoubleQuote]() {
-								goto l187
+								goto l190
 							}
-							goto l130
-						l187:
-							position, tokenIndex = position130, tokenIndex130
+							goto l156
+						l190:
+							position, tokenIndex = position156, tokenIndex156
 							if !_rules[ruleSingleQuote]() {
-								goto l188
+								goto l191
 							}
 							if !_rules[ruleCustomTypedValue]() {
-								goto l188
+								goto l191
 							}
 							if !_rules[ruleSingleQuote]() {
-								goto l188
+								goto l191
 							}
-							goto l130
-						l188:
-							position, tokenIndex = position130, tokenIndex130
+							goto l156
+						l191:
+							position, tokenIndex = position156, tokenIndex156
 							if !_rules[ruleCustomTypedValue]() {
-								goto l189
+								goto l192
 							}
-							goto l130
-						l189:
-							position, tokenIndex = position130, tokenIndex130
+							goto l156
+						l192:
+							position, tokenIndex = position156, tokenIndex156
 							if !_rules[ruleQuotedStringValue]() {
-								goto l190
+								goto l193
 							}
-							goto l130
-						l190:
-							position, tokenIndex = position130, tokenIndex130
+							goto l156
+						l193:
+							position, tokenIndex = position156, tokenIndex156
+							if !_rules[ruleUnquotedParamValue]() {
+								goto l150
+							}
+						}
+					l156:
+						add(ruleNoRefValue, position155)
+					}
+				}
+			l152:
+				add(ruleValue, position151)
+			}
+			return true
+		l150:
+			position, tokenIndex = position150, tokenIndex150
+			return false
+		},
+		/* 17 FunctionValue <- <(<FunctionName> Action16 '(' WhiteSpacing FunctionArgs? ')' Action17)> */
+		func() bool {
+			position194, tokenIndex194 := position, tokenIndex
+			{
+				position195 := position
+				{
+					position196 := position
+					{
+						position197 := position
+						if c := buffer[position]; c < rune('a') || c > rune('z') {
+							goto l194
+						}
+						position++
+					l198:
+						{
+							position199, tokenIndex199 := position, tokenIndex
+							{
+								position200, tokenIndex200 := position, tokenIndex
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l201
+								}
+								position++
+								goto l200
+							l201:
+								position, tokenIndex = position200, tokenIndex200
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l199
+								}
+								position++
+							}
+						l200:
+							goto l198
+						l199:
+							position, tokenIndex = position199, tokenIndex199
+						}
+						add(ruleFunctionName, position197)
+					}
+					add(rulePegText, position196)
+				}
+				{
+					add(ruleAction16, position)
+				}
+				if buffer[position] != rune('(') {
+					goto l194
+				}
+				position++
+				if !_rules[ruleWhiteSpacing]() {
+					goto l194
+				}
+				{
+					position203, tokenIndex203 := position, tokenIndex
+					{
+						position205 := position
+						if !_rules[ruleFunctionArg]() {
+							goto l203
+						}
+						if !_rules[ruleWhiteSpacing]() {
+							goto l203
+						}
+					l206:
+						{
+							position207, tokenIndex207 := position, tokenIndex
+							if buffer[position] != rune(',') {
+								goto l207
+							}
+							position++
+							if !_rules[ruleWhiteSpacing]() {
+								goto l207
+							}
+							if !_rules[ruleFunctionArg]() {
+								goto l207
+							}
+							if !_rules[ruleWhiteSpacing]() {
+								goto l207
+							}
+							goto l206
+						l207:
+							position, tokenIndex = position207, tokenIndex207
+						}
+						add(ruleFunctionArgs, position205)
+					}
+					goto l204
+				l203:
+					position, tokenIndex = position203, tokenIndex203
+				}
+			l204:
+				if buffer[position] != rune(')') {
+					goto l194
+				}
+				position++
+				{
+					add(ruleAction17, position)
+				}
+				add(ruleFunctionValue, position195)
+			}
+			return true
+		l194:
+			position, tokenIndex = position194, tokenIndex194
+			return false
+		},
+		/* 18 FunctionName <- <([a-z] ([a-z] / [0-9])*)> */
+		nil,
+		/* 19 FunctionArgs <- <(FunctionArg WhiteSpacing (',' WhiteSpacing FunctionArg WhiteSpacing)*)> */
+		nil,
+		/* 20 FunctionArg <- <(FunctionValue / ConcatenationValue / (AliasValue Action19) / CustomTypedValue / ((&('{') HoleValue) | (&('$') (RefValue Action18)) | (&('"' | '\'') QuotedStringValue) | (&('*' | '+' | '-' | '.' | '/' | '0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9' | ':' | ';' | '<' | '>' | '@' | 'A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z' | '_' | 'a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z' | '~') UnquotedParamValue)))> */
+		func() bool {
+			position211, tokenIndex211 := position, tokenIndex
+			{
+				position212 := position
+				{
+					position213, tokenIndex213 := position, tokenIndex
+					if !_rules[ruleFunctionValue]() {
+						goto l214
+					}
+					goto l213
+				l214:
+					position, tokenIndex = position213, tokenIndex213
+					if !_rules[ruleConcatenationValue]() {
+						goto l215
+					}
+					goto l213
+				l215:
+					position, tokenIndex = position213, tokenIndex213
+					if !_rules[ruleAliasValue]() {
+						goto l216
+					}
+					{
+						add(ruleAction19, position)
+					}
+					goto l213
+				l216:
+					position, tokenIndex = position213, tokenIndex213
+					if !_rules[ruleCustomTypedValue]() {
+						goto l218
+					}
+					goto l213
+				l218:
+					position, tokenIndex = position213, tokenIndex213
+					{
+						switch buffer[position] {
+						case '{':
+							if !_rules[ruleHoleValue]() {
+								goto l211
+							}
+							break
+						case '$':
+							if !_rules[ruleRefValue]() {
+								goto l211
+							}
+							{
+								add(ruleAction18, position)
+							}
+							break
+						case '"', '\'':
+							if !_rules[ruleQuotedStringValue]() {
+								goto l211
+							}
+							break
+						default:
 							if !_rules[ruleUnquotedParamValue]() {
-								goto l122
+								goto l211
 							}
+							break
 						}
-					l130:
-						add(ruleNoRefValue, position129)
 					}
+
 				}
-			l124:
-				add(ruleValue, position123)
+			l213:
+				add(ruleFunctionArg, position212)
 			}
 			return true
-		l122:
-			position, tokenIndex = position122, tokenIndex122
+		l211:
+			position, tokenIndex = position211, tokenIndex211
 			return false
 		},
-		/* 15 CustomTypedValue <- <(<IntRangeValue> Action13)> */
+		/* 21 CustomTypedValue <- <(<IntRangeValue> Action20)> */
 		func() bool {
-			position191, tokenIndex191 := position, tokenIndex
+			position221, tokenIndex221 := position, tokenIndex
 			{
-				position192 := position
+				position222 := position
 				{
-					position193 := position
+					position223 := position
 					{
-						position194 := position
+						position224 := position
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l191
+							goto l221
 						}
 						position++
-					l195:
+					l225:
 						{
-							position196, tokenIndex196 := position, tokenIndex
+							position226, tokenIndex226 := position, tokenIndex
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l196
+								goto l226
 							}
 							position++
-							goto l195
-						l196:
-							position, tokenIndex = position196, tokenIndex196
+							goto l225
+						l226:
+							position, tokenIndex = position226, tokenIndex226
 						}
 						if buffer[position] != rune('-') {
-							goto l191
+							goto l221
 						}
 						position++
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l191
+							goto l221
 						}
 						position++
-					l197:
+					l227:
 						{
-							position198, tokenIndex198 := position, tokenIndex
+							position228, tokenIndex228 := position, tokenIndex
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l198
+								goto l228
 							}
 							position++
-							goto l197
-						l198:
-							position, tokenIndex = position198, tokenIndex198
+							goto l227
+						l228:
+							position, tokenIndex = position228, tokenIndex228
 						}
-						add(ruleIntRangeValue, position194)
+						add(ruleIntRangeValue, position224)
 					}
-					add(rulePegText, position193)
+					add(rulePegText, position223)
 				}
 				{
-					add(ruleAction13, position)
+					add(ruleAction20, position)
 				}
-				add(ruleCustomTypedValue, position192)
+				add(ruleCustomTypedValue, position222)
 			}
 			return true
-		l191:
-			position, tokenIndex = position191, tokenIndex191
+		l221:
+			position, tokenIndex = position221, tokenIndex221
 			return false
 		},
-		/* 16 UnquotedParamValue <- <(<UnquotedParam> Action14)> */
+		/* 22 UnquotedParamValue <- <(<UnquotedParam> Action21)> */
 		func() bool {
-			position200, tokenIndex200 := position, tokenIndex
+			position230, tokenIndex230 := position, tokenIndex
 			{
-				position201 := position
+				position231 := position
 				{
-					position202 := position
+					position232 := position
 					if !_rules[ruleUnquotedParam]() {
-						goto l200
+						goto l230
 					}
-					add(rulePegText, position202)
+					add(rulePegText, position232)
 				}
 				{
-					add(ruleAction14, position)
+					add(ruleAction21, position)
 				}
-				add(ruleUnquotedParamValue, position201)
+				add(ruleUnquotedParamValue, position231)
 			}
 			return true
-		l200:
-			position, tokenIndex = position200, tokenIndex200
+		l230:
+			position, tokenIndex = position230, tokenIndex230
 			return false
 		},
-		/* 17 UnquotedParam <- <((&('*') '*') | (&('>') '>') | (&('<') '<') | (&('@') '@') | (&('~') '~') | (&(';') ';') | (&('+') '+') | (&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
+		/* 23 UnquotedParam <- <((&('*') '*') | (&('>') '>') | (&('<') '<') | (&('@') '@') | (&('~') '~') | (&(';') ';') | (&('+') '+') | (&('/') '/') | (&(':') ':') | (&('_') '_') | (&('.') '.') | (&('-') '-') | (&('0' | '1' | '2' | '3' | '4' | '5' | '6' | '7' | '8' | '9') [0-9]) | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))+> */
 		func() bool {
-			position204, tokenIndex204 := position, tokenIndex
+			position234, tokenIndex234 := position, tokenIndex
 			{
-				position205 := position
+				position235 := position
 				{
 					switch buffer[position] {
 					case '*':
 						if buffer[position] != rune('*') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case '>':
 						if buffer[position] != rune('>') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case '<':
 						if buffer[position] != rune('<') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case '@':
 						if buffer[position] != rune('@') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case '~':
 						if buffer[position] != rune('~') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case ';':
 						if buffer[position] != rune(';') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case '+':
 						if buffer[position] != rune('+') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case '/':
 						if buffer[position] != rune('/') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case ':':
 						if buffer[position] != rune(':') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case '_':
 						if buffer[position] != rune('_') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case '.':
 						if buffer[position] != rune('.') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case '-':
 						if buffer[position] != rune('-') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 						if c := buffer[position]; c < rune('0') || c > rune('9') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					default:
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l204
+							goto l234
 						}
 						position++
 						break
 					}
 				}
 
-			l206:
+			l236:
 				{
-					position207, tokenIndex207 := position, tokenIndex
+					position237, tokenIndex237 := position, tokenIndex
 					{
 						switch buffer[position] {
 						case '*':
 							if buffer[position] != rune('*') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case '>':
 							if buffer[position] != rune('>') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case '<':
 							if buffer[position] != rune('<') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case '@':
 							if buffer[position] != rune('@') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case '~':
 							if buffer[position] != rune('~') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case ';':
 							if buffer[position] != rune(';') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case '+':
 							if buffer[position] != rune('+') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case '/':
 							if buffer[position] != rune('/') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case ':':
 							if buffer[position] != rune(':') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case '_':
 							if buffer[position] != rune('_') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case '.':
 							if buffer[position] != rune('.') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case '-':
 							if buffer[position] != rune('-') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z':
 							if c := buffer[position]; c < rune('A') || c > rune('Z') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						default:
 							if c := buffer[position]; c < rune('a') || c > rune('z') {
-								goto l207
+								goto l237
 							}
 							position++
 							break
 						}
 					}
 
-					goto l206
-				l207:
-					position, tokenIndex = position207, tokenIndex207
+					goto l236
+				l237:
+					position, tokenIndex = position237, tokenIndex237
 				}
-				add(ruleUnquotedParam, position205)
+				add(ruleUnquotedParam, position235)
 			}
 			return true
-		l204:
-			position, tokenIndex = position204, tokenIndex204
+		l234:
+			position, tokenIndex = position234, tokenIndex234
 			return false
 		},
-		/* 18 ConcatenationValue <- <((Action15 HoleValue (WhiteSpacing '+' WhiteSpacing (QuotedStringValue / HoleValue))+ Action16) / (Action17 QuotedStringValue (WhiteSpacing '+' WhiteSpacing (QuotedStringValue / HoleValue))+ Action18))> */
-		nil,
-		/* 19 QuotedStringValue <- <(QuotedString Action19)> */
+		/* 24 ConcatenationValue <- <((Action22 HoleValue (WhiteSpacing '+' WhiteSpacing (QuotedStringValue / HoleValue))+ Action23) / (Action24 QuotedStringValue (WhiteSpacing '+' WhiteSpacing (QuotedStringValue / HoleValue))+ Action25))> */
 		func() bool {
-			position211, tokenIndex211 := position, tokenIndex
+			position240, tokenIndex240 := position, tokenIndex
 			{
-				position212 := position
+				position241 := position
 				{
-					position213 := position
+					position242, tokenIndex242 := position, tokenIndex
+					{
+						add(ruleAction22, position)
+					}
+					if !_rules[ruleHoleValue]() {
+						goto l243
+					}
+					if !_rules[ruleWhiteSpacing]() {
+						goto l243
+					}
+					if buffer[position] != rune('+') {
+						goto l243
+					}
+					position++
+					if !_rules[ruleWhiteSpacing]() {
+						goto l243
+					}
+					{
+						position247, tokenIndex247 := position, tokenIndex
+						if !_rules[ruleQuotedStringValue]() {
+							goto l248
+						}
+						goto l247
+					l248:
+						position, tokenIndex = position247, tokenIndex247
+						if !_rules[ruleHoleValue]() {
+							goto l243
+						}
+					}
+				l247:
+				l245:
+					{
+						position246, tokenIndex246 := position, tokenIndex
+						if !_rules[ruleWhiteSpacing]() {
+							goto l246
+						}
+						if buffer[position] != rune('+') {
+							goto l246
+						}
+						position++
+						if !_rules[ruleWhiteSpacing]() {
+							goto l246
+						}
+						{
+							position249, tokenIndex249 := position, tokenIndex
+							if !_rules[ruleQuotedStringValue]() {
+								goto l250
+							}
+							goto l249
+						l250:
+							position, tokenIndex = position249, tokenIndex249
+							if !_rules[ruleHoleValue]() {
+								goto l246
+							}
+						}
+					l249:
+						goto l245
+					l246:
+						position, tokenIndex = position246, tokenIndex246
+					}
 					{
-						position214, tokenIndex214 := position, tokenIndex
+						add(ruleAction23, position)
+					}
+					goto l242
+				l243:
+					position, tokenIndex = position242, tokenIndex242
+					{
+						add(ruleAction24, position)
+					}
+					if !_rules[ruleQuotedStringValue]() {
+						goto l240
+					}
+					if !_rules[ruleWhiteSpacing]() {
+						goto l240
+					}
+					if buffer[position] != rune('+') {
+						goto l240
+					}
+					position++
+					if !_rules[ruleWhiteSpacing]() {
+						goto l240
+					}
+					{
+						position255, tokenIndex255 := position, tokenIndex
+						if !_rules[ruleQuotedStringValue]() {
+							goto l256
+						}
+						goto l255
+					l256:
+						position, tokenIndex = position255, tokenIndex255
+						if !_rules[ruleHoleValue]() {
+							goto l240
+						}
+					}
+				l255:
+				l253:
+					{
+						position254, tokenIndex254 := position, tokenIndex
+						if !_rules[ruleWhiteSpacing]() {
+							goto l254
+						}
+						if buffer[position] != rune('+') {
+							goto l254
+						}
+						position++
+						if !_rules[ruleWhiteSpacing]() {
+							goto l254
+						}
+						{
+							position257, tokenIndex257 := position, tokenIndex
+							if !_rules[ruleQuotedStringValue]() {
+								goto l258
+							}
+							goto l257
+						l258:
+							position, tokenIndex = position257, tokenIndex257
+							if !_rules[ruleHoleValue]() {
+								goto l254
+							}
+						}
+					l257:
+						goto l253
+					l254:
+						position, tokenIndex = position254, tokenIndex254
+					}
+					{
+						add(ruleAction25, position)
+					}
+				}
+			l242:
+				add(ruleConcatenationValue, position241)
+			}
+			return true
+		l240:
+			position, tokenIndex = position240, tokenIndex240
+			return false
+		},
+		/* 25 QuotedStringValue <- <(QuotedString Action26)> */
+		func() bool {
+			position260, tokenIndex260 := position, tokenIndex
+			{
+				position261 := position
+				{
+					position262 := position
+					{
+						position263, tokenIndex263 := position, tokenIndex
 						if !_rules[ruleDoubleQuotedValue]() {
-							goto l215
+							goto l264
 						}
-						goto l214
-					l215:
-						position, tokenIndex = position214, tokenIndex214
+						goto l263
+					l264:
+						position, tokenIndex = position263, tokenIndex263
 						if !_rules[ruleSingleQuotedValue]() {
-							goto l211
+							goto l260
 						}
 					}
-				l214:
-					add(ruleQuotedString, position213)
+				l263:
+					add(ruleQuotedString, position262)
 				}
 				{
-					add(ruleAction19, position)
+					add(ruleAction26, position)
 				}
-				add(ruleQuotedStringValue, position212)
+				add(ruleQuotedStringValue, position261)
 			}
 			return true
-		l211:
-			position, tokenIndex = position211, tokenIndex211
+		l260:
+			position, tokenIndex = position260, tokenIndex260
 			return false
 		},
-		/* 20 QuotedString <- <(DoubleQuotedValue / SingleQuotedValue)> */
+		/* 26 QuotedString <- <(DoubleQuotedValue / SingleQuotedValue)> */
 		nil,
-		/* 21 DoubleQuotedValue <- <(DoubleQuote <(!'"' .)*> DoubleQuote)> */
+		/* 27 DoubleQuotedValue <- <(DoubleQuote <(!'"' .)*> DoubleQuote)> */
 		func() bool {
-			position218, tokenIndex218 := position, tokenIndex
+			position267, tokenIndex267 := position, tokenIndex
 			{
-				position219 := position
+				position268 := position
 				if !_rules[ruleDoubleQuote]() {
-					goto l218
+					goto l267
 				}
 				{
-					position220 := position
-				l221:
+					position269 := position
+				l270:
 					{
-						position222, tokenIndex222 := position, tokenIndex
+						position271, tokenIndex271 := position, tokenIndex
 						{
-							position223, tokenIndex223 := position, tokenIndex
+							position272, tokenIndex272 := position, tokenIndex
 							if buffer[position] != rune('"') {
-								goto l223
+								goto l272
 							}
 							position++
-							goto l222
-						l223:
-							position, tokenIndex = position223, tokenIndex223
+							goto l271
+						l272:
+							position, tokenIndex = position272, tokenIndex272
 						}
 						if !matchDot() {
-							goto l222
+							goto l271
 						}
-						goto l221
-					l222:
-						position, tokenIndex = position222, tokenIndex222
+						goto l270
+					l271:
+						position, tokenIndex = position271, tokenIndex271
 					}
-					add(rulePegText, position220)
+					add(rulePegText, position269)
 				}
 				if !_rules[ruleDoubleQuote]() {
-					goto l218
+					goto l267
 				}
-				add(ruleDoubleQuotedValue, position219)
+				add(ruleDoubleQuotedValue, position268)
 			}
 			return true
-		l218:
-			position, tokenIndex = position218, tokenIndex218
+		l267:
+			position, tokenIndex = position267, tokenIndex267
 			return false
 		},
-		/* 22 SingleQuotedValue <- <(SingleQuote <(!'\'' .)*> SingleQuote)> */
+		/* 28 SingleQuotedValue <- <(SingleQuote <(!'\'' .)*> SingleQuote)> */
 		func() bool {
-			position224, tokenIndex224 := position, tokenIndex
+			position273, tokenIndex273 := position, tokenIndex
 			{
-				position225 := position
+				position274 := position
 				if !_rules[ruleSingleQuote]() {
-					goto l224
+					goto l273
 				}
 				{
-					position226 := position
-				l227:
+					position275 := position
+				l276:
 					{
-						position228, tokenIndex228 := position, tokenIndex
+						position277, tokenIndex277 := position, tokenIndex
 						{
-							position229, tokenIndex229 := position, tokenIndex
+							position278, tokenIndex278 := position, tokenIndex
 							if buffer[position] != rune('\'') {
-								goto l229
+								goto l278
 							}
 							position++
-							goto l228
-						l229:
-							position, tokenIndex = position229, tokenIndex229
+							goto l277
+						l278:
+							position, tokenIndex = position278, tokenIndex278
 						}
 						if !matchDot() {
-							goto l228
+							goto l277
 						}
-						goto l227
-					l228:
-						position, tokenIndex = position228, tokenIndex228
+						goto l276
+					l277:
+						position, tokenIndex = position277, tokenIndex277
 					}
-					add(rulePegText, position226)
+					add(rulePegText, position275)
 				}
 				if !_rules[ruleSingleQuote]() {
-					goto l224
+					goto l273
 				}
-				add(ruleSingleQuotedValue, position225)
+				add(ruleSingleQuotedValue, position274)
 			}
 			return true
-		l224:
-			position, tokenIndex = position224, tokenIndex224
+		l273:
+			position, tokenIndex = position273, tokenIndex273
 			return false
 		},
-		/* 23 IntRangeValue <- <([0-9]+ '-' [0-9]+)> */
-		nil,
-		/* 24 RefValue <- <('$' <Identifier>)> */
-		nil,
-		/* 25 AliasValue <- <(('@' <UnquotedParam>) / ('@' DoubleQuotedValue) / ('@' SingleQuotedValue))> */
+		/* 29 IntRangeValue <- <([0-9]+ '-' [0-9]+)> */
 		nil,
-		/* 26 HoleValue <- <(Hole Action20)> */
+		/* 30 RefValue <- <('$' <Identifier>)> */
 		func() bool {
-			position233, tokenIndex233 := position, tokenIndex
+			position280, tokenIndex280 := position, tokenIndex
 			{
-				position234 := position
+				position281 := position
+				if buffer[position] != rune('$') {
+					goto l280
+				}
+				position++
 				{
-					position235 := position
+					position282 := position
+					if !_rules[ruleIdentifier]() {
+						goto l280
+					}
+					add(rulePegText, position282)
+				}
+				add(ruleRefValue, position281)
+			}
+			return true
+		l280:
+			position, tokenIndex = position280, tokenIndex280
+			return false
+		},
+		/* 31 AliasValue <- <(('@' <UnquotedParam>) / ('@' DoubleQuotedValue) / ('@' SingleQuotedValue))> */
+		func() bool {
+			position283, tokenIndex283 := position, tokenIndex
+			{
+				position284 := position
+				{
+					position285, tokenIndex285 := position, tokenIndex
+					if buffer[position] != rune('@') {
+						goto l286
+					}
+					position++
+					{
+						position287 := position
+						if !_rules[ruleUnquotedParam]() {
+							goto l286
+						}
+						add(rulePegText, position287)
+					}
+					goto l285
+				l286:
+					position, tokenIndex = position285, tokenIndex285
+					if buffer[position] != rune('@') {
+						goto l288
+					}
+					position++
+					if !_rules[ruleDoubleQuotedValue]() {
+						goto l288
+					}
+					goto l285
+				l288:
+					position, tokenIndex = position285, tokenIndex285
+					if buffer[position] != rune('@') {
+						goto l283
+					}
+					position++
+					if !_rules[ruleSingleQuotedValue]() {
+						goto l283
+					}
+				}
+			l285:
+				add(ruleAliasValue, position284)
+			}
+			return true
+		l283:
+			position, tokenIndex = position283, tokenIndex283
+			return false
+		},
+		/* 32 HoleValue <- <(Hole Action27)> */
+		func() bool {
+			position289, tokenIndex289 := position, tokenIndex
+			{
+				position290 := position
+				{
+					position291 := position
 					if buffer[position] != rune('{') {
-						goto l233
+						goto l289
 					}
 					position++
 					if !_rules[ruleWhiteSpacing]() {
-						goto l233
+						goto l289
 					}
 					{
-						position236 := position
+						position292 := position
 						if !_rules[ruleIdentifier]() {
-							goto l233
+							goto l289
 						}
-						add(rulePegText, position236)
+						add(rulePegText, position292)
 					}
 					if !_rules[ruleWhiteSpacing]() {
-						goto l233
+						goto l289
 					}
 					if buffer[position] != rune('}') {
-						goto l233
+						goto l289
 					}
 					position++
-					add(ruleHole, position235)
+					add(ruleHole, position291)
 				}
 				{
-					add(ruleAction20, position)
+					add(ruleAction27, position)
 				}
-				add(ruleHoleValue, position234)
+				add(ruleHoleValue, position290)
 			}
 			return true
-		l233:
-			position, tokenIndex = position233, tokenIndex233
+		l289:
+			position, tokenIndex = position289, tokenIndex289
 			return false
 		},
-		/* 27 Hole <- <('{' WhiteSpacing <Identifier> WhiteSpacing '}')> */
+		/* 33 Hole <- <('{' WhiteSpacing <Identifier> WhiteSpacing '}')> */
 		nil,
-		/* 28 HolesStringValue <- <(Action21 <(UnquotedParamValue? HoleValue UnquotedParamValue?)+> Action22)> */
+		/* 34 HolesStringValue <- <(Action28 <(UnquotedParamValue? HoleValue UnquotedParamValue?)+> Action29)> */
 		nil,
-		/* 29 HoleWithSuffixValue <- <(Action23 <(HoleValue UnquotedParamValue+ (UnquotedParamValue? HoleValue UnquotedParamValue?)*)> Action24)> */
+		/* 35 HoleWithSuffixValue <- <(Action30 <(HoleValue UnquotedParamValue+ (UnquotedParamValue? HoleValue UnquotedParamValue?)*)> Action31)> */
 		nil,
-		/* 30 Comment <- <(('#' (!EndOfLine .)*) / ('/' '/' (!EndOfLine .)*))> */
+		/* 36 Comment <- <(<(('#' (!EndOfLine .)*) / ('/' '/' (!EndOfLine .)*))> Action32)> */
 		nil,
-		/* 31 SingleQuote <- <'\''> */
+		/* 37 SingleQuote <- <'\''> */
 		func() bool {
-			position242, tokenIndex242 := position, tokenIndex
+			position298, tokenIndex298 := position, tokenIndex
 			{
-				position243 := position
+				position299 := position
 				if buffer[position] != rune('\'') {
-					goto l242
+					goto l298
 				}
 				position++
-				add(ruleSingleQuote, position243)
+				add(ruleSingleQuote, position299)
 			}
 			return true
-		l242:
-			position, tokenIndex = position242, tokenIndex242
+		l298:
+			position, tokenIndex = position298, tokenIndex298
 			return false
 		},
-		/* 32 DoubleQuote <- <'"'> */
+		/* 38 DoubleQuote <- <'"'> */
 		func() bool {
-			position244, tokenIndex244 := position, tokenIndex
+			position300, tokenIndex300 := position, tokenIndex
 			{
-				position245 := position
+				position301 := position
 				if buffer[position] != rune('"') {
-					goto l244
+					goto l300
 				}
 				position++
-				add(ruleDoubleQuote, position245)
+				add(ruleDoubleQuote, position301)
 			}
 			return true
-		l244:
-			position, tokenIndex = position244, tokenIndex244
+		l300:
+			position, tokenIndex = position300, tokenIndex300
 			return false
 		},
-		/* 33 WhiteSpacing <- <Whitespace*> */
+		/* 39 WhiteSpacing <- <Whitespace*> */
 		func() bool {
 			{
-				position247 := position
-			l248:
+				position303 := position
+			l304:
 				{
-					position249, tokenIndex249 := position, tokenIndex
+					position305, tokenIndex305 := position, tokenIndex
 					if !_rules[ruleWhitespace]() {
-						goto l249
+						goto l305
 					}
-					goto l248
-				l249:
-					position, tokenIndex = position249, tokenIndex249
+					goto l304
+				l305:
+					position, tokenIndex = position305, tokenIndex305
 				}
-				add(ruleWhiteSpacing, position247)
+				add(ruleWhiteSpacing, position303)
 			}
 			return true
 		},
-		/* 34 MustWhiteSpacing <- <Whitespace+> */
+		/* 40 MustWhiteSpacing <- <Whitespace+> */
 		func() bool {
-			position250, tokenIndex250 := position, tokenIndex
+			position306, tokenIndex306 := position, tokenIndex
 			{
-				position251 := position
+				position307 := position
 				if !_rules[ruleWhitespace]() {
-					goto l250
+					goto l306
 				}
-			l252:
+			l308:
 				{
-					position253, tokenIndex253 := position, tokenIndex
+					position309, tokenIndex309 := position, tokenIndex
 					if !_rules[ruleWhitespace]() {
-						goto l253
+						goto l309
 					}
-					goto l252
-				l253:
-					position, tokenIndex = position253, tokenIndex253
+					goto l308
+				l309:
+					position, tokenIndex = position309, tokenIndex309
 				}
-				add(ruleMustWhiteSpacing, position251)
+				add(ruleMustWhiteSpacing, position307)
 			}
 			return true
-		l250:
-			position, tokenIndex = position250, tokenIndex250
+		l306:
+			position, tokenIndex = position306, tokenIndex306
 			return false
 		},
-		/* 35 Equal <- <(WhiteSpacing '=' WhiteSpacing)> */
+		/* 41 Equal <- <(WhiteSpacing '=' WhiteSpacing)> */
 		func() bool {
-			position254, tokenIndex254 := position, tokenIndex
+			position310, tokenIndex310 := position, tokenIndex
 			{
-				position255 := position
+				position311 := position
 				if !_rules[ruleWhiteSpacing]() {
-					goto l254
+					goto l310
 				}
 				if buffer[position] != rune('=') {
-					goto l254
+					goto l310
 				}
 				position++
 				if !_rules[ruleWhiteSpacing]() {
-					goto l254
+					goto l310
 				}
-				add(ruleEqual, position255)
+				add(ruleEqual, position311)
 			}
 			return true
-		l254:
-			position, tokenIndex = position254, tokenIndex254
+		l310:
+			position, tokenIndex = position310, tokenIndex310
 			return false
 		},
-		/* 36 BlankLine <- <(WhiteSpacing EndOfLine)> */
+		/* 42 BlankLine <- <(WhiteSpacing EndOfLine)> */
 		func() bool {
-			position256, tokenIndex256 := position, tokenIndex
+			position312, tokenIndex312 := position, tokenIndex
 			{
-				position257 := position
+				position313 := position
 				if !_rules[ruleWhiteSpacing]() {
-					goto l256
+					goto l312
 				}
 				if !_rules[ruleEndOfLine]() {
-					goto l256
+					goto l312
 				}
-				add(ruleBlankLine, position257)
+				add(ruleBlankLine, position313)
 			}
 			return true
-		l256:
-			position, tokenIndex = position256, tokenIndex256
+		l312:
+			position, tokenIndex = position312, tokenIndex312
 			return false
 		},
-		/* 37 Whitespace <- <(' ' / '\t')> */
+		/* 43 Whitespace <- <(' ' / '\t')> */
 		func() bool {
-			position258, tokenIndex258 := position, tokenIndex
+			position314, tokenIndex314 := position, tokenIndex
 			{
-				position259 := position
+				position315 := position
 				{
-					position260, tokenIndex260 := position, tokenIndex
+					position316, tokenIndex316 := position, tokenIndex
 					if buffer[position] != rune(' ') {
-						goto l261
+						goto l317
 					}
 					position++
-					goto l260
-				l261:
-					position, tokenIndex = position260, tokenIndex260
+					goto l316
+				l317:
+					position, tokenIndex = position316, tokenIndex316
 					if buffer[position] != rune('\t') {
-						goto l258
+						goto l314
 					}
 					position++
 				}
-			l260:
-				add(ruleWhitespace, position259)
+			l316:
+				add(ruleWhitespace, position315)
 			}
 			return true
-		l258:
-			position, tokenIndex = position258, tokenIndex258
+		l314:
+			position, tokenIndex = position314, tokenIndex314
 			return false
 		},
-		/* 38 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
+		/* 44 EndOfLine <- <(('\r' '\n') / '\n' / '\r')> */
 		func() bool {
-			position262, tokenIndex262 := position, tokenIndex
+			position318, tokenIndex318 := position, tokenIndex
 			{
-				position263 := position
+				position319 := position
 				{
-					position264, tokenIndex264 := position, tokenIndex
+					position320, tokenIndex320 := position, tokenIndex
 					if buffer[position] != rune('\r') {
-						goto l265
+						goto l321
 					}
 					position++
 					if buffer[position] != rune('\n') {
-						goto l265
+						goto l321
 					}
 					position++
-					goto l264
-				l265:
-					position, tokenIndex = position264, tokenIndex264
+					goto l320
+				l321:
+					position, tokenIndex = position320, tokenIndex320
 					if buffer[position] != rune('\n') {
-						goto l266
+						goto l322
 					}
 					position++
-					goto l264
-				l266:
-					position, tokenIndex = position264, tokenIndex264
+					goto l320
+				l322:
+					position, tokenIndex = position320, tokenIndex320
 					if buffer[position] != rune('\r') {
-						goto l262
+						goto l318
 					}
 					position++
 				}
-			l264:
-				add(ruleEndOfLine, position263)
+			l320:
+				add(ruleEndOfLine, position319)
 			}
 			return true
-		l262:
-			position, tokenIndex = position262, tokenIndex262
+		l318:
+			position, tokenIndex = position318, tokenIndex318
 			return false
 		},
-		/* 39 EndOfFile <- <!.> */
+		/* 45 EndOfFile <- <!.> */
+		nil,
+		/* 47 Action0 <- <{ p.NewStatement() }> */
+		nil,
+		nil,
+		/* 49 Action1 <- <{ p.markStatement(p.position(end)) }> */
+		nil,
+		/* 50 Action2 <- <{ p.StatementDone() }> */
+		nil,
+		/* 51 Action3 <- <{ p.addDeclarationIdentifier(text) }> */
+		nil,
+		/* 52 Action4 <- <{ p.addValue() }> */
+		nil,
+		/* 53 Action5 <- <{ p.addAction(text) }> */
+		nil,
+		/* 54 Action6 <- <{ p.addEntity(text) }> */
 		nil,
-		/* 41 Action0 <- <{ p.NewStatement() }> */
+		/* 55 Action7 <- <{ p.addIncludePath(text) }> */
 		nil,
-		/* 42 Action1 <- <{ p.StatementDone() }> */
+		/* 56 Action8 <- <{ p.addParamKey(text) }> */
 		nil,
+		/* 57 Action9 <- <{ p.markParamKey(text, p.position(begin)) }> */
 		nil,
-		/* 44 Action2 <- <{ p.addDeclarationIdentifier(text) }> */
+		/* 58 Action10 <- <{  p.addFirstValueInList() }> */
 		nil,
-		/* 45 Action3 <- <{ p.addValue() }> */
+		/* 59 Action11 <- <{  p.lastValueInList() }> */
 		nil,
-		/* 46 Action4 <- <{ p.addAction(text) }> */
+		/* 60 Action12 <- <{  p.addFirstValueInList() }> */
 		nil,
-		/* 47 Action5 <- <{ p.addEntity(text) }> */
+		/* 61 Action13 <- <{  p.lastValueInList() }> */
 		nil,
-		/* 48 Action6 <- <{ p.addParamKey(text) }> */
+		/* 62 Action14 <- <{  p.addAliasParam(text) }> */
 		nil,
-		/* 49 Action7 <- <{  p.addFirstValueInList() }> */
+		/* 63 Action15 <- <{  p.addParamRefValue(text) }> */
 		nil,
-		/* 50 Action8 <- <{  p.lastValueInList() }> */
+		/* 64 Action16 <- <{ p.addFunctionName(text) }> */
 		nil,
-		/* 51 Action9 <- <{  p.addFirstValueInList() }> */
+		/* 65 Action17 <- <{ p.lastValueInFunction() }> */
 		nil,
-		/* 52 Action10 <- <{  p.lastValueInList() }> */
+		/* 66 Action18 <- <{ p.addParamRefValue(text) }> */
 		nil,
-		/* 53 Action11 <- <{  p.addAliasParam(text) }> */
+		/* 67 Action19 <- <{ p.addAliasParam(text) }> */
 		nil,
-		/* 54 Action12 <- <{  p.addParamRefValue(text) }> */
+		/* 68 Action20 <- <{ p.addParamValue(text) }> */
 		nil,
-		/* 55 Action13 <- <{ p.addParamValue(text) }> */
+		/* 69 Action21 <- <{ p.addParamValue(text) }> */
 		nil,
-		/* 56 Action14 <- <{ p.addParamValue(text) }> */
+		/* 70 Action22 <- <{ p.addFirstValueInConcatenation() }> */
 		nil,
-		/* 57 Action15 <- <{ p.addFirstValueInConcatenation() }> */
+		/* 71 Action23 <- <{  p.lastValueInConcatenation() }> */
 		nil,
-		/* 58 Action16 <- <{  p.lastValueInConcatenation() }> */
+		/* 72 Action24 <- <{ p.addFirstValueInConcatenation() }> */
 		nil,
-		/* 59 Action17 <- <{ p.addFirstValueInConcatenation() }> */
+		/* 73 Action25 <- <{  p.lastValueInConcatenation() }> */
 		nil,
-		/* 60 Action18 <- <{  p.lastValueInConcatenation() }> */
+		/* 74 Action26 <- <{ p.addStringValue(text) }> */
 		nil,
-		/* 61 Action19 <- <{ p.addStringValue(text) }> */
+		/* 75 Action27 <- <{  p.addParamHoleValue(text) }> */
 		nil,
-		/* 62 Action20 <- <{  p.addParamHoleValue(text) }> */
+		/* 76 Action28 <- <{ p.addFirstValueInConcatenation() }> */
 		nil,
-		/* 63 Action21 <- <{ p.addFirstValueInConcatenation() }> */
+		/* 77 Action29 <- <{  p.lastValueInConcatenation() }> */
 		nil,
-		/* 64 Action22 <- <{  p.lastValueInConcatenation() }> */
+		/* 78 Action30 <- <{ p.addFirstValueInConcatenation() }> */
 		nil,
-		/* 65 Action23 <- <{ p.addFirstValueInConcatenation() }> */
+		/* 79 Action31 <- <{  p.lastValueInConcatenation() }> */
 		nil,
-		/* 66 Action24 <- <{  p.lastValueInConcatenation() }> */
+		/* 80 Action32 <- <{ p.addComment(text) }> */
 		nil,
 	}
 	p.rules = _rules
@@ -9,18 +9,29 @@ type statementBuilder struct {
 	action                string
 	entity                string
 	declarationIdentifier string
+	includePath           string
 	isValue               bool
 	newparams             map[string]interface{}
 	currentKey            string
 	currentNode           interface{}
 	listBuilder           *listValueBuilder
 	concatenationBuilder  *concatenationValueBuilder
+	functionBuilders      []*functionValueBuilder
+	comment               string
+	pos                   Position
+	paramPos              map[string]Position
 }
 
 func (b *statementBuilder) build() *Statement {
-	if b.action == "" && b.entity == "" && b.declarationIdentifier == "" && !b.isValue {
+	if b.action == "" && b.entity == "" && b.declarationIdentifier == "" && b.includePath == "" && !b.isValue {
 		return nil
 	}
+	if b.includePath != "" {
+		if b.newparams == nil {
+			b.newparams = make(map[string]interface{})
+		}
+		return &Statement{Node: &IncludeNode{Path: b.includePath, ParamNodes: b.newparams}, Pos: b.pos, ParamPos: b.paramPos}
+	}
 	var expr ExpressionNode
 	if b.isValue {
 		expr = &RightExpressionNode{i: b.currentNode}
@@ -37,9 +48,9 @@ func (b *statementBuilder) build() *Statement {
 	}
 	if b.declarationIdentifier != "" {
 		decl := &DeclarationNode{Ident: b.declarationIdentifier, Expr: expr}
-		return &Statement{Node: decl}
+		return &Statement{Node: decl, Pos: b.pos, ParamPos: b.paramPos}
 	}
-	return &Statement{Node: expr}
+	return &Statement{Node: expr, Pos: b.pos, ParamPos: b.paramPos}
 }
 
 func (b *statementBuilder) addParamKey(key string) *statementBuilder {
@@ -55,6 +66,9 @@ func (b *statementBuilder) addParamValue(node interface{}) *statementBuilder {
 	if b.concatenationBuilder != nil {
 		b.concatenationBuilder.add(node)
 		b.currentNode = nil
+	} else if last := len(b.functionBuilders) - 1; last > -1 {
+		b.functionBuilders[last].add(node)
+		b.currentNode = nil
 	} else if b.listBuilder != nil {
 		b.listBuilder.add(node)
 		b.currentNode = nil
@@ -97,6 +111,10 @@ func (a *AST) addEntity(text string) {
 	a.stmtBuilder.entity = text
 }
 
+func (a *AST) addIncludePath(text string) {
+	a.stmtBuilder.includePath = text
+}
+
 func (a *AST) addValue() {
 	a.stmtBuilder.isValue = true
 }
@@ -110,13 +128,43 @@ func (a *AST) NewStatement() {
 }
 
 func (a *AST) StatementDone() {
-
-	if stmt := a.stmtBuilder.build(); stmt != nil {
+	if comment := a.stmtBuilder.comment; comment != "" {
+		a.Comments = append(a.Comments, &Comment{Text: comment, Pos: a.stmtBuilder.pos})
+	} else if stmt := a.stmtBuilder.build(); stmt != nil {
 		a.Statements = append(a.Statements, stmt)
 	}
 	a.stmtBuilder = nil
 }
 
+func (a *AST) markStatement(pos Position) {
+	a.stmtBuilder.pos = pos
+}
+
+func (a *AST) markParamKey(key string, pos Position) {
+	if a.stmtBuilder.paramPos == nil {
+		a.stmtBuilder.paramPos = make(map[string]Position)
+	}
+	a.stmtBuilder.paramPos[key] = pos
+}
+
+func (a *AST) addComment(text string) {
+	a.stmtBuilder.comment = text
+}
+
+// position converts an offset in the parsed text to a line and column
+func (p *Peg) position(offset int) Position {
+	pos := Position{Line: 1, Col: 1}
+	for _, r := range p.buffer[:offset] {
+		if r == '\n' {
+			pos.Line++
+			pos.Col = 1
+		} else {
+			pos.Col++
+		}
+	}
+	return pos
+}
+
 func (a *AST) addParamKey(text string) {
 	a.stmtBuilder.addParamKey(text)
 }
@@ -156,6 +204,18 @@ func (a *AST) lastValueInConcatenation() {
 	}
 }
 
+func (a *AST) addFunctionName(text string) {
+	a.stmtBuilder.functionBuilders = append(a.stmtBuilder.functionBuilders, &functionValueBuilder{name: text})
+}
+
+func (a *AST) lastValueInFunction() {
+	if last := len(a.stmtBuilder.functionBuilders) - 1; last > -1 {
+		node := a.stmtBuilder.functionBuilders[last].build()
+		a.stmtBuilder.functionBuilders = a.stmtBuilder.functionBuilders[:last]
+		a.stmtBuilder.addParamValue(node)
+	}
+}
+
 func (a *AST) addStringValue(text string) {
 	a.stmtBuilder.addParamValue(InterfaceNode{i: text})
 }
@@ -199,3 +259,17 @@ func (c *concatenationValueBuilder) build() ConcatenationNode {
 	node := ConcatenationNode{arr: c.elements}
 	return node
 }
+
+type functionValueBuilder struct {
+	name string
+	args []interface{}
+}
+
+func (c *functionValueBuilder) add(node interface{}) *functionValueBuilder {
+	c.args = append(c.args, node)
+	return c
+}
+
+func (c *functionValueBuilder) build() FunctionNode {
+	return FunctionNode{name: c.name, args: c.args}
+}
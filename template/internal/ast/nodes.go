@@ -15,6 +15,7 @@ var (
 	_ Node = (*ConcatenationNode)(nil)
 	_ Node = (*ListNode)(nil)
 	_ Node = (*InterfaceNode)(nil)
+	_ Node = (*FunctionNode)(nil)
 )
 
 type RightExpressionNode struct {
@@ -29,7 +30,7 @@ func (n *RightExpressionNode) Result() interface{} {
 	switch v := n.i.(type) {
 	case InterfaceNode:
 		return v.i
-	case RefNode, AliasNode, HoleNode:
+	case RefNode, AliasNode, HoleNode, FunctionNode:
 		return nil
 	case ListNode:
 		var arr []interface{}
@@ -37,7 +38,7 @@ func (n *RightExpressionNode) Result() interface{} {
 			switch ev := e.(type) {
 			case InterfaceNode:
 				arr = append(arr, ev.i)
-			case RefNode, AliasNode, HoleNode:
+			case RefNode, AliasNode, HoleNode, FunctionNode:
 				return nil
 			default:
 				arr = append(arr, ev)
@@ -77,8 +78,9 @@ type CompiledCommand struct {
 
 type CommandNode struct {
 	Command
-	CmdResult interface{}
-	CmdErr    error
+	CmdResult   interface{}
+	CmdErr      error
+	CmdSnapshot map[string]interface{}
 
 	Action, Entity string
 	ParamNodes     map[string]interface{}
@@ -257,3 +259,41 @@ func (n InterfaceNode) String() string {
 func (n InterfaceNode) clone() Node {
 	return n
 }
+
+type FunctionNode struct {
+	name string
+	args []interface{}
+}
+
+func NewFunctionNode(name string, args []interface{}) FunctionNode {
+	return FunctionNode{name: name, args: args}
+}
+
+func (n FunctionNode) Name() string {
+	return n.name
+}
+
+func (n FunctionNode) Args() []interface{} {
+	return n.args
+}
+
+func (n FunctionNode) String() string {
+	var args []string
+	for _, a := range n.args {
+		switch aa := a.(type) {
+		case InterfaceNode:
+			if str, isStr := aa.i.(string); isStr {
+				args = append(args, doubleQuote(str))
+			} else {
+				args = append(args, aa.String())
+			}
+		default:
+			args = append(args, fmt.Sprint(aa))
+		}
+	}
+	return fmt.Sprintf("%s(%s)", n.name, strings.Join(args, ", "))
+}
+
+func (n FunctionNode) clone() Node {
+	return n
+}
@@ -1,12 +1,11 @@
 package ast
 
 import (
-	"regexp"
 	"strconv"
 	"strings"
-)
 
-var SimpleStringValue = regexp.MustCompile("^[a-zA-Z0-9-._:/+;~@<>*]+$") // in sync with [a-zA-Z0-9-._:/+;~@<>]+ in PEG (with ^ and $ around)
+	"github.com/wallix/awless/template/params"
+)
 
 func quoteStringIfNeeded(input string) string {
 	if _, err := strconv.Atoi(input); err == nil {
@@ -15,7 +14,7 @@ func quoteStringIfNeeded(input string) string {
 	if _, err := strconv.ParseFloat(input, 64); err == nil {
 		return "'" + input + "'"
 	}
-	if SimpleStringValue.MatchString(input) {
+	if params.SimpleStringValue.MatchString(input) {
 		return input
 	} else {
 		return Quote(input)
@@ -30,6 +29,13 @@ func Quote(str string) string {
 	}
 }
 
+func doubleQuote(str string) string {
+	if strings.ContainsRune(str, '"') {
+		return "'" + str + "'"
+	}
+	return "\"" + str + "\""
+}
+
 func isQuoted(str string) bool {
 	if len(str) < 2 {
 		return false
@@ -49,8 +49,12 @@ func ProcessRefs(tree Node, fillers map[string]interface{}) {
 			switch p := parent.(type) {
 			case ListNode:
 				p.arr[v.listIndex] = val
+			case FunctionNode:
+				p.args[v.funcArgIndex] = val
 			case *CommandNode:
 				p.ParamNodes[v.key] = val
+			case *IncludeNode:
+				p.ParamNodes[v.key] = val
 			case *RightExpressionNode:
 				p.i = val
 			}
@@ -105,6 +109,20 @@ func CollectHoles(tree Node) (holes []HoleNode) {
 	return
 }
 
+// CollectParamRefsAndHoles returns the refs and holes of the tree, keyed by the param they are assigned to
+func CollectParamRefsAndHoles(tree Node) (refs map[string][]RefNode, holes map[string][]HoleNode) {
+	refs, holes = make(map[string][]RefNode), make(map[string][]HoleNode)
+	v := newVisitor()
+	v.onRefs = func(parent interface{}, node RefNode) {
+		refs[v.key] = append(refs[v.key], node)
+	}
+	v.onHoles = func(parent interface{}, node HoleNode) {
+		holes[v.key] = append(holes[v.key], node)
+	}
+	v.visit(tree)
+	return
+}
+
 func ProcessHoles(tree Node, fillers map[string]interface{}) map[string]interface{} {
 	processed := make(map[string]interface{})
 
@@ -142,8 +160,12 @@ func ProcessHoles(tree Node, fillers map[string]interface{}) map[string]interfac
 				p.arr[v.concatItemIndex] = val
 			case ListNode:
 				p.arr[v.listIndex] = val
+			case FunctionNode:
+				p.args[v.funcArgIndex] = val
 			case *CommandNode:
 				p.ParamNodes[v.key] = val
+			case *IncludeNode:
+				p.ParamNodes[v.key] = val
 			case *RightExpressionNode:
 				p.i = val
 			}
@@ -155,6 +177,37 @@ func ProcessHoles(tree Node, fillers map[string]interface{}) map[string]interfac
 	return processed
 }
 
+// ProcessFunctions replaces functions with their result. evalFunc is not called
+// for functions nested in arguments: evaluating them is up to the outer function.
+// Functions evalFunc cannot evaluate yet (i.e. unresolved holes) are left as is.
+func ProcessFunctions(tree Node, evalFunc func(fn FunctionNode, key string) (interface{}, bool, error)) error {
+	var err error
+	v := newVisitor()
+	v.onFunctions = func(parent interface{}, node FunctionNode) {
+		if _, isNested := parent.(FunctionNode); isNested || err != nil {
+			return
+		}
+		res, done, evalErr := evalFunc(node, v.key)
+		if evalErr != nil {
+			err = evalErr
+			return
+		}
+		if done {
+			val := InterfaceNode{i: res}
+			switch p := parent.(type) {
+			case ListNode:
+				p.arr[v.listIndex] = val
+			case *CommandNode:
+				p.ParamNodes[v.key] = val
+			case *RightExpressionNode:
+				p.i = val
+			}
+		}
+	}
+	v.visit(tree)
+	return err
+}
+
 func CollectAliases(tree Node) (aliases []AliasNode) {
 	v := newVisitor()
 	v.onAliases = func(parent interface{}, node AliasNode) {
@@ -171,6 +224,8 @@ func ProcessAliases(tree Node, aliasFunc func(action, entity string, key string)
 			switch p := parent.(type) {
 			case ListNode:
 				p.arr[v.listIndex] = resolv
+			case FunctionNode:
+				p.args[v.funcArgIndex] = resolv
 			case ConcatenationNode:
 				p.arr[v.concatItemIndex] = resolv
 			case *CommandNode:
@@ -186,21 +241,24 @@ func ProcessAliases(tree Node, aliasFunc func(action, entity string, key string)
 }
 
 type visitor struct {
-	onRefs    func(parent interface{}, n RefNode)
-	onAliases func(parent interface{}, n AliasNode)
-	onHoles   func(parent interface{}, n HoleNode)
+	onRefs      func(parent interface{}, n RefNode)
+	onAliases   func(parent interface{}, n AliasNode)
+	onHoles     func(parent interface{}, n HoleNode)
+	onFunctions func(parent interface{}, n FunctionNode)
 
 	parent                     Node
 	declaredVariables          []string
 	action, entity, key        string
 	listIndex, concatItemIndex int
+	funcArgIndex               int
 }
 
 func newVisitor() *visitor {
 	return &visitor{
-		onRefs:    func(interface{}, RefNode) {},
-		onAliases: func(interface{}, AliasNode) {},
-		onHoles:   func(interface{}, HoleNode) {},
+		onRefs:      func(interface{}, RefNode) {},
+		onAliases:   func(interface{}, AliasNode) {},
+		onHoles:     func(interface{}, HoleNode) {},
+		onFunctions: func(interface{}, FunctionNode) {},
 	}
 }
 
@@ -237,6 +295,15 @@ func (v *visitor) visit(tree Node) {
 				v.visit(n)
 			}
 		}
+	case *IncludeNode:
+		v.action, v.entity = "include", ""
+		for key, param := range t.ParamNodes {
+			v.parent = tree
+			v.key = key
+			if n, ok := param.(Node); ok {
+				v.visit(n)
+			}
+		}
 	case *DeclarationNode:
 		v.key = t.Ident
 		v.parent = tree
@@ -249,13 +316,22 @@ func (v *visitor) visit(tree Node) {
 		}
 
 	case ListNode:
-		v.parent = tree
 		for i, el := range t.arr {
+			v.parent = tree
 			v.listIndex = i
 			if n, ok := el.(Node); ok {
 				v.visit(n)
 			}
 		}
+	case FunctionNode:
+		v.onFunctions(v.parent, t)
+		for i, el := range t.args {
+			v.parent = tree
+			v.funcArgIndex = i
+			if n, ok := el.(Node); ok {
+				v.visit(n)
+			}
+		}
 	case ConcatenationNode:
 		v.parent = tree
 		for i, el := range t.arr {
@@ -0,0 +1,294 @@
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/internal/ast"
+	"github.com/wallix/awless/template/params"
+)
+
+// LintCompileMode runs the compile passes that do not need prompting nor resolving aliases
+var LintCompileMode = []compileFunc{
+	resolveIncludesPass,
+	injectCommandsInNodesPass,
+	failOnDeclarationWithNoResultPass,
+	processAndValidateParamsPass,
+	checkInvalidReferenceDeclarationsPass,
+	resolveHolesPass,
+	removeOptionalHolesPass,
+	evaluateFunctionsPass,
+	inlineVariableValuePass,
+}
+
+var revertibleHeaderRegex = regexp.MustCompile(`(?i)^#\s*Revertible:\s*true\s*$`)
+
+type LintIssue struct {
+	ast.Position
+	Warning bool
+	Message string
+}
+
+func (i *LintIssue) String() string {
+	level := "error"
+	if i.Warning {
+		level = "warning"
+	}
+	return fmt.Sprintf("%s: %s: %s", i.Position, level, i.Message)
+}
+
+// Lint reports the problems of a template without running it: unknown params, unused
+// declarations, refs used before declaration, holes without default value (i.e. not in
+// the env fillers nor declared defaults), invalid "# Param:" declarations and non revertible
+// commands in a template with a "# Revertible: true" header.
+// The compile passes are then run if no error was found.
+func Lint(tpl *Template, cenv env.Compiling) (issues []*LintIssue) {
+	errorf := func(pos ast.Position, msg string, a ...interface{}) {
+		issues = append(issues, &LintIssue{Position: pos, Message: fmt.Sprintf(msg, a...)})
+	}
+	warnf := func(pos ast.Position, msg string, a ...interface{}) {
+		issues = append(issues, &LintIssue{Position: pos, Warning: true, Message: fmt.Sprintf(msg, a...)})
+	}
+
+	var revertible bool
+	for _, c := range tpl.Comments {
+		if revertibleHeaderRegex.MatchString(strings.TrimSpace(c.Text)) {
+			revertible = true
+		}
+	}
+
+	allDeclared := make(map[string]bool)
+	for _, decl := range tpl.declarationNodesIterator() {
+		allDeclared[decl.Ident] = true
+	}
+	fillers := cenv.Get(env.FILLERS)
+	paramDecls := lintParamDeclarations(tpl, fillers, errorf)
+	declaredDefaults := make(map[string]interface{})
+	for _, d := range paramDecls {
+		if _, ok := fillers[d.Hole]; !ok && d.Default != "" {
+			if parsed, err := ParseParams(fmt.Sprintf("%s=%s", d.Hole, d.Default)); err == nil {
+				declaredDefaults[d.Hole] = parsed[d.Hole]
+				fillers[d.Hole] = parsed[d.Hole]
+			}
+		}
+	}
+	cenv.Push(env.FILLERS, declaredDefaults)
+	holesUsed := make(map[string]bool)
+	declared := make(map[string]ast.Position)
+	used := make(map[string]bool)
+	var declarationOrder []string
+
+	for _, st := range tpl.Statements {
+		paramPos := func(key string) ast.Position {
+			if pos, ok := st.ParamPos[key]; ok {
+				return pos
+			}
+			return st.Pos
+		}
+
+		var cmd *ast.CommandNode
+		var decl *ast.DeclarationNode
+		switch n := st.Node.(type) {
+		case *ast.CommandNode:
+			cmd = n
+		case *ast.DeclarationNode:
+			decl = n
+			cmd, _ = n.Expr.(*ast.CommandNode)
+		case *ast.IncludeNode:
+			included, err := expandIncludes([]*ast.Statement{st}, cenv.IncludeFunc(), "", nil)
+			if err != nil {
+				errorf(st.Pos, "%s", err)
+			}
+			for _, incl := range included {
+				if d, ok := incl.Node.(*ast.DeclarationNode); ok {
+					allDeclared[d.Ident] = true
+					declared[d.Ident] = st.Pos
+					used[d.Ident] = true // variables of included templates are not required to be used
+				}
+			}
+		}
+
+		if cmd != nil {
+			lintCommand(cmd, decl != nil, revertible, cenv, fillers, st.Pos, paramPos, errorf, warnf)
+		}
+
+		refs, holes := ast.CollectParamRefsAndHoles(st)
+		_, isInclude := st.Node.(*ast.IncludeNode)
+		for _, key := range sortedParamKeys(refs, holes) {
+			for _, ref := range refs[key] {
+				used[ref.Ref()] = true
+				if _, ok := declared[ref.Ref()]; ok {
+					continue
+				}
+				if allDeclared[ref.Ref()] {
+					errorf(paramPos(key), "%s is used before its declaration", ref)
+				} else {
+					errorf(paramPos(key), "%s is not declared", ref)
+				}
+			}
+			for _, hole := range holes[key] {
+				holesUsed[hole.Hole()] = true
+				if _, ok := fillers[hole.Hole()]; !ok && !isInclude {
+					warnf(paramPos(key), "hole %s has no default value and will be prompted", hole)
+				}
+			}
+		}
+
+		if decl != nil {
+			if _, ok := declared[decl.Ident]; !ok {
+				declarationOrder = append(declarationOrder, decl.Ident)
+			}
+			declared[decl.Ident] = st.Pos
+		}
+	}
+
+	for _, ident := range declarationOrder {
+		if !used[ident] {
+			warnf(declared[ident], "%s is declared but never used", ident)
+		}
+	}
+	for _, d := range paramDecls {
+		if !holesUsed[d.Hole] {
+			warnf(d.pos, "param %s is declared but never used", d.Hole)
+		}
+	}
+
+	var hasErrors bool
+	for _, issue := range issues {
+		hasErrors = hasErrors || !issue.Warning
+	}
+	if !hasErrors {
+		if _, _, err := Compile(&Template{AST: tpl.AST.Clone()}, cenv, LintCompileMode); err != nil {
+			errorf(compileErrorPosition(tpl, err), "%s", err)
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line == issues[j].Line {
+			return issues[i].Col < issues[j].Col
+		}
+		return issues[i].Line < issues[j].Line
+	})
+	return
+}
+
+type lintedParamDeclaration struct {
+	*ParamDeclaration
+	pos ast.Position
+}
+
+func lintParamDeclarations(tpl *Template, fillers map[string]interface{}, errorf func(ast.Position, string, ...interface{})) (decls []lintedParamDeclaration) {
+	seen := make(map[string]bool)
+	for _, c := range tpl.Comments {
+		matches := paramDeclarationRegex.FindStringSubmatch(strings.TrimSpace(c.Text))
+		if len(matches) != 2 {
+			continue
+		}
+		decl, err := parseParamDeclaration(matches[1])
+		if err != nil {
+			errorf(c.Pos, "param declaration: %s", err)
+			continue
+		}
+		if seen[decl.Hole] {
+			errorf(c.Pos, "param declaration: '%s' already declared", decl.Hole)
+			continue
+		}
+		seen[decl.Hole] = true
+		if v, ok := fillers[decl.Hole]; ok {
+			if err := decl.Validate(v); err != nil {
+				errorf(c.Pos, "param %s: %s", decl.Hole, err)
+			}
+		}
+		decls = append(decls, lintedParamDeclaration{ParamDeclaration: decl, pos: c.Pos})
+	}
+	return
+}
+
+func lintCommand(cmd *ast.CommandNode, isDeclared, revertible bool, cenv env.Compiling, fillers map[string]interface{},
+	pos ast.Position, paramPos func(string) ast.Position, errorf, warnf func(ast.Position, string, ...interface{})) {
+	lookup := cenv.LookupCommandFunc()
+	if lookup == nil {
+		return
+	}
+	command, ok := lookup(cmd.Action, cmd.Entity).(ast.Command)
+	if !ok || command == nil {
+		errorf(pos, "unknown command '%s %s'", cmd.Action, cmd.Entity)
+		return
+	}
+
+	rule := command.ParamsSpec().Rule()
+	required, optionals, suggested := params.List(rule)
+	known := append(append(required, optionals...), suggested...)
+	for _, key := range cmd.Keys() {
+		if !contains(known, key) {
+			errorf(paramPos(key), "%s %s: unknown param '%s'", cmd.Action, cmd.Entity, key)
+		}
+	}
+	literals := make(map[string]interface{})
+	for k, v := range cmd.ParamNodes {
+		if n, ok := v.(ast.InterfaceNode); ok {
+			literals[k] = n.Value()
+		}
+	}
+	for _, key := range cmd.Keys() {
+		if validate, ok := command.ParamsSpec().Validators()[key]; ok && literals[key] != nil {
+			if err := validate(literals[key], literals); err != nil {
+				errorf(paramPos(key), "%s %s: %s: %s", cmd.Action, cmd.Entity, key, err)
+			}
+		}
+	}
+	for _, key := range rule.Missing(cmd.Keys()) {
+		if hole := fmt.Sprintf("%s.%s", cmd.Entity, key); fillers[hole] == nil {
+			warnf(pos, "%s %s: missing param '%s' has no default value and will be prompted", cmd.Action, cmd.Entity, key)
+		}
+	}
+
+	if isDeclared {
+		if _, ok := command.(interface {
+			ExtractResult(interface{}) string
+		}); !ok {
+			errorf(pos, "%s %s: command does not return a result, cannot assign to a variable", cmd.Action, cmd.Entity)
+		}
+	}
+
+	if revertible {
+		_, isReverter := command.(reverter)
+		_, isSnapshotter := command.(snapshotter)
+		if !isReverter && !isSnapshotter {
+			errorf(pos, "%s %s: command is not revertible, in a template flagged as revertible", cmd.Action, cmd.Entity)
+		}
+	}
+}
+
+func sortedParamKeys(refs map[string][]ast.RefNode, holes map[string][]ast.HoleNode) (keys []string) {
+	for k := range refs {
+		keys = append(keys, k)
+	}
+	for k := range holes {
+		if _, ok := refs[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return
+}
+
+// compileErrorPosition locates a compile error prefixed with "action entity:" at its first matching command
+func compileErrorPosition(tpl *Template, err error) ast.Position {
+	for _, st := range tpl.Statements {
+		var cmd *ast.CommandNode
+		switch n := st.Node.(type) {
+		case *ast.CommandNode:
+			cmd = n
+		case *ast.DeclarationNode:
+			cmd, _ = n.Expr.(*ast.CommandNode)
+		}
+		if cmd != nil && strings.HasPrefix(err.Error(), fmt.Sprintf("%s %s:", cmd.Action, cmd.Entity)) {
+			return st.Pos
+		}
+	}
+	return ast.Position{Line: 1, Col: 1}
+}
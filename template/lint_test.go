@@ -0,0 +1,142 @@
+package template
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/template/env"
+)
+
+func TestFormat(t *testing.T) {
+	in := `# Create a network
+#  with a subnet
+
+
+vpc    =  create vpc cidr=10.0.0.0/16 name=my-vpc
+    # the subnet
+create subnet vpc=$vpc   cidr={subnet.cidr}
+`
+	exp := `# Create a network
+#  with a subnet
+
+vpc = create vpc cidr=10.0.0.0/16 name=my-vpc
+# the subnet
+create subnet cidr={subnet.cidr} vpc=$vpc
+`
+	tpl := MustParse(in)
+	if got, want := tpl.Format(), exp; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	if got, want := MustParse(exp).Format(), exp; got != want {
+		t.Fatalf("not idempotent: got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestParsePositions(t *testing.T) {
+	tpl := MustParse("# comment\n\n  vpc = create vpc cidr=10.0.0.0/16\ncreate subnet  vpc=$vpc cidr=10.0.0.0/24")
+	if got, want := len(tpl.Comments), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := tpl.Comments[0].Pos.String(), "1:1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tpl.Statements[0].Pos.String(), "3:3"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tpl.Statements[0].ParamPos["cidr"].String(), "3:20"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tpl.Statements[1].ParamPos["vpc"].String(), "4:16"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestLint(t *testing.T) {
+	tcases := []struct {
+		name    string
+		tpl     string
+		fillers map[string]interface{}
+		exp     []string
+	}{
+		{
+			name: "valid template",
+			tpl:  "vpc = create vpc cidr=10.0.0.0/16\ncreate subnet vpc=$vpc cidr=10.0.0.0/24",
+		},
+		{
+			name: "unknown param",
+			tpl:  "create vpc cidr=10.0.0.0/16 size=2",
+			exp:  []string{"1:29: error: create vpc: unknown param 'size'"},
+		},
+		{
+			name: "unknown command",
+			tpl:  "create vpc cidr=10.0.0.0/16\nstart vpc id=any",
+			exp:  []string{"2:1: error: unknown command 'start vpc'"},
+		},
+		{
+			name: "unused declaration",
+			tpl:  "vpc = create vpc cidr=10.0.0.0/16",
+			exp:  []string{"1:1: warning: vpc is declared but never used"},
+		},
+		{
+			name: "ref used before declaration and undeclared",
+			tpl:  "create subnet vpc=$vpc cidr=10.0.0.0/24\nvpc = create vpc cidr=10.0.0.0/16\ndelete subnet id=$sub",
+			exp: []string{
+				"1:15: error: $vpc is used before its declaration",
+				"3:15: error: $sub is not declared",
+			},
+		},
+		{
+			name: "holes without default",
+			tpl:  "create vpc cidr={my.cidr} name={vpc.name}\ncreate subnet cidr=10.0.0.0/24 vpc=vpc-1234",
+			fillers: map[string]interface{}{
+				"vpc.name": "default-vpc",
+			},
+			exp: []string{"1:12: warning: hole {my.cidr} has no default value and will be prompted"},
+		},
+		{
+			name: "missing required param",
+			tpl:  "create subnet cidr=10.0.0.0/24",
+			exp:  []string{"1:1: warning: create subnet: missing param 'vpc' has no default value and will be prompted"},
+		},
+		{
+			name: "non revertible command in revertible template",
+			tpl:  "# Revertible: true\ncreate vpc cidr=10.0.0.0/16\ndelete subnet id=subnet-1234",
+			exp:  []string{"3:1: error: delete subnet: command is not revertible, in a template flagged as revertible"},
+		},
+		{
+			name: "invalid param value",
+			tpl:  "create subnet vpc=vpc-1234 cidr=10.0.0",
+			exp:  []string{"1:28: error: create subnet: cidr: invalid CIDR address: 10.0.0"},
+		},
+		{
+			name: "param declarations",
+			tpl:  "# Param: vpc.cidr cidr default=10.0.0.0/16\n# Param: vpc.name string allowed=prod,staging\n# Param: subnet.cidr cidr\ncreate vpc cidr={vpc.cidr} name={vpc.name}",
+			fillers: map[string]interface{}{
+				"vpc.name": "dev",
+			},
+			exp: []string{
+				"2:1: error: param vpc.name: 'dev' is not one of prod, staging",
+				"3:1: warning: param subnet.cidr is declared but never used",
+			},
+		},
+		{
+			name: "invalid param declaration",
+			tpl:  "# Param: vpc.cidr cidr default=10.0.0\ncreate vpc cidr=10.0.0.0/16",
+			exp:  []string{"1:1: error: param declaration: invalid default value: '10.0.0' is not a CIDR"},
+		},
+	}
+
+	for _, tcase := range tcases {
+		t.Run(tcase.name, func(t *testing.T) {
+			cenv := NewEnv().WithLookupCommandFunc(lookupMockCommand).Build()
+			cenv.Push(env.FILLERS, tcase.fillers)
+			var got []string
+			for _, issue := range Lint(MustParse(tcase.tpl), cenv) {
+				got = append(got, issue.String())
+			}
+			if !reflect.DeepEqual(got, tcase.exp) {
+				t.Fatalf("got %q\nwant %q", got, tcase.exp)
+			}
+		})
+	}
+}
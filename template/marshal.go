@@ -18,6 +18,9 @@ type TemplateExecution struct {
 	Author, Source, Locale string
 	Profile, Path, Message string
 	Fillers                map[string]interface{}
+
+	// compiled is the template as compiled before running, to report the commands that did not run
+	compiled *Template
 }
 
 // Date extract the date from the ulid template identifier
@@ -74,6 +77,7 @@ func (t *TemplateExecution) MarshalJSON() ([]byte, error) {
 				newCmd.Results = append(newCmd.Results, s)
 			}
 		}
+		newCmd.Snapshot = cmd.CmdSnapshot
 		out.Commands = append(out.Commands, newCmd)
 	}
 
@@ -122,6 +126,7 @@ func (t *TemplateExecution) UnmarshalJSON(b []byte) error {
 			if len(c.Errors) > 0 {
 				n.CmdErr = errors.New(c.Errors[0])
 			}
+			n.CmdSnapshot = c.Snapshot
 			tpl.Statements = append(tpl.Statements, &ast.Statement{Node: n})
 		}
 	}
@@ -176,7 +181,8 @@ type toJSON struct {
 }
 
 type command struct {
-	Line    string   `json:"line"`
-	Errors  []string `json:"errors,omitempty"`
-	Results []string `json:"results,omitempty"`
+	Line     string                 `json:"line"`
+	Errors   []string               `json:"errors,omitempty"`
+	Results  []string               `json:"results,omitempty"`
+	Snapshot map[string]interface{} `json:"snapshot,omitempty"`
 }
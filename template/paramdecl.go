@@ -0,0 +1,284 @@
+package template
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+var paramDeclarationRegex = regexp.MustCompile(`^#\s*Param:\s*(.*)$`)
+
+const (
+	StringParam     = "string"
+	IntParam        = "int"
+	BoolParam       = "bool"
+	CIDRParam       = "cidr"
+	IPParam         = "ip"
+	ResourceIDParam = "resource-id"
+)
+
+// ParamDeclaration is a typed parameter of a template, declared in its header with:
+//
+//	# Param: <hole> <type> ["description"] [default=<value>] [allowed=<value>,<value>...]
+//
+// where type is one of string, int, bool, cidr, ip or resource-id:<resource type>
+type ParamDeclaration struct {
+	Hole         string
+	Type         string
+	ResourceType string
+	Description  string
+	Default      string
+	Allowed      []string
+}
+
+type ParamDeclarations []*ParamDeclaration
+
+// ParamDeclarations returns the parameters declared in the comments of the template
+func (t *Template) ParamDeclarations() (ParamDeclarations, error) {
+	var decls ParamDeclarations
+	if t == nil || t.AST == nil {
+		return decls, nil
+	}
+	for _, c := range t.Comments {
+		matches := paramDeclarationRegex.FindStringSubmatch(strings.TrimSpace(c.Text))
+		if len(matches) != 2 {
+			continue
+		}
+		decl, err := parseParamDeclaration(matches[1])
+		if err != nil {
+			return decls, fmt.Errorf("line %d: param declaration: %s", c.Pos.Line, err)
+		}
+		if _, exists := decls.Get(decl.Hole); exists {
+			return decls, fmt.Errorf("line %d: param declaration: '%s' already declared", c.Pos.Line, decl.Hole)
+		}
+		decls = append(decls, decl)
+	}
+	return decls, nil
+}
+
+func parseParamDeclaration(text string) (*ParamDeclaration, error) {
+	tokens, err := splitDeclarationTokens(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) < 2 {
+		return nil, errors.New("expecting at least a hole and a type")
+	}
+	decl := &ParamDeclaration{Hole: strings.Trim(tokens[0], "{}"), Type: tokens[1]}
+	if strings.HasPrefix(decl.Type, ResourceIDParam+":") {
+		decl.Type, decl.ResourceType = ResourceIDParam, strings.TrimPrefix(decl.Type, ResourceIDParam+":")
+	}
+	switch decl.Type {
+	case StringParam, IntParam, BoolParam, CIDRParam, IPParam:
+	case ResourceIDParam:
+		if decl.ResourceType == "" {
+			return nil, fmt.Errorf("%s: missing resource type (i.e. resource-id:subnet)", decl.Hole)
+		}
+	default:
+		return nil, fmt.Errorf("%s: unknown type '%s' (expecting string, int, bool, cidr, ip or resource-id:<type>)", decl.Hole, decl.Type)
+	}
+
+	var description []string
+	for _, tok := range tokens[2:] {
+		switch {
+		case strings.HasPrefix(tok, "default="):
+			decl.Default = strings.TrimPrefix(tok, "default=")
+		case strings.HasPrefix(tok, "allowed="):
+			for _, v := range strings.Split(strings.TrimPrefix(tok, "allowed="), ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					decl.Allowed = append(decl.Allowed, v)
+				}
+			}
+		default:
+			description = append(description, tok)
+		}
+	}
+	decl.Description = strings.Join(description, " ")
+
+	if decl.Default != "" {
+		if err := decl.Validate(decl.Default); err != nil {
+			return nil, fmt.Errorf("invalid default value: %s", err)
+		}
+	}
+	return decl, nil
+}
+
+// splitDeclarationTokens splits on spaces, keeping quoted strings (without their quotes) as one token
+func splitDeclarationTokens(text string) (tokens []string, err error) {
+	var current bytes.Buffer
+	var quote rune
+	var inToken bool
+	for _, r := range text {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inToken = r, true
+		case r == ' ' || r == '\t':
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote %c", quote)
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+	return
+}
+
+func (decls ParamDeclarations) Get(hole string) (*ParamDeclaration, bool) {
+	for _, d := range decls {
+		if d.Hole == hole {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// Defaults returns the declared default values as fillers
+func (decls ParamDeclarations) Defaults() (map[string]interface{}, error) {
+	defaults := make(map[string]interface{})
+	for _, d := range decls {
+		if d.Default == "" {
+			continue
+		}
+		parsed, err := ParseParams(fmt.Sprintf("%s=%s", d.Hole, d.Default))
+		if err != nil {
+			return defaults, fmt.Errorf("default value of %s: %s", d.Hole, err)
+		}
+		for k, v := range parsed {
+			defaults[k] = v
+		}
+	}
+	return defaults, nil
+}
+
+// ValidateFillers validates the values of the declared holes, the last fillers having precedence
+func (decls ParamDeclarations) ValidateFillers(fillers ...map[string]interface{}) error {
+	merged := make(map[string]interface{})
+	for _, m := range fillers {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	var errs []string
+	for _, d := range decls {
+		if v, ok := merged[d.Hole]; ok {
+			if err := d.Validate(v); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %s", d.Hole, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid params: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Validate checks a value against the declared type and allowed values.
+// Aliases, references and holes, resolved later, are not validated.
+func (d *ParamDeclaration) Validate(value interface{}) error {
+	switch v := value.(type) {
+	case ast.AliasNode, ast.RefNode, ast.HoleNode:
+		return nil
+	case ast.ListNode:
+		for _, e := range v.Elems() {
+			if err := d.Validate(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		for _, e := range v {
+			if err := d.Validate(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	case string:
+		if strings.HasPrefix(v, "@") || strings.HasPrefix(v, "$") || strings.HasPrefix(v, "{") {
+			return nil
+		}
+	}
+
+	str := fmt.Sprint(value)
+	switch d.Type {
+	case IntParam:
+		if _, err := strconv.Atoi(str); err != nil {
+			return fmt.Errorf("'%s' is not an int", str)
+		}
+	case BoolParam:
+		if _, err := strconv.ParseBool(str); err != nil {
+			return fmt.Errorf("'%s' is not a bool", str)
+		}
+	case CIDRParam:
+		if _, _, err := net.ParseCIDR(str); err != nil {
+			return fmt.Errorf("'%s' is not a CIDR", str)
+		}
+	case IPParam:
+		if net.ParseIP(str) == nil {
+			return fmt.Errorf("'%s' is not an IP", str)
+		}
+	case ResourceIDParam:
+		if strings.TrimSpace(str) == "" {
+			return fmt.Errorf("empty %s id", d.ResourceType)
+		}
+	}
+	if len(d.Allowed) > 0 && !contains(d.Allowed, str) {
+		return fmt.Errorf("'%s' is not one of %s", str, strings.Join(d.Allowed, ", "))
+	}
+	return nil
+}
+
+// TypeString returns the type as declared (i.e. resource-id:subnet)
+func (d *ParamDeclaration) TypeString() string {
+	if d.Type == ResourceIDParam {
+		return d.Type + ":" + d.ResourceType
+	}
+	return d.Type
+}
+
+// Doc returns the documentation of the declared parameters
+func (decls ParamDeclarations) Doc() string {
+	var holeWidth, typeWidth int
+	for _, d := range decls {
+		if l := len(d.Hole); l > holeWidth {
+			holeWidth = l
+		}
+		if l := len(d.TypeString()); l > typeWidth {
+			typeWidth = l
+		}
+	}
+	var buff bytes.Buffer
+	for _, d := range decls {
+		details := []string{fmt.Sprintf("%-*s ", typeWidth, d.TypeString())}
+		if d.Description != "" {
+			details = append(details, d.Description)
+		}
+		if len(d.Allowed) > 0 {
+			details = append(details, fmt.Sprintf("(%s)", strings.Join(d.Allowed, " | ")))
+		}
+		if d.Default != "" {
+			details = append(details, fmt.Sprintf("[default: %s]", d.Default))
+		}
+		line := fmt.Sprintf("  %-*s  %s", holeWidth, d.Hole, strings.Join(details, " "))
+		buff.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	return buff.String()
+}
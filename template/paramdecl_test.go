@@ -0,0 +1,121 @@
+package template
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+func TestParamDeclarations(t *testing.T) {
+	tpl := MustParse(`# Title: my infra
+# Param: {vpc.cidr} cidr "CIDR of the VPC" default=10.0.0.0/16
+# Param: subnet.az string 'Availability zone' allowed=eu-west-1a,eu-west-1b
+#Param: instance.count int default=2
+# Param: instance.subnet resource-id:subnet "Subnet of the instance"
+create vpc cidr={vpc.cidr}`)
+
+	decls, err := tpl.ParamDeclarations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := ParamDeclarations{
+		{Hole: "vpc.cidr", Type: "cidr", Description: "CIDR of the VPC", Default: "10.0.0.0/16"},
+		{Hole: "subnet.az", Type: "string", Description: "Availability zone", Allowed: []string{"eu-west-1a", "eu-west-1b"}},
+		{Hole: "instance.count", Type: "int", Default: "2"},
+		{Hole: "instance.subnet", Type: "resource-id", ResourceType: "subnet", Description: "Subnet of the instance"},
+	}
+	if got, want := decls, exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	defaults, err := decls.Defaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := defaults, map[string]interface{}{"vpc.cidr": "10.0.0.0/16", "instance.count": 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if err := decls.ValidateFillers(defaults, map[string]interface{}{"instance.count": "two"}); err == nil || err.Error() != "invalid params: instance.count: 'two' is not an int" {
+		t.Fatalf("got %v", err)
+	}
+	if err := decls.ValidateFillers(map[string]interface{}{"subnet.az": "us-east-1a"}, map[string]interface{}{"subnet.az": "eu-west-1a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expDoc := `  vpc.cidr         cidr                CIDR of the VPC [default: 10.0.0.0/16]
+  subnet.az        string              Availability zone (eu-west-1a | eu-west-1b)
+  instance.count   int                 [default: 2]
+  instance.subnet  resource-id:subnet  Subnet of the instance
+`
+	if got, want := decls.Doc(), expDoc; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		tcases := []struct {
+			tpl, expErr string
+		}{
+			{tpl: "# Param: vpc.cidr\ncreate vpc", expErr: "line 1: param declaration: expecting at least a hole and a type"},
+			{tpl: "# Param: vpc.cidr float\ncreate vpc", expErr: "unknown type 'float'"},
+			{tpl: "# Param: vpc.id resource-id\ncreate vpc", expErr: "missing resource type"},
+			{tpl: "# Param: vpc.cidr cidr default=10.0.0\ncreate vpc", expErr: "invalid default value: '10.0.0' is not a CIDR"},
+			{tpl: "# Param: vpc.cidr cidr \"unterminated\ncreate vpc", expErr: "unterminated quote"},
+			{tpl: "# Param: vpc.cidr cidr\n# Param: {vpc.cidr} string\ncreate vpc", expErr: "line 2: param declaration: 'vpc.cidr' already declared"},
+		}
+		for _, tcase := range tcases {
+			_, err := MustParse(tcase.tpl).ParamDeclarations()
+			if err == nil || !strings.Contains(err.Error(), tcase.expErr) {
+				t.Fatalf("got %v, want error containing %s", err, tcase.expErr)
+			}
+		}
+	})
+}
+
+func TestValidateParamDeclaration(t *testing.T) {
+	tcases := []struct {
+		decl   *ParamDeclaration
+		value  interface{}
+		expErr string
+	}{
+		{decl: &ParamDeclaration{Type: "int"}, value: 3},
+		{decl: &ParamDeclaration{Type: "int"}, value: "three", expErr: "'three' is not an int"},
+		{decl: &ParamDeclaration{Type: "bool"}, value: "yes", expErr: "'yes' is not a bool"},
+		{decl: &ParamDeclaration{Type: "cidr"}, value: "10.0.0.0/24"},
+		{decl: &ParamDeclaration{Type: "cidr"}, value: "10.0.0.0", expErr: "'10.0.0.0' is not a CIDR"},
+		{decl: &ParamDeclaration{Type: "ip"}, value: "10.0.0.1"},
+		{decl: &ParamDeclaration{Type: "ip"}, value: "10.0.0", expErr: "'10.0.0' is not an IP"},
+		{decl: &ParamDeclaration{Type: "string", Allowed: []string{"t2.micro", "t2.nano"}}, value: "t2.nano"},
+		{decl: &ParamDeclaration{Type: "string", Allowed: []string{"t2.micro", "t2.nano"}}, value: "m4.large", expErr: "'m4.large' is not one of t2.micro, t2.nano"},
+		{decl: &ParamDeclaration{Type: "cidr"}, value: ast.NewListNode([]interface{}{"10.0.0.0/24", "10.0.0"}), expErr: "'10.0.0' is not a CIDR"},
+		{decl: &ParamDeclaration{Type: "resource-id", ResourceType: "subnet"}, value: "@my-subnet"},
+		{decl: &ParamDeclaration{Type: "cidr"}, value: "$vpc"},
+	}
+	for i, tcase := range tcases {
+		err := tcase.decl.Validate(tcase.value)
+		if tcase.expErr == "" && err != nil {
+			t.Fatalf("%d: unexpected error %s", i+1, err)
+		}
+		if tcase.expErr != "" && (err == nil || err.Error() != tcase.expErr) {
+			t.Fatalf("%d: got %v, want %s", i+1, err, tcase.expErr)
+		}
+	}
+}
+
+func TestRunnerValidatesDeclaredParams(t *testing.T) {
+	runner := &Runner{
+		Template:    MustParse("# Param: vpc.cidr cidr default=10.0.0.0/16\n# Param: vpc.name string allowed=prod,staging\ncreate vpc cidr={vpc.cidr} name={vpc.name}"),
+		Log:         logger.DiscardLogger,
+		CmdLookuper: lookupMockCommand,
+		Fillers:     []map[string]interface{}{{"vpc.cidr": "10.0.0", "vpc.name": "dev"}},
+	}
+	_, err := runner.Plan()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if got, want := err.Error(), "invalid params: vpc.cidr: '10.0.0' is not a CIDR; vpc.name: 'dev' is not one of prod, staging"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
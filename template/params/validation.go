@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"regexp"
 	"strings"
 )
 
+// SimpleStringValue matches the param values written without quotes in templates
+var SimpleStringValue = regexp.MustCompile("^[a-zA-Z0-9-._:/+;~@<>*]+$") // in sync with [a-zA-Z0-9-._:/+;~@<>]+ in PEG (with ^ and $ around)
+
 func Validate(all Validators, paramValues map[string]interface{}) error {
 	msg := bytes.NewBufferString("param validation:")
 	var hasErr bool
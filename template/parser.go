@@ -137,6 +137,14 @@ func (pe *parseError) Error() string {
 	return buff.String()
 }
 
+// Position returns the line (starting at 1) and char (starting at 0) where the parsing failed
+func (pe *parseError) Position() (line, char int) {
+	if pe.invalidIndexes() {
+		return 1, 0
+	}
+	return pe.line, pe.start
+}
+
 func (pe *parseError) invalidIndexes() bool {
 	if pe.line == 0 {
 		return true
@@ -0,0 +1,174 @@
+package template
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+const planVersion = 2
+
+// Plan is a compiled template frozen with its simulated results,
+// to be reviewed before being applied
+type Plan struct {
+	Version      int                `json:"version"`
+	Created      time.Time          `json:"created"`
+	Profile      string             `json:"profile"`
+	Locale       string             `json:"region"`
+	TemplatePath string             `json:"templatePath,omitempty"`
+	Sources      map[string]string  `json:"sources"` // hashes of the template and its includes per path
+	GraphHash    string             `json:"graphHash"`
+	Template     string             `json:"template"`
+	Commands     []*PlannedCommand  `json:"commands"`
+	Additions    []*PlannedResource `json:"additions"`
+	Removals     []*PlannedResource `json:"removals"`
+	// Digest is the sha256 of all the other fields, checked on reading to detect a plan edited or corrupted
+	// since written. Anyone able to edit the plan can recompute it: it is a consistency check, not a signature
+	Digest string `json:"digest"`
+}
+
+type PlannedCommand struct {
+	Line   string `json:"line"`
+	Result string `json:"result,omitempty"`
+}
+
+type PlannedResource struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// NewPlan freezes the compiled template. Results are taken from its dry run,
+// their placeholder IDs giving the resources predicted to be added to the graph.
+func NewPlan(compiled, dryRun *Template) *Plan {
+	plan := &Plan{
+		Version:   planVersion,
+		Created:   time.Now().UTC(),
+		Sources:   make(map[string]string),
+		Template:  compiled.String(),
+		Commands:  []*PlannedCommand{},
+		Additions: []*PlannedResource{},
+		Removals:  []*PlannedResource{},
+	}
+
+	var results []*ast.CommandNode
+	if dryRun != nil {
+		results = dryRun.CommandNodesIterator()
+	}
+	for i, cmd := range compiled.CommandNodesIterator() {
+		planned := &PlannedCommand{Line: cmd.String()}
+		simulated := cmd
+		if i < len(results) {
+			simulated = results[i] // with refs resolved to placeholder IDs
+			if s, ok := simulated.CmdResult.(string); ok {
+				planned.Result = s
+			}
+		}
+		plan.Commands = append(plan.Commands, planned)
+
+		switch cmd.Action {
+		case "create", "copy", "import":
+			if planned.Result != "" {
+				plan.Additions = append(plan.Additions, &PlannedResource{Type: cmd.Entity, ID: planned.Result, Name: plannedParam(simulated, "name")})
+			}
+		case "delete":
+			id := plannedParam(simulated, "id")
+			if id == "" {
+				id = plannedParam(simulated, "name")
+			}
+			plan.Removals = append(plan.Removals, &PlannedResource{Type: cmd.Entity, ID: id})
+		}
+	}
+	return plan
+}
+
+// ParseTemplate returns the frozen template to apply
+func (p *Plan) ParseTemplate() (*Template, error) {
+	return Parse(p.Template)
+}
+
+// Write writes the plan with the digest of its content
+func (p *Plan) Write(w io.Writer) error {
+	digest, err := p.digest()
+	if err != nil {
+		return err
+	}
+	p.Digest = digest
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+func ReadPlan(r io.Reader) (*Plan, error) {
+	plan := &Plan{}
+	if err := json.NewDecoder(r).Decode(plan); err != nil {
+		return nil, fmt.Errorf("invalid plan: %s", err)
+	}
+	if plan.Version != planVersion {
+		return nil, fmt.Errorf("unsupported plan version %d (expected %d)", plan.Version, planVersion)
+	}
+	digest, err := plan.digest()
+	if err != nil {
+		return nil, err
+	}
+	if digest != plan.Digest {
+		return nil, errors.New("plan does not match its digest: the plan file was edited or corrupted since written, run `awless plan` again")
+	}
+	return plan, nil
+}
+
+func (p *Plan) digest() (string, error) {
+	content := *p
+	content.Digest = ""
+	b, err := json.Marshal(&content)
+	if err != nil {
+		return "", err
+	}
+	return Hash(b), nil
+}
+
+// Hash is the hex encoded sha256 of the given content, used to detect changes since a plan was made
+func Hash(content []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(content))
+}
+
+// References returns the string values of the params of the template commands, among which
+// the IDs of the existing resources the template acts on, sorted and without duplicates
+func (t *Template) References() []string {
+	unique := make(map[string]bool)
+	for _, cmd := range t.CommandNodesIterator() {
+		for _, v := range cmd.ToDriverParams() {
+			switch vv := v.(type) {
+			case string:
+				unique[vv] = true
+			case ast.ListNode:
+				for _, e := range vv.Elems() {
+					if n, ok := e.(ast.InterfaceNode); ok {
+						if s, ok := n.Value().(string); ok {
+							unique[s] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	var refs []string
+	for ref := range unique {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+func plannedParam(cmd *ast.CommandNode, key string) string {
+	if v, ok := cmd.ToDriverParams()[key]; ok && v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package template
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewPlan(t *testing.T) {
+	compiled := MustParse("vpc = create vpc cidr=10.0.0.0/16 name=my-vpc\nsub = create subnet cidr=10.0.0.0/24 vpc=$vpc\ndelete instance id=i-1234\ndelete subnet id=$sub")
+	dryRun := MustParse("vpc = create vpc cidr=10.0.0.0/16 name=my-vpc\nsub = create subnet cidr=10.0.0.0/24 vpc=vpc-111\ndelete instance id=i-1234\ndelete subnet id=subnet-222")
+	results := []interface{}{"vpc-111", "subnet-222", nil, nil}
+	for i, cmd := range dryRun.CommandNodesIterator() {
+		cmd.CmdResult = results[i]
+	}
+
+	plan := NewPlan(compiled, dryRun)
+	if got, want := plan.Template, compiled.String(); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	expCommands := []*PlannedCommand{
+		{Line: "create vpc cidr=10.0.0.0/16 name=my-vpc", Result: "vpc-111"},
+		{Line: "create subnet cidr=10.0.0.0/24 vpc=$vpc", Result: "subnet-222"},
+		{Line: "delete instance id=i-1234"},
+		{Line: "delete subnet id=$sub"},
+	}
+	if got, want := plan.Commands, expCommands; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	expAdditions := []*PlannedResource{{Type: "vpc", ID: "vpc-111", Name: "my-vpc"}, {Type: "subnet", ID: "subnet-222"}}
+	if got, want := plan.Additions, expAdditions; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	expRemovals := []*PlannedResource{{Type: "instance", ID: "i-1234"}, {Type: "subnet", ID: "subnet-222"}}
+	if got, want := plan.Removals, expRemovals; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	plan.Profile, plan.Locale = "default", "eu-west-1"
+	plan.Sources["/templates/infra.aws"] = Hash([]byte("create vpc"))
+	plan.GraphHash = Hash(nil)
+
+	var buff bytes.Buffer
+	if err := plan.Write(&buff); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadPlan(&buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := read.Created.Unix(), plan.Created.Unix(); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	read.Created = plan.Created
+	if got, want := read, plan; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	frozen, err := read.ParseTemplate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := frozen.String(), compiled.String(); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestReadPlan(t *testing.T) {
+	if _, err := ReadPlan(strings.NewReader(`{"version": 1}`)); err == nil || !strings.Contains(err.Error(), "unsupported plan version 1") {
+		t.Fatalf("got %v, want unsupported version error", err)
+	}
+	if _, err := ReadPlan(strings.NewReader(`create vpc`)); err == nil || !strings.Contains(err.Error(), "invalid plan") {
+		t.Fatalf("got %v, want invalid plan error", err)
+	}
+
+	plan := NewPlan(MustParse("delete instance id=i-1234"), MustParse("delete instance id=i-1234"))
+	plan.Sources["/templates/infra.aws"] = Hash([]byte("delete instance id=i-1234"))
+	plan.GraphHash = Hash(nil)
+	var buff bytes.Buffer
+	if err := plan.Write(&buff); err != nil {
+		t.Fatal(err)
+	}
+	written := buff.String()
+	edits := []struct{ from, to string }{
+		{`"template": "delete instance id=i-1234"`, `"template": "delete instance id=i-5678"`},
+		{`"line": "delete instance id=i-1234"`, `"line": "delete instance id=i-5678"`},
+		{plan.GraphHash, Hash([]byte("edited"))},
+		{plan.Sources["/templates/infra.aws"], Hash([]byte("edited"))},
+	}
+	for _, edit := range edits {
+		if !strings.Contains(written, edit.from) {
+			t.Fatalf("%s not found in plan %s", edit.from, written)
+		}
+		edited := strings.Replace(written, edit.from, edit.to, 1)
+		if _, err := ReadPlan(strings.NewReader(edited)); err == nil || !strings.Contains(err.Error(), "does not match its digest") {
+			t.Fatalf("%s: got %v, want digest error", edit.to, err)
+		}
+	}
+}
+
+func TestTemplateReferences(t *testing.T) {
+	tpl := MustParse("sub = create subnet cidr=10.0.0.0/24 vpc=vpc-111\ndelete instance id=i-1234\nattach securitygroup id=sg-1 instance=i-1234\ncreate loadbalancer name=lb subnets=[$sub, subnet-2]")
+	exp := []string{"10.0.0.0/24", "i-1234", "lb", "sg-1", "subnet-2", "vpc-111"}
+	if got, want := tpl.References(), exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
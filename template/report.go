@@ -0,0 +1,116 @@
+package template
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+const (
+	StatusOK        = "OK"
+	StatusKO        = "KO"
+	StatusScheduled = "scheduled"
+	StatusSkipped   = "skipped"
+)
+
+// RunReport is the machine readable outcome of a template run,
+// for pipelines to consume the created IDs without scraping logs
+type RunReport struct {
+	ID           string                 `json:"id,omitempty"`
+	Profile      string                 `json:"profile,omitempty"`
+	Locale       string                 `json:"region,omitempty"`
+	TemplatePath string                 `json:"templatePath,omitempty"`
+	Status       string                 `json:"status"`
+	Error        string                 `json:"error,omitempty"`
+	Commands     []*CommandReport       `json:"commands"`
+	Variables    map[string]interface{} `json:"variables"`
+	RevertID     string                 `json:"revertId,omitempty"`
+}
+
+type CommandReport struct {
+	Line   string      `json:"line"`
+	Status string      `json:"status"`
+	Error  string      `json:"error,omitempty"`
+	Result interface{} `json:"result,omitempty"`
+}
+
+// NewRunReport reports all the commands of the compiled template with their results and the variables bound to them.
+// The commands that never ran (i.e. after a failing one) are skipped. The revert ID is only given for revertible templates.
+func NewRunReport(tplExec *TemplateExecution, revertible bool) *RunReport {
+	report := &RunReport{
+		ID:           tplExec.ID,
+		Profile:      tplExec.Profile,
+		Locale:       tplExec.Locale,
+		TemplatePath: tplExec.Path,
+		Status:       StatusOK,
+		Commands:     []*CommandReport{},
+		Variables:    make(map[string]interface{}),
+	}
+	if revertible {
+		report.RevertID = tplExec.ID
+	}
+
+	ran := tplExec.CommandNodesIterator()
+	all := ran
+	if tplExec.compiled != nil {
+		all = tplExec.compiled.CommandNodesIterator()
+	}
+	for i, cmd := range all {
+		if i >= len(ran) {
+			report.Commands = append(report.Commands, &CommandReport{Line: cmd.String(), Status: StatusSkipped})
+			continue
+		}
+		cmd = ran[i]
+		cmdReport := &CommandReport{Line: cmd.String(), Status: StatusOK, Result: cmd.CmdResult}
+		if cmd.CmdErr != nil {
+			cmdReport.Status = StatusKO
+			cmdReport.Error = cmd.CmdErr.Error()
+			report.Status = StatusKO
+		}
+		report.Commands = append(report.Commands, cmdReport)
+	}
+
+	for _, sts := range tplExec.Statements {
+		if decl, ok := sts.Node.(*ast.DeclarationNode); ok {
+			if cmd, ok := decl.Expr.(*ast.CommandNode); ok && cmd.CmdResult != nil {
+				report.Variables[decl.Ident] = cmd.CmdResult
+			}
+		}
+	}
+	return report
+}
+
+// NewScheduledRunReport reports a template sent to the scheduler (i.e. `awless run --run-in`),
+// whose commands have not run yet
+func NewScheduledRunReport(tplExec *TemplateExecution) *RunReport {
+	report := &RunReport{
+		ID:           tplExec.ID,
+		Profile:      tplExec.Profile,
+		Locale:       tplExec.Locale,
+		TemplatePath: tplExec.Path,
+		Status:       StatusScheduled,
+		Commands:     []*CommandReport{},
+		Variables:    make(map[string]interface{}),
+	}
+	for _, cmd := range tplExec.CommandNodesIterator() {
+		report.Commands = append(report.Commands, &CommandReport{Line: cmd.String(), Status: StatusScheduled})
+	}
+	return report
+}
+
+// NewFailedRunReport reports a template that could not be run (i.e. invalid or failing its dry run)
+func NewFailedRunReport(err error) *RunReport {
+	return &RunReport{
+		Status:    StatusKO,
+		Error:     err.Error(),
+		Commands:  []*CommandReport{},
+		Variables: make(map[string]interface{}),
+	}
+}
+
+func (r *RunReport) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
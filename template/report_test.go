@@ -0,0 +1,95 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRunReport(t *testing.T) {
+	tpl := MustParse("vpc = create vpc cidr=10.0.0.0/16\nsubnet = create subnet cidr=10.0.0.0/24 vpc=$vpc\ncreate tag resource=$subnet key=env value=prod")
+	tpl.ID = "01BX5ZZKBKACTAV9WEVGEMMVRZ"
+	cmds := tpl.CommandNodesIterator()
+	cmds[0].CmdResult = "vpc-1234"
+	cmds[1].CmdResult = "subnet-5678"
+	cmds[2].CmdErr = errors.New("throttled")
+
+	tplExec := &TemplateExecution{Template: tpl, Locale: "eu-west-1", Profile: "default", Path: "infra.aws"}
+	var buff bytes.Buffer
+	if err := NewRunReport(tplExec, true).Write(&buff); err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(buff.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"id":           "01BX5ZZKBKACTAV9WEVGEMMVRZ",
+		"profile":      "default",
+		"region":       "eu-west-1",
+		"templatePath": "infra.aws",
+		"status":       "KO",
+		"commands": []interface{}{
+			map[string]interface{}{"line": "create vpc cidr=10.0.0.0/16", "status": "OK", "result": "vpc-1234"},
+			map[string]interface{}{"line": "create subnet cidr=10.0.0.0/24 vpc=$vpc", "status": "OK", "result": "subnet-5678"},
+			map[string]interface{}{"line": "create tag key=env resource=$subnet value=prod", "status": "KO", "error": "throttled"},
+		},
+		"variables": map[string]interface{}{"vpc": "vpc-1234", "subnet": "subnet-5678"},
+		"revertId":  "01BX5ZZKBKACTAV9WEVGEMMVRZ",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got\n%#v\nwant\n%#v", got, want)
+	}
+
+	cmds[2].CmdErr = nil
+	if report := NewRunReport(tplExec, false); report.Status != StatusOK || report.RevertID != "" {
+		t.Fatalf("got %#v", report)
+	}
+
+	scheduled := NewScheduledRunReport(tplExec)
+	if scheduled.Status != StatusScheduled || scheduled.ID != tpl.ID || len(scheduled.Variables) != 0 {
+		t.Fatalf("got %#v", scheduled)
+	}
+	for _, cmd := range scheduled.Commands {
+		if cmd.Status != StatusScheduled || cmd.Result != nil {
+			t.Fatalf("got %#v", cmd)
+		}
+	}
+	if got, want := len(scheduled.Commands), 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	failed := NewFailedRunReport(errors.New("Dry run failed"))
+	buff.Reset()
+	if err := failed.Write(&buff); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buff.String(), "{\n  \"status\": \"KO\",\n  \"error\": \"Dry run failed\",\n  \"commands\": [],\n  \"variables\": {}\n}\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestRunReportSkippedCommands(t *testing.T) {
+	compiled := MustParse("vpc = create vpc cidr=10.0.0.0/16\nsubnet = create subnet cidr=10.0.0.0/24 vpc=$vpc\ncreate tag resource=$subnet key=env value=prod")
+	ran := MustParse("vpc = create vpc cidr=10.0.0.0/16\nsubnet = create subnet cidr=10.0.0.0/24 vpc=$vpc")
+	cmds := ran.CommandNodesIterator()
+	cmds[0].CmdResult = "vpc-1234"
+	cmds[1].CmdErr = errors.New("throttled")
+
+	report := NewRunReport(&TemplateExecution{Template: ran, compiled: compiled}, false)
+	if got, want := report.Status, StatusKO; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	var statuses []string
+	for _, cmd := range report.Commands {
+		statuses = append(statuses, cmd.Status)
+	}
+	if got, want := statuses, []string{StatusOK, StatusKO, StatusSkipped}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := report.Commands[2].Line, "create tag key=env resource=$subnet value=prod"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
@@ -9,6 +9,14 @@ import (
 	"github.com/wallix/awless/template/internal/ast"
 )
 
+func lookupMockCommand(tokens ...string) interface{} {
+	newCommandFunc := awsspec.MockAWSSessionFactory.Build(strings.Join(tokens, ""))
+	if newCommandFunc == nil {
+		return nil
+	}
+	return newCommandFunc()
+}
+
 func TestRevertOneliner(t *testing.T) {
 	tcases := []struct {
 		in, exp string
@@ -29,7 +37,7 @@ func TestRevertOneliner(t *testing.T) {
 	}
 
 	for _, tcase := range tcases {
-		reverted, err := MustParse(tcase.in).Revert()
+		reverted, err := MustParse(tcase.in).Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -48,7 +56,7 @@ func TestRevertTemplate(t *testing.T) {
 		for _, cmd := range tpl.CommandNodesIterator() {
 			cmd.CmdResult = "i-54321"
 		}
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -69,7 +77,7 @@ func TestRevertTemplate(t *testing.T) {
 				cmd.CmdResult = "i-54321"
 			}
 		}
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -100,7 +108,7 @@ func TestRevertTemplate(t *testing.T) {
 			}
 		}
 
-		reverted, err := compiled.Revert()
+		reverted, err := compiled.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -132,7 +140,7 @@ inst1 = create instance
 				cmd.CmdResult = "i-1"
 			}
 		}
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -153,7 +161,7 @@ delete securitygroup id=securitygroup-1`
 		for _, cmd := range tpl.CommandNodesIterator() {
 			cmd.CmdResult = "sg-54321"
 		}
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -170,7 +178,7 @@ delete securitygroup id=sg-54321`
 		for _, cmd := range tpl.CommandNodesIterator() {
 			cmd.CmdResult = "ami-12345678"
 		}
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -183,7 +191,7 @@ delete securitygroup id=sg-54321`
 
 	t.Run("Revert detach a volume removes the force param", func(t *testing.T) {
 		tpl := MustParse("detach volume device=/dev/sdh force=true id=vol-12345 instance=i-12345")
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -196,7 +204,7 @@ delete securitygroup id=sg-54321`
 
 	t.Run("Revert attach a volume waits that the volume is available", func(t *testing.T) {
 		tpl := MustParse("detach volume device=/dev/sdh id=vol-12345 instance=i-12345\nattach volume device=/dev/sdh id=vol-12345 instance=i-12345")
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -211,7 +219,7 @@ attach volume device=/dev/sdh id=vol-12345 instance=i-12345`
 
 	t.Run("Revert create route", func(t *testing.T) {
 		tpl := MustParse("create route cidr=0.0.0.0/0 gateway=igw-12345 table=rtb-12345")
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -224,7 +232,7 @@ attach volume device=/dev/sdh id=vol-12345 instance=i-12345`
 
 	t.Run("Revert attach instance", func(t *testing.T) {
 		tpl := MustParse("attach instance id=i-123456 port=80 targetgroup=mytargetgrouparn")
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -240,7 +248,7 @@ attach volume device=/dev/sdh id=vol-12345 instance=i-12345`
 		for _, cmd := range tpl.CommandNodesIterator() {
 			cmd.CmdResult = "my-scalinggroup"
 		}
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -258,7 +266,7 @@ delete scalinggroup force=true name=my-scalinggroup`
 		for _, cmd := range tpl.CommandNodesIterator() {
 			cmd.CmdResult = "my-accesskey"
 		}
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -274,7 +282,7 @@ delete scalinggroup force=true name=my-scalinggroup`
 		for _, cmd := range tpl.CommandNodesIterator() {
 			cmd.CmdResult = "my-queue-url"
 		}
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -290,7 +298,7 @@ delete scalinggroup force=true name=my-scalinggroup`
 		for _, cmd := range tpl.CommandNodesIterator() {
 			cmd.CmdResult = "my queue url"
 		}
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -303,7 +311,7 @@ delete scalinggroup force=true name=my-scalinggroup`
 
 	t.Run("Revert create record", func(t *testing.T) {
 		tpl := MustParse("create record comment='my test record' name=test.awlesstest.io. ttl=60 type=A value=1.2.3.4 zone=/hostedzone/Z29L20HGD4CX07")
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -324,7 +332,7 @@ delete scalinggroup force=true name=my-scalinggroup`
 				cmd.CmdResult = "my-database"
 			}
 		}
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -339,7 +347,7 @@ delete dbsubnetgroup name=my-dbsubgroup`
 
 	t.Run("Revert start containertask type=service", func(t *testing.T) {
 		tpl := MustParse("start containertask cluster=cl desired-count=2 name=taskname deployment-name=dpname type=service")
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -354,7 +362,7 @@ stop containertask cluster=cl deployment-name=dpname type=service`
 	t.Run("Revert start containertask type=task", func(t *testing.T) {
 		tpl := MustParse("start containertask type=task cluster=cl desired-count=2 name=taskname")
 		tpl.CommandNodesIterator()[0].CmdResult = "my-task-arn"
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -368,7 +376,7 @@ stop containertask cluster=cl deployment-name=dpname type=service`
 	t.Run("Revert create certificate", func(t *testing.T) {
 		tpl := MustParse("create certificate domain=test.awless.io")
 		tpl.CommandNodesIterator()[0].CmdResult = "my-certificate-arn"
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -382,7 +390,7 @@ stop containertask cluster=cl deployment-name=dpname type=service`
 	t.Run("Revert create policy", func(t *testing.T) {
 		tpl := MustParse("create policy name=mypolicy")
 		tpl.CommandNodesIterator()[0].CmdResult = "my-policy-arn"
-		reverted, err := tpl.Revert()
+		reverted, err := tpl.Revert(lookupMockCommand)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -394,10 +402,31 @@ stop containertask cluster=cl deployment-name=dpname type=service`
 	})
 }
 
+func TestRevertUpdateFromSnapshot(t *testing.T) {
+	tplExec := &TemplateExecution{}
+	err := tplExec.UnmarshalJSON([]byte(`{"id": "123456", "commands": [
+		{"line": "update subnet id=subnet-1234 public=true", "snapshot": {"public": false}},
+		{"line": "update scalinggroup name=my-group max-size=12 min-size=10", "snapshot": {"max-size": 2, "min-size": 1}},
+		{"line": "update instance id=i-1234 type=t2.micro"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reverted, err := tplExec.Revert(lookupMockCommand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "update scalinggroup max-size=2 min-size=1 name=my-group\nupdate subnet id=subnet-1234 public=false"
+	if got, want := reverted.String(), exp; got != want {
+		t.Fatalf("got: %s\nwant: %s\n", got, want)
+	}
+}
+
 func TestCmdNodeIsRevertible(t *testing.T) {
 	tcases := []struct {
 		line, result string
 		params       map[string]interface{}
+		snapshot     map[string]interface{}
 		err          error
 		revertible   bool
 	}{
@@ -407,7 +436,8 @@ func TestCmdNodeIsRevertible(t *testing.T) {
 		{line: "create vpc", revertible: false},
 		{line: "start instance", revertible: false},
 		{line: "create vpc", result: "any", revertible: true},
-		{line: "stop instance", result: "any", revertible: true},
+		{line: "stop instance", result: "any", params: map[string]interface{}{"ids": "i-12345"}, revertible: true},
+		{line: "stop instance", result: "any", revertible: false},
 		{line: "attach policy", revertible: true},
 		{line: "detach policy", revertible: true},
 		{line: "create record", revertible: true},
@@ -418,16 +448,18 @@ func TestCmdNodeIsRevertible(t *testing.T) {
 		{line: "stop alarm", revertible: true},
 		{line: "start containertask", params: map[string]interface{}{"type": "service"}, revertible: true},
 		{line: "start containertask", params: map[string]interface{}{"type": "task"}, revertible: true},
+		{line: "update subnet", revertible: false},
+		{line: "update subnet", params: map[string]interface{}{"id": "any"}, snapshot: map[string]interface{}{"public": true}, revertible: true},
 	}
 
 	for _, tc := range tcases {
 		splits := strings.SplitN(tc.line, " ", 2)
 		action, entity := splits[0], splits[1]
-		cmd := &ast.CommandNode{Action: action, Entity: entity, CmdResult: tc.result, CmdErr: tc.err}
+		cmd := &ast.CommandNode{Action: action, Entity: entity, CmdResult: tc.result, CmdErr: tc.err, CmdSnapshot: tc.snapshot}
 		if tc.params != nil {
 			cmd.ParamNodes = tc.params
 		}
-		if tc.revertible != isRevertible(cmd) {
+		if tc.revertible != isRevertible(cmd, lookupMockCommand) {
 			t.Fatalf("expected '%s' to have revertible=%t", cmd, tc.revertible)
 		}
 	}
@@ -17,6 +17,7 @@ type Runner struct {
 	AliasFunc                              func(paramPath, alias string) string
 	MissingHolesFunc                       func(string, []string, bool) string
 	CmdLookuper                            func(tokens ...string) interface{}
+	IncludeFunc                            func(path, from string) (string, string, error)
 	Validators                             []Validator
 	ParamsSuggested                        int
 
@@ -25,6 +26,50 @@ type Runner struct {
 }
 
 func (ru *Runner) Run() error {
+	tplExec, _, renv, err := ru.dryRun()
+	if err != nil {
+		return err
+	}
+
+	ok, err := ru.BeforeRun(tplExec)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		tplExec.compiled = tplExec.Template
+		tplExec.Template, err = tplExec.Template.Run(renv)
+		if err != nil {
+			logger.Errorf("Running template error: %s", err)
+		}
+		if err := ru.AfterRun(tplExec); err != nil {
+			return err
+		}
+	}
+
+	if tplExec.Stats().KOCount > 0 {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// Plan compiles and dry runs the template, without running it,
+// to freeze it with its simulated results
+func (ru *Runner) Plan() (*Plan, error) {
+	tplExec, simulated, _, err := ru.dryRun()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := NewPlan(tplExec.Template, simulated)
+	plan.Profile = ru.Profile
+	plan.Locale = ru.Locale
+	plan.TemplatePath = ru.TemplatePath
+	return plan, nil
+}
+
+func (ru *Runner) dryRun() (*TemplateExecution, *Template, env.Running, error) {
 	tplExec := &TemplateExecution{
 		Template: ru.Template,
 		Path:     ru.TemplatePath,
@@ -34,14 +79,27 @@ func (ru *Runner) Run() error {
 	}
 	tplExec.SetMessage(ru.Message)
 
+	decls, err := ru.Template.ParamDeclarations()
+	if err != nil {
+		return tplExec, nil, nil, err
+	}
+	declaredDefaults, err := decls.Defaults()
+	if err != nil {
+		return tplExec, nil, nil, err
+	}
+	// declared defaults have the lowest priority
+	fillers := append([]map[string]interface{}{declaredDefaults}, ru.Fillers...)
+	if err = decls.ValidateFillers(fillers...); err != nil {
+		return tplExec, nil, nil, err
+	}
+
 	cenv := NewEnv().WithAliasFunc(ru.AliasFunc).WithMissingHolesFunc(ru.MissingHolesFunc).
-		WithLookupCommandFunc(ru.CmdLookuper).WithLog(ru.Log).WithParamsMode(ru.ParamsSuggested).Build()
-	cenv.Push(env.FILLERS, ru.Fillers...)
+		WithLookupCommandFunc(ru.CmdLookuper).WithIncludeFunc(ru.IncludeFunc).WithLog(ru.Log).WithParamsMode(ru.ParamsSuggested).Build()
+	cenv.Push(env.FILLERS, fillers...)
 
-	var err error
 	tplExec.Template, cenv, err = Compile(tplExec.Template, cenv, NewRunnerCompileMode)
 	if err != nil {
-		return err
+		return tplExec, nil, nil, err
 	}
 
 	tplExec.Fillers = cenv.Get(env.PROCESSED_FILLERS)
@@ -61,7 +119,8 @@ func (ru *Runner) Run() error {
 	}
 
 	renv := NewRunEnv(cenv)
-	if _, err = tplExec.Template.DryRun(renv); err != nil {
+	simulated, err := tplExec.Template.DryRun(renv)
+	if err != nil {
 		switch t := err.(type) {
 		case *Errors:
 			errs, _ := t.Errors()
@@ -71,27 +130,8 @@ func (ru *Runner) Run() error {
 		default:
 			logger.Error(err)
 		}
-		return errors.New("Dry run failed")
+		return tplExec, simulated, renv, errors.New("Dry run failed")
 	}
 
-	ok, err := ru.BeforeRun(tplExec)
-	if err != nil {
-		return err
-	}
-
-	if ok {
-		tplExec.Template, err = tplExec.Template.Run(renv)
-		if err != nil {
-			logger.Errorf("Running template error: %s", err)
-		}
-		if err := ru.AfterRun(tplExec); err != nil {
-			return err
-		}
-	}
-
-	if tplExec.Stats().KOCount > 0 {
-		os.Exit(1)
-	}
-
-	return nil
+	return tplExec, simulated, renv, nil
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/oklog/ulid"
 	"github.com/wallix/awless/template/env"
 	"github.com/wallix/awless/template/internal/ast"
+	"github.com/wallix/awless/template/params"
 )
 
 type Template struct {
@@ -97,6 +98,13 @@ func processCmdNode(renv env.Running, n *ast.CommandNode) bool {
 		n.CmdResult, n.CmdErr = n.Command.Run(renv, n.ToDriverParams())
 		n.CmdErr = prefixError(n.CmdErr, fmt.Sprintf("dry run: %s %s", n.Action, n.Entity))
 	} else {
+		if s, ok := n.Command.(snapshotter); ok {
+			snapshot, err := s.SnapshotState(renv, n.ToDriverParams())
+			if err != nil {
+				renv.Log().Warningf("%s %s: cannot snapshot current state, command will not be revertible: %s", n.Action, n.Entity, err)
+			}
+			n.CmdSnapshot = snapshot
+		}
 		n.CmdResult, n.CmdErr = n.Run(renv, n.ToDriverParams())
 		var res, status string
 		if n.CmdResult != nil {
@@ -255,5 +263,5 @@ func (d *Errors) Error() string {
 }
 
 func MatchStringParamValue(s string) bool {
-	return ast.SimpleStringValue.MatchString(s)
+	return params.SimpleStringValue.MatchString(s)
 }